@@ -16,6 +16,7 @@ type (
 		Redis    `yaml:"redis"`
 		OAuth    `yaml:"oauth"`
 		Minio    `yaml:"minio"`
+		Pastes   `yaml:"pastes"`
 	}
 
 	App struct {
@@ -25,6 +26,8 @@ type (
 
 	HTTP struct {
 		Port string `yaml:"port" env:"PORT"`
+		// BaseURL is the public scheme and host of the service used in absolute links.
+		BaseURL string `yaml:"base_url" env:"BASE_URL" env-default:"http://localhost:8080"`
 	}
 
 	Log struct {
@@ -46,6 +49,27 @@ type (
 		ActionTimeout time.Duration `yaml:"action_timeout" env:"MINIO_ACTION_TIMEOUT"`
 	}
 
+	Pastes struct {
+		ViewsFlushInterval time.Duration `yaml:"views_flush_interval" env:"PASTES_VIEWS_FLUSH_INTERVAL" env-default:"10s"`
+		StarsFlushInterval time.Duration `yaml:"stars_flush_interval" env:"PASTES_STARS_FLUSH_INTERVAL" env-default:"10s"`
+		ReaperInterval     time.Duration `yaml:"reaper_interval" env:"PASTES_REAPER_INTERVAL" env-default:"1m"`
+		IndexInterval      time.Duration `yaml:"index_interval" env:"PASTES_INDEX_INTERVAL" env-default:"1h"`
+		Expiration         `yaml:"expiration"`
+		Query              `yaml:"query"`
+	}
+
+	Query struct {
+		Timeout   time.Duration `yaml:"timeout" env:"PASTES_QUERY_TIMEOUT" env-default:"1s"`
+		MaxOutput int           `yaml:"max_output" env:"PASTES_QUERY_MAX_OUTPUT" env-default:"1048576"`
+	}
+
+	Expiration struct {
+		Min        time.Duration `yaml:"min" env:"PASTES_EXPIRATION_MIN" env-default:"5m"`
+		Max        time.Duration `yaml:"max" env:"PASTES_EXPIRATION_MAX" env-default:"17520h"`
+		Default    time.Duration `yaml:"default" env:"PASTES_EXPIRATION_DEFAULT" env-default:"17520h"`
+		AllowNever bool          `yaml:"allow_never" env:"PASTES_EXPIRATION_ALLOW_NEVER" env-default:"true"`
+	}
+
 	OAuth struct {
 		ClientID     string `yaml:"client_id" env:"OAUTH_CLIENT_ID"`
 		ClientSecret string `yaml:"client_secret" env:"OAUTH_CLIENT_SECRET"`
@@ -83,8 +83,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/pastes": {
+        "/collections": {
             "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Создаёт упорядоченную коллекцию паст текущего пользователя, доступную по собственному хешу.\nПользователь должен иметь доступ ко всем пастам коллекции.",
                 "consumes": [
                     "application/json"
                 ],
@@ -92,17 +98,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pastes"
+                    "collections"
                 ],
-                "summary": "Создание нововой пасты",
+                "summary": "Создание коллекции",
                 "parameters": [
                     {
-                        "description": "Паста",
-                        "name": "paste",
+                        "description": "Коллекция",
+                        "name": "collection",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreatePasteBody"
+                            "$ref": "#/definitions/CreateCollectionBody"
                         }
                     }
                 ],
@@ -115,10 +121,10 @@ const docTemplate = `{
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "paste": {
-                                            "$ref": "#/definitions/PasteInfo"
+                                        "collection": {
+                                            "$ref": "#/definitions/Collection"
                                         },
-                                        "url": {
+                                        "location": {
                                             "type": "string"
                                         }
                                     }
@@ -134,7 +140,40 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
                                     "type": "string"
                                 }
                             }
@@ -145,19 +184,13 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "errors": {
+                                "error": {
                                     "type": "object",
                                     "properties": {
                                         "field": {
                                             "type": "string"
-                                        },
-                                        "message": {
-                                            "type": "string"
                                         }
                                     }
-                                },
-                                "message": {
-                                    "type": "string"
                                 }
                             }
                         }
@@ -167,7 +200,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
+                                "error": {
                                     "type": "string"
                                 }
                             }
@@ -176,20 +209,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/pastes/{hash}": {
+        "/collections/{hash}": {
             "get": {
-                "description": "Получение посты по хешу.\nЕсли паста защищена паролем, то нужно обратиться к ` + "`" + `/pastes/{hash}/unlock` + "`" + `, чтобы получить доступ к ней.",
+                "description": "Возвращает коллекцию с метаданными паст в порядке следования без их текстов.\nСгоревшие пасты и приватные пасты, к которым у пользователя нет доступа, не возвращаются.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pastes"
+                    "collections"
                 ],
-                "summary": "Получениие пасты.",
+                "summary": "Получение коллекции",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Хеш пасты",
+                        "description": "Хеш коллекции",
                         "name": "hash",
                         "in": "path",
                         "required": true
@@ -204,8 +237,87 @@ const docTemplate = `{
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "paste": {
-                                            "$ref": "#/definitions/PasteInfo"
+                                        "collection": {
+                                            "$ref": "#/definitions/Collection"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Переименовывает коллекцию или меняет её видимость. Изменить коллекцию может только её владелец.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Изменение коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш коллекции",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменения",
+                        "name": "collection",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCollectionBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "collection": {
+                                            "$ref": "#/definitions/Collection"
                                         }
                                     }
                                 },
@@ -215,6 +327,17 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -237,6 +360,22 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -256,20 +395,18 @@ const docTemplate = `{
                         "Bearer": []
                     }
                 ],
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Удаляет коллекцию, пасты коллекции остаются. Удалить коллекцию может только её владелец.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pastes"
+                    "collections"
                 ],
-                "summary": "Удаление пасты по хешу",
+                "summary": "Удаление коллекции",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Хеш пасты",
+                        "description": "Хеш коллекции",
                         "name": "hash",
                         "in": "path",
                         "required": true
@@ -321,10 +458,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/pastes/{hash}/unlock": {
-            "post": {
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Переименовывает коллекцию или меняет её видимость. Изменить коллекцию может только её владелец.",
                 "consumes": [
                     "application/json"
                 ],
@@ -332,24 +473,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pastes"
+                    "collections"
                 ],
-                "summary": "Получение доступа к пасте с паролем.",
+                "summary": "Изменение коллекции",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Хеш пасты",
+                        "description": "Хеш коллекции",
                         "name": "hash",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Пароль",
-                        "name": "credentials",
+                        "description": "Изменения",
+                        "name": "collection",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UnlockPasteBody"
+                            "$ref": "#/definitions/UpdateCollectionBody"
                         }
                     }
                 ],
@@ -362,8 +503,8 @@ const docTemplate = `{
                                 "data": {
                                     "type": "object",
                                     "properties": {
-                                        "paste": {
-                                            "$ref": "#/definitions/PasteInfo"
+                                        "collection": {
+                                            "$ref": "#/definitions/Collection"
                                         }
                                     }
                                 },
@@ -373,6 +514,17 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
@@ -395,6 +547,22 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -409,8 +577,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/token": {
+        "/collections/{hash}/grants": {
             "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Выдаёт пользователю доступ к приватной коллекции. Доступ к приватным пастам коллекции\nвыдаётся отдельно. Выдать доступ может только владелец коллекции.",
                 "consumes": [
                     "application/json"
                 ],
@@ -418,17 +592,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "collections"
                 ],
-                "summary": "Получения авторизационных данных",
+                "summary": "Выдача доступа к коллекции",
                 "parameters": [
                     {
-                        "description": "Уникальный код, сгенерированный OAuth2 приложением",
-                        "name": "code",
+                        "type": "string",
+                        "description": "Хеш коллекции",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Пользователь",
+                        "name": "grant",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateTokenRequest"
+                            "$ref": "#/definitions/GrantAccessBody"
                         }
                     }
                 ],
@@ -449,88 +630,5349 @@ const docTemplate = `{
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
+                                "error": {
                                     "type": "string"
                                 }
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "message": {
+                                "error": {
                                     "type": "string"
                                 }
                             }
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "CreatePasteBody": {
-            "description": "Тело запроса для создания пасты.",
-            "type": "object",
-            "required": [
-                "format",
-                "text"
-            ],
-            "properties": {
-                "expires": {
-                    "description": "Время, через которое паста становится не доступной",
-                    "type": "string",
-                    "enum": [
-                        "30m",
-                        "1h",
-                        "168h",
-                        "5040h"
-                    ],
-                    "example": "30m"
-                },
-                "format": {
-                    "description": "Формат текста",
-                    "type": "string",
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{hash}/grants/{username}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Отзывает доступ пользователя к приватной коллекции. Отозвать доступ может только владелец коллекции.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Отзыв доступа к коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш коллекции",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{hash}/pastes": {
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Задаёт новый порядок паст коллекции, в запросе должны быть перечислены все пасты коллекции.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Изменение порядка паст коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш коллекции",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Порядок паст",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/ReorderCollectionBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Добавляет пасту в конец коллекции. Владелец коллекции должен иметь доступ к пасте.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Добавление пасты в коллекцию",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш коллекции",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Паста",
+                        "name": "paste",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddCollectionPasteBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/collections/{hash}/pastes/{paste}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Убирает пасту из коллекции, сама паста остаётся.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "collections"
+                ],
+                "summary": "Удаление пасты из коллекции",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш коллекции",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "paste",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/formats/detect": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "formats"
+                ],
+                "summary": "Определение формата текста без создания пасты",
+                "parameters": [
+                    {
+                        "description": "Текст",
+                        "name": "text",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/DetectFormatBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "format": {
+                                            "$ref": "#/definitions/FormatDetection"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "errors": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes": {
+            "get": {
+                "description": "Возвращает метаданные не сгоревших паст с тегами от новых к старым.\nАнонимным пользователям доступны только публичные пасты, авторизованным также\nих собственные пасты и приватные пасты, к которым им выдан доступ.\nБез тегов возвращаются все доступные пасты.\nСледующая страница запрашивается с курсором ` + "`" + `next` + "`" + ` из ответа.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Пасты по тегам",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Теги пасты",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "any"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Пасты со всеми тегами или с любым из них",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Число паст на странице, от 1 до 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "pastes": {
+                                            "$ref": "#/definitions/TaggedPastesPage"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Создание нововой пасты",
+                "parameters": [
+                    {
+                        "description": "Паста",
+                        "name": "paste",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreatePasteBody"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Отклонять текст, не соответствующий формату json, yaml, toml или xml, по умолчанию true",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "paste": {
+                                            "$ref": "#/definitions/PasteInfo"
+                                        },
+                                        "url": {
+                                            "type": "string"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "errors": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        },
+                                        "message": {
+                                            "type": "string"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/diff": {
+            "get": {
+                "description": "Если пасты защищены паролем, то их нужно передать в заголовках ` + "`" + `X-Paste-Password-A` + "`" + ` и ` + "`" + `X-Paste-Password-B` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Разница между двумя пастами.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш исходной пасты",
+                        "name": "a",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Хеш новой пасты",
+                        "name": "b",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль исходной пасты",
+                        "name": "X-Paste-Password-A",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль новой пасты",
+                        "name": "X-Paste-Password-B",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "diff": {
+                                            "$ref": "#/definitions/DiffInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/recent": {
+            "get": {
+                "description": "Возвращает последние публичные пасты всех пользователей от новых к старым с началом текста.\nПасты с паролем, сжигаемые после прочтения и с ограничением просмотров в ленту не попадают.\nПоддерживаются условные запросы с заголовками ` + "`" + `If-None-Match` + "`" + ` и ` + "`" + `If-Modified-Since` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Лента новых паст",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Число паст, от 1 до 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "pastes": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/FeedEntry"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/search": {
+            "get": {
+                "description": "Полнотекстовый поиск по заголовкам и текстам паст, включая все файлы пасты.\nЗапрос ` + "`" + `q` + "`" + ` поддерживает синтаксис веб-поиска: фразы в кавычках, ` + "`" + `or` + "`" + ` и исключение слов через ` + "`" + `-` + "`" + `.\nЗаголовки, похожие на запрос, находятся даже с опечатками. Результаты упорядочены по релевантности\nи содержат фрагменты текста с позициями совпадений. Ищутся только публичные пасты, а также\nскрытые и приватные пасты текущего пользователя. Пасты с паролем, сжигаемые после прочтения\nи с ограничением просмотров не ищутся. Следующая страница запрашивается с курсором ` + "`" + `next` + "`" + ` из ответа.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Поиск паст",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Поисковый запрос",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Формат пасты",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя автора пасты",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Создана не раньше, RFC 3339 или YYYY-MM-DD",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Создана раньше, RFC 3339 или YYYY-MM-DD включительно",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Число результатов на странице, от 1 до 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "search": {
+                                            "$ref": "#/definitions/SearchPage"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}": {
+            "get": {
+                "description": "Получение посты по хешу.\nЕсли паста защищена паролем, то нужно обратиться к ` + "`" + `/pastes/{hash}/unlock` + "`" + `, чтобы получить доступ к ней.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получениие пасты.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "paste": {
+                                            "$ref": "#/definitions/PasteInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Изменяет текст, название, формат, пароль и время жизни пасты.\nПустые поля остаются без изменений. Изменять пасту может только её автор.\nТексты файлов многофайловой пасты изменяются по именам файлов, неизвестное имя файла возвращает 422.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Изменение пасты по хешу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменения",
+                        "name": "paste",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdatePasteBody"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Отклонять текст, не соответствующий формату json, yaml, toml или xml, по умолчанию true",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "paste": {
+                                            "$ref": "#/definitions/PasteInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Удаление пасты по хешу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Изменяет текст, название, формат, пароль и время жизни пасты.\nПустые поля остаются без изменений. Изменять пасту может только её автор.\nТексты файлов многофайловой пасты изменяются по именам файлов, неизвестное имя файла возвращает 422.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Изменение пасты по хешу",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Изменения",
+                        "name": "paste",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdatePasteBody"
+                        }
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Отклонять текст, не соответствующий формату json, yaml, toml или xml, по умолчанию true",
+                        "name": "strict",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "paste": {
+                                            "$ref": "#/definitions/PasteInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/comments": {
+            "get": {
+                "description": "Возвращает ветки комментариев к пасте от старых к новым, ответы вложены в комментарии.\nЕсли паста защищена паролем, то его нужно передать в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Комментарии к пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "comments": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/Comment"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Комментирует пасту целиком или диапазон строк её текста, либо отвечает на комментарий.\nСтроки относятся к текущей ревизии пасты.\nЕсли паста защищена паролем, то его нужно передать в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Создание комментария к пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateCommentBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "comment": {
+                                            "$ref": "#/definitions/Comment"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/comments/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Удаляет комментарий. Удалить комментарий может его автор или автор пасты.\nКомментарий с ответами остаётся в ветке без текста.\nЕсли паста защищена паролем, то его нужно передать в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Удаление комментария к пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Изменяет текст комментария. Изменить комментарий может только его автор.\nЕсли паста защищена паролем, то его нужно передать в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Изменение комментария к пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Идентификатор комментария",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Комментарий",
+                        "name": "comment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateCommentBody"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "comment": {
+                                            "$ref": "#/definitions/Comment"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/convert": {
+            "get": {
+                "description": "Конвертирует текст пасты формата json, yaml, toml или xml в формат ` + "`" + `to` + "`" + `, по умолчанию в формат пасты.\nТекст форматируется с отступами, с параметром ` + "`" + `minify=1` + "`" + ` записывается компактно. Порядок ключей сохраняется,\nкомментарии сохраняются при конвертации из yaml в yaml.\nЗапрос POST с параметром ` + "`" + `save=1` + "`" + ` сохраняет результат как новую пасту, связанную с исходной,\nс паролем, сжиганием после прочтения и лимитом просмотров исходной пасты,\nи возвращает её в формате ` + "`" + `{message, data: {location, paste}}` + "`" + `.\nПароль защищённой пасты передаётся в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Конвертация пасты в другой формат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "yaml",
+                            "toml",
+                            "xml"
+                        ],
+                        "type": "string",
+                        "description": "Формат результата",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Записать компактно",
+                        "name": "minify",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сохранить как новую пасту, только для POST",
+                        "name": "save",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Конвертирует текст пасты формата json, yaml, toml или xml в формат ` + "`" + `to` + "`" + `, по умолчанию в формат пасты.\nТекст форматируется с отступами, с параметром ` + "`" + `minify=1` + "`" + ` записывается компактно. Порядок ключей сохраняется,\nкомментарии сохраняются при конвертации из yaml в yaml.\nЗапрос POST с параметром ` + "`" + `save=1` + "`" + ` сохраняет результат как новую пасту, связанную с исходной,\nс паролем, сжиганием после прочтения и лимитом просмотров исходной пасты,\nи возвращает её в формате ` + "`" + `{message, data: {location, paste}}` + "`" + `.\nПароль защищённой пасты передаётся в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Конвертация пасты в другой формат",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "yaml",
+                            "toml",
+                            "xml"
+                        ],
+                        "type": "string",
+                        "description": "Формат результата",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Записать компактно",
+                        "name": "minify",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Сохранить как новую пасту, только для POST",
+                        "name": "save",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/diff": {
+            "get": {
+                "description": "По умолчанию сравнивается текущая ревизия с предыдущей.\nЕсли паста защищена паролем, то его нужно передать в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Разница между ревизиями пасты.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Исходная ревизия",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Новая ревизия",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "diff": {
+                                            "$ref": "#/definitions/DiffInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/extend": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Устанавливает новую дату сгорания пасты, продлить можно только ещё не сгоревшую пасту.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Продление пасты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новое время жизни",
+                        "name": "expiration",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/ExtendPasteBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "paste": {
+                                            "$ref": "#/definitions/PasteMeta"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/fork": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Копирует текст и метаданные пасты в новую пасту текущего пользователя.\nФорк защищённой пасты защищён тем же паролем.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Создание форка пасты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш исходной пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Параметры форка",
+                        "name": "fork",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/ForkPasteBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "location": {
+                                            "type": "string"
+                                        },
+                                        "paste": {
+                                            "$ref": "#/definitions/PasteInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/forks": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получение списка форков пасты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "forks": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/PasteMeta"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/grants": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Возвращает имена пользователей, которым автор выдал доступ к приватной пасте.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Пользователи с доступом к пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "grants": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Выдаёт пользователю доступ к приватной пасте. Выдать доступ может только автор пасты.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Выдача доступа к пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Пользователь",
+                        "name": "grant",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/GrantAccessBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/grants/{username}": {
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Отзывает доступ пользователя к приватной пасте. Отозвать доступ может только автор пасты.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Отзыв доступа к пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/html": {
+            "get": {
+                "description": "Возвращает HTML документ с текстом пасты, подсвеченным по формату пасты, и номерами строк.\nСтроки можно выделить параметром ` + "`" + `hl` + "`" + `, например ` + "`" + `10-20` + "`" + ` или ` + "`" + `1,5-7` + "`" + `.\nПароль защищённой пасты передаётся в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получение пасты с подсветкой синтаксиса",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "github",
+                        "description": "Тема подсветки",
+                        "name": "theme",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выделенные строки, не более 20 диапазонов",
+                        "name": "hl",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/query": {
+            "get": {
+                "description": "Вычисляет выражение ` + "`" + `expr` + "`" + ` по тексту пасты формата json, yaml, toml или xml и возвращает найденные значения.\nПоддерживаются выражения в стиле jq, например ` + "`" + `.spec.containers[0].image` + "`" + ` или ` + "`" + `.items[] | select(.enabled) | .name` + "`" + `,\nи JSONPath, начинающиеся с ` + "`" + `$` + "`" + `, например ` + "`" + `$..containers[?(@.name == 'app')].image` + "`" + `.\nВремя вычисления и размер результата ограничены. С параметром ` + "`" + `raw=1` + "`" + ` значения возвращаются\nтекстом по одному на строку, строки без кавычек.\nПароль защищённой пасты передаётся в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Запрос к структурированной пасте",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Выражение",
+                        "name": "expr",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Вернуть значения текстом",
+                        "name": "raw",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "results": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "object"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/raw": {
+            "get": {
+                "description": "Возвращает только текст пасты с Content-Type, соответствующим формату пасты.\nПароль защищённой пасты передаётся в заголовке ` + "`" + `X-Paste-Password` + "`" + `.\nС параметром ` + "`" + `download=1` + "`" + ` текст отдаётся как файл с именем из названия пасты.\nС параметром ` + "`" + `ansi=1` + "`" + ` или заголовком ` + "`" + `Accept: text/x-ansi` + "`" + ` текст подсвечивается ANSI кодами для терминала,\nпо умолчанию текст отдаётся без изменений.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получение текста пасты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Скачать как файл",
+                        "name": "download",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Подсветка для терминала",
+                        "name": "ansi",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "256",
+                            "truecolor"
+                        ],
+                        "type": "string",
+                        "default": "256",
+                        "description": "Палитра терминала",
+                        "name": "colors",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "github",
+                        "description": "Тема подсветки",
+                        "name": "theme",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Номера строк",
+                        "name": "ln",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/raw/{name}": {
+            "get": {
+                "description": "Возвращает только текст файла многофайловой пасты с Content-Type, соответствующим формату файла.\nС параметром ` + "`" + `download=1` + "`" + ` текст отдаётся как файл с именем файла пасты.",
+                "produces": [
+                    "text/plain",
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получение текста файла пасты",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя файла",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Скачать как файл",
+                        "name": "download",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/revisions": {
+            "get": {
+                "description": "Список заканчивается текущей ревизией. Ревизии возвращаются без текста.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получение списка ревизий пасты.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "revisions": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/RevisionInfo"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/revisions/{revision}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получение ревизии пасты.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Номер ревизии",
+                        "name": "revision",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "revision": {
+                                            "$ref": "#/definitions/RevisionInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/star": {
+            "post": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Ставит звезду пасте от текущего пользователя. Повторная звезда ничего не меняет.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Добавление пасты в избранное",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "stars": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Снимает звезду текущего пользователя с пасты. Снятие отсутствующей звезды ничего не меняет.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Удаление пасты из избранного",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "stars": {
+                                            "type": "integer"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/unlock": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Получение доступа к пасте с паролем.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Пароль",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UnlockPasteBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "paste": {
+                                            "$ref": "#/definitions/PasteInfo"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/validate": {
+            "post": {
+                "description": "Проверяет текст пасты формата json, yaml или toml по JSON схеме из пасты ` + "`" + `schema` + "`" + `,\nпо умолчанию по схеме, указанной при создании пасты. Возвращает нарушения схемы с JSON указателями на значения.\nПаста со схемой не должна быть защищена паролем. Поддерживаются локальные ссылки ` + "`" + `$ref` + "`" + `.\nПароль защищённой пасты передаётся в заголовке ` + "`" + `X-Paste-Password` + "`" + `.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Проверка пасты по JSON схеме",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты с JSON схемой",
+                        "name": "schema",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "validation": {
+                                            "$ref": "#/definitions/SchemaValidation"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/pastes/{hash}/zip": {
+            "get": {
+                "description": "Возвращает zip архив со всеми файлами пасты.",
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Скачивание пасты архивом",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Хеш пасты",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Пароль пасты",
+                        "name": "X-Paste-Password",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/tags": {
+            "get": {
+                "description": "Возвращает до 100 самых используемых тегов с числом не сгоревших паст,\nдоступных пользователю, как в списке паст по тегам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Популярные теги",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "tags": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/TagCount"
+                                            }
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/token": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Получения авторизационных данных",
+                "parameters": [
+                    {
+                        "description": "Уникальный код, сгенерированный OAuth2 приложением",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/pastes": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Возвращает метаданные паст текущего пользователя без текстов.\nПо умолчанию пасты упорядочены по дате создания от новых к старым, по дате сгорания\nи названию от меньших к большим, бессрочные пасты идут после сгорающих.\nСледующая страница запрашивается с курсором ` + "`" + `next` + "`" + ` из ответа и той же сортировкой.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Пасты текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Формат пасты",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "expired"
+                        ],
+                        "type": "string",
+                        "description": "Статус пасты",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Паста защищена паролем",
+                        "name": "locked",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Теги пасты",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "any"
+                        ],
+                        "type": "string",
+                        "default": "all",
+                        "description": "Пасты со всеми тегами или с любым из них",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created",
+                            "expires",
+                            "title"
+                        ],
+                        "type": "string",
+                        "default": "created",
+                        "description": "Поле сортировки",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Порядок сортировки",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Число паст на странице, от 1 до 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "pastes": {
+                                            "$ref": "#/definitions/UserPastesPage"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/stars": {
+            "get": {
+                "security": [
+                    {
+                        "Bearer": []
+                    }
+                ],
+                "description": "Возвращает метаданные не сгоревших паст со звездой текущего пользователя,\nот недавно добавленных в избранное к давним. Приватные пасты, к которым\nу пользователя больше нет доступа, не возвращаются.\nСледующая страница запрашивается с курсором ` + "`" + `next` + "`" + ` из ответа.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Избранные пасты текущего пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Число паст на странице, от 1 до 100",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Курсор следующей страницы",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "data": {
+                                    "type": "object",
+                                    "properties": {
+                                        "pastes": {
+                                            "$ref": "#/definitions/StarredPastesPage"
+                                        }
+                                    }
+                                },
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "object",
+                                    "properties": {
+                                        "field": {
+                                            "type": "string"
+                                        }
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/feed.atom": {
+            "get": {
+                "description": "Возвращает ленту последних публичных паст пользователя в формате Atom или RSS\nв зависимости от расширения пути. Записи содержат название, формат, дату создания\nи начало текста пасты. Поддерживаются условные запросы с заголовками ` + "`" + `If-None-Match` + "`" + `\nи ` + "`" + `If-Modified-Since` + "`" + `.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Лента пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}/feed.rss": {
+            "get": {
+                "description": "Возвращает ленту последних публичных паст пользователя в формате Atom или RSS\nв зависимости от расширения пути. Записи содержат название, формат, дату создания\nи начало текста пасты. Поддерживаются условные запросы с заголовками ` + "`" + `If-None-Match` + "`" + `\nи ` + "`" + `If-Modified-Since` + "`" + `.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "pastes"
+                ],
+                "summary": "Лента пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "error": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AddCollectionPasteBody": {
+            "description": "Тело запроса для добавления пасты в коллекцию.",
+            "type": "object",
+            "required": [
+                "hash"
+            ],
+            "properties": {
+                "hash": {
+                    "description": "Хеш пасты, паста добавляется в конец коллекции",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                }
+            }
+        },
+        "Collection": {
+            "description": "Коллекция паст.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Дата создания",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "hash": {
+                    "description": "Уникальный идентификатор",
+                    "type": "string",
+                    "example": "Kx8QaEvs"
+                },
+                "pastes": {
+                    "description": "Метаданные паст коллекции в порядке следования",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PasteMeta"
+                    }
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "example": "Onboarding runbook"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "visibility": {
+                    "description": "Видимость коллекции",
+                    "type": "string",
+                    "example": "unlisted"
+                }
+            }
+        },
+        "Comment": {
+            "description": "Комментарий к пасте.",
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Имя автора",
+                    "type": "string",
+                    "example": "octocat"
+                },
+                "created_at": {
+                    "description": "Дата создания",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "deleted": {
+                    "description": "Комментарий удалён, но на него есть ответы",
+                    "type": "boolean",
+                    "example": false
+                },
+                "id": {
+                    "description": "Идентификатор комментария",
+                    "type": "integer",
+                    "example": 1
+                },
+                "line_end": {
+                    "description": "Последняя комментируемая строка",
+                    "type": "integer",
+                    "example": 5
+                },
+                "line_start": {
+                    "description": "Первая комментируемая строка, не указывается для комментариев ко всей пасте",
+                    "type": "integer",
+                    "example": 3
+                },
+                "replies": {
+                    "description": "Ответы на комментарий от старых к новым",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Comment"
+                    }
+                },
+                "revision": {
+                    "description": "Ревизия пасты, к которой оставлен комментарий",
+                    "type": "integer",
+                    "example": 1
+                },
+                "text": {
+                    "description": "Текст комментария, пустой у удалённых комментариев",
+                    "type": "string",
+                    "example": "Здесь лучше использовать errors.Is"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                }
+            }
+        },
+        "CreateCollectionBody": {
+            "description": "Тело запроса для создания коллекции.",
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "pastes": {
+                    "description": "Хеши паст коллекции в порядке следования",
+                    "type": "array",
+                    "maxItems": 100,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "HrEQaEvs",
+                        "aB3dE5gH"
+                    ]
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Onboarding runbook"
+                },
+                "visibility": {
+                    "description": "Видимость коллекции, как у паст. По умолчанию скрытая.",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "example": "unlisted"
+                }
+            }
+        },
+        "CreateCommentBody": {
+            "description": "Тело запроса для создания комментария. Ответ наследует строки комментария, на который он отвечает.",
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "line_end": {
+                    "description": "Последняя комментируемая строка, по умолчанию равна первой",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 5
+                },
+                "line_start": {
+                    "description": "Первая комментируемая строка, начиная с 1",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 3
+                },
+                "parent_id": {
+                    "description": "Идентификатор комментария, на который дан ответ",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 1
+                },
+                "text": {
+                    "description": "Текст комментария",
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "Здесь лучше использовать errors.Is"
+                }
+            }
+        },
+        "CreatePasteBody": {
+            "description": "Тело запроса для создания пасты.",
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "burn_after_read": {
+                    "description": "Удалить пасту после первого прочтения",
+                    "type": "boolean",
+                    "example": false
+                },
+                "expires": {
+                    "description": "Время, через которое паста становится не доступной, например ` + "`" + `30m` + "`" + ` или ` + "`" + `72h` + "`" + `.\nЗначение ` + "`" + `never` + "`" + ` доступно только авторизованным пользователям.",
+                    "type": "string",
+                    "example": "30m"
+                },
+                "expires_at": {
+                    "description": "Дата, после которой паста становится не доступной, в формате RFC 3339",
+                    "type": "string",
+                    "example": "2023-10-29T20:38:41+08:00"
+                },
+                "files": {
+                    "description": "Файлы пасты, первый файл считается основным текстом пасты",
+                    "type": "array",
+                    "maxItems": 20,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/PasteFileBody"
+                    }
+                },
+                "format": {
+                    "description": "Формат текста, не указывается вместе с файлами.\nЕсли не указан, определяется по тексту.",
+                    "type": "string",
+                    "enum": [
+                        "json",
+                        "yaml",
+                        "toml"
+                    ],
+                    "example": "plaintext"
+                },
+                "max_views": {
+                    "description": "Максимальное количество просмотров, после которого паста становится не доступной",
+                    "type": "integer",
+                    "minimum": 1,
+                    "example": 10
+                },
+                "password": {
+                    "description": "Пароль для получения доступа к пасте",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "password for security"
+                },
+                "schema": {
+                    "description": "Хеш пасты с JSON схемой, которой должен соответствовать текст при создании и каждом изменении",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "tags": {
+                    "description": "Теги пасты, приводятся к нижнему регистру, пробелы заменяются на дефисы",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
+                },
+                "text": {
+                    "description": "Текст, не указывается вместе с файлами",
+                    "type": "string",
+                    "example": "Some very secret text"
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "The private paste"
+                },
+                "visibility": {
+                    "description": "Видимость пасты: публичные пасты видны в ленте и поиске, скрытые доступны по хешу,\nприватные только автору и пользователям с доступом. По умолчанию скрытая.\nПриватные пасты доступны только авторизованным пользователям.",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "example": "unlisted"
+                }
+            }
+        },
+        "CreateTokenRequest": {
+            "description": "Payload for creating a new user if not exists and get access token.",
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Github oauth2 code",
+                    "type": "string"
+                }
+            }
+        },
+        "DetectFormatBody": {
+            "description": "Тело запроса для определения формата текста.",
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "description": "Текст",
+                    "type": "string",
+                    "example": "{\"key\": \"value\"}"
+                }
+            }
+        },
+        "DiffHunk": {
+            "description": "Группа изменённых строк.",
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "description": "Строки",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DiffLine"
+                    }
+                },
+                "new_lines": {
+                    "description": "Количество строк в новой версии",
+                    "type": "integer",
+                    "example": 4
+                },
+                "new_start": {
+                    "description": "Номер первой строки в новой версии",
+                    "type": "integer",
+                    "example": 1
+                },
+                "old_lines": {
+                    "description": "Количество строк в исходной версии",
+                    "type": "integer",
+                    "example": 3
+                },
+                "old_start": {
+                    "description": "Номер первой строки в исходной версии",
+                    "type": "integer",
+                    "example": 1
+                }
+            }
+        },
+        "DiffInfo": {
+            "description": "Разница между версиями паст.",
+            "type": "object",
+            "properties": {
+                "from": {
+                    "description": "Исходная версия",
+                    "type": "string",
+                    "example": "HrEQaEvs@1"
+                },
+                "hunks": {
+                    "description": "Группы изменений",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/DiffHunk"
+                    }
+                },
+                "to": {
+                    "description": "Новая версия",
+                    "type": "string",
+                    "example": "HrEQaEvs@2"
+                },
+                "unified": {
+                    "description": "Разница в формате unified diff",
+                    "type": "string",
+                    "example": "--- HrEQaEvs@1\n+++ HrEQaEvs@2\n"
+                }
+            }
+        },
+        "DiffLine": {
+            "description": "Строка группы изменений.",
+            "type": "object",
+            "properties": {
+                "op": {
+                    "description": "Операция: \" \" - без изменений, \"-\" - удалена, \"+\" - добавлена",
+                    "type": "string",
+                    "enum": [
+                        " ",
+                        "-",
+                        "+"
+                    ],
+                    "example": "+"
+                },
+                "text": {
+                    "description": "Текст строки",
+                    "type": "string",
+                    "example": "key: value"
+                }
+            }
+        },
+        "ExtendPasteBody": {
+            "description": "Тело запроса для продления пасты. Без полей паста продлевается на время жизни по умолчанию.",
+            "type": "object",
+            "properties": {
+                "expires": {
+                    "description": "Время, через которое паста становится не доступной, например ` + "`" + `30m` + "`" + ` или ` + "`" + `72h` + "`" + `, или ` + "`" + `never` + "`" + `",
+                    "type": "string",
+                    "example": "168h"
+                },
+                "expires_at": {
+                    "description": "Дата, после которой паста становится не доступной, в формате RFC 3339",
+                    "type": "string",
+                    "example": "2023-10-29T20:38:41+08:00"
+                }
+            }
+        },
+        "FeedEntry": {
+            "description": "Паста в ленте.",
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Имя автора, не указывается для анонимных паст",
+                    "type": "string",
+                    "example": "octocat"
+                },
+                "created_at": {
+                    "description": "Дата создания",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "excerpt": {
+                    "description": "Начало текста пасты",
+                    "type": "string",
+                    "example": "services: web: image: nginx"
+                },
+                "expires_at": {
+                    "description": "Дата сгорания, не указывается для бессрочных паст",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
+                    "example": "plaintext"
+                },
+                "hash": {
+                    "description": "Уникальный идентификатор",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "tags": {
+                    "description": "Теги пасты",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "example": "The paste"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                }
+            }
+        },
+        "ForkPasteBody": {
+            "description": "Тело запроса для создания форка пасты.",
+            "type": "object",
+            "properties": {
+                "password": {
+                    "description": "Пароль исходной пасты, если она защищена",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "password for security"
+                },
+                "title": {
+                    "description": "Название форка, по умолчанию название исходной пасты",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "My fork"
+                }
+            }
+        },
+        "FormatDetection": {
+            "description": "Определенный формат текста.",
+            "type": "object",
+            "properties": {
+                "confidence": {
+                    "description": "Уверенность в формате от 0 до 1",
+                    "type": "number",
+                    "example": 1
+                },
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
+                    "example": "json"
+                }
+            }
+        },
+        "GrantAccessBody": {
+            "description": "Тело запроса для выдачи доступа к приватной пасте.",
+            "type": "object",
+            "required": [
+                "username"
+            ],
+            "properties": {
+                "username": {
+                    "description": "Имя пользователя",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "octocat"
+                }
+            }
+        },
+        "PasteFileBody": {
+            "description": "Файл пасты.",
+            "type": "object",
+            "required": [
+                "name",
+                "text"
+            ],
+            "properties": {
+                "format": {
+                    "description": "Формат текста, если не указан, определяется по тексту",
+                    "type": "string",
                     "enum": [
                         "json",
                         "yaml",
                         "toml"
                     ],
+                    "example": "yaml"
+                },
+                "name": {
+                    "description": "Имя файла",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "docker-compose.yaml"
+                },
+                "text": {
+                    "description": "Текст",
+                    "type": "string",
+                    "example": "services: {}"
+                }
+            }
+        },
+        "PasteFileInfo": {
+            "description": "Файл пасты.",
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
+                    "example": "yaml"
+                },
+                "name": {
+                    "description": "Имя файла",
+                    "type": "string",
+                    "example": "docker-compose.yaml"
+                },
+                "text": {
+                    "description": "Текст",
+                    "type": "string",
+                    "example": "services: {}"
+                }
+            }
+        },
+        "PasteInfo": {
+            "description": "Тело ответа на создание пасты.",
+            "type": "object",
+            "properties": {
+                "burn_after_read": {
+                    "description": "Паста удаляется после первого прочтения",
+                    "type": "boolean",
+                    "example": false
+                },
+                "converted_from": {
+                    "description": "Хеш пасты, из которой сконвертирована паста",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "created_at": {
+                    "description": "Дата создания",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "expires_at": {
+                    "description": "Дата сгорания, не указывается для бессрочных паст",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "files": {
+                    "description": "Файлы пасты, первый файл совпадает с текстом пасты",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PasteFileInfo"
+                    }
+                },
+                "forked_from": {
+                    "description": "Хеш пасты, из которой сделан форк",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "forks": {
+                    "description": "Количество форков",
+                    "type": "integer",
+                    "example": 0
+                },
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
+                    "example": "plaintext"
+                },
+                "format_confidence": {
+                    "description": "Уверенность в формате от 0 до 1, меньше 1 если формат определен по тексту",
+                    "type": "number",
+                    "example": 1
+                },
+                "hash": {
+                    "description": "Уникальный идентификатор",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "max_views": {
+                    "description": "Максимальное количество просмотров",
+                    "type": "integer",
+                    "example": 10
+                },
+                "revision": {
+                    "description": "Номер текущей ревизии",
+                    "type": "integer",
+                    "example": 1
+                },
+                "schema": {
+                    "description": "Хеш пасты с JSON схемой текста",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "stars": {
+                    "description": "Количество звёзд",
+                    "type": "integer",
+                    "example": 3
+                },
+                "tags": {
+                    "description": "Теги пасты",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
+                },
+                "text": {
+                    "description": "Текст",
+                    "type": "string",
+                    "example": "The some paste"
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "example": "The paste"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "valid": {
+                    "description": "Текст соответствует формату",
+                    "type": "boolean",
+                    "example": true
+                },
+                "views": {
+                    "description": "Количество просмотров",
+                    "type": "integer",
+                    "example": 1
+                },
+                "visibility": {
+                    "description": "Видимость пасты",
+                    "type": "string",
+                    "example": "unlisted"
+                }
+            }
+        },
+        "PasteMeta": {
+            "description": "Метаданные пасты без текста.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Дата создания",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "expires_at": {
+                    "description": "Дата сгорания, не указывается для бессрочных паст",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
                     "example": "plaintext"
                 },
-                "password": {
-                    "description": "Пароль для получения доступа к пасте",
+                "hash": {
+                    "description": "Уникальный идентификатор",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "tags": {
+                    "description": "Теги пасты",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "example": "The paste"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                }
+            }
+        },
+        "ReorderCollectionBody": {
+            "description": "Тело запроса для изменения порядка паст коллекции.",
+            "type": "object",
+            "required": [
+                "pastes"
+            ],
+            "properties": {
+                "pastes": {
+                    "description": "Хеши всех паст коллекции в новом порядке",
+                    "type": "array",
+                    "maxItems": 100,
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "aB3dE5gH",
+                        "HrEQaEvs"
+                    ]
+                }
+            }
+        },
+        "RevisionInfo": {
+            "description": "Ревизия пасты.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Дата создания ревизии",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "files": {
+                    "description": "Файлы многофайловой пасты в этой ревизии",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PasteFileInfo"
+                    }
+                },
+                "format": {
+                    "description": "Формат текста",
                     "type": "string",
-                    "maxLength": 255,
-                    "example": "password for security"
+                    "example": "plaintext"
+                },
+                "revision": {
+                    "description": "Номер ревизии",
+                    "type": "integer",
+                    "example": 1
                 },
                 "text": {
                     "description": "Текст",
                     "type": "string",
-                    "example": "Some very secret text"
+                    "example": "The some paste"
                 },
                 "title": {
                     "description": "Название",
                     "type": "string",
-                    "maxLength": 255,
-                    "example": "The private paste"
+                    "example": "The paste"
                 }
             }
         },
-        "CreateTokenRequest": {
-            "description": "Payload for creating a new user if not exists and get access token.",
+        "SchemaValidation": {
+            "description": "Результат проверки пасты по JSON схеме.",
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Github oauth2 code",
-                    "type": "string"
+                "valid": {
+                    "description": "Соответствует ли паста схеме",
+                    "type": "boolean",
+                    "example": false
+                },
+                "violations": {
+                    "description": "Нарушения схемы",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SchemaViolation"
+                    }
                 }
             }
         },
-        "PasteInfo": {
-            "description": "Тело ответа на создание пасты.",
+        "SchemaViolation": {
+            "description": "Нарушение JSON схемы.",
+            "type": "object",
+            "properties": {
+                "keyword": {
+                    "description": "Ключевое слово схемы",
+                    "type": "string",
+                    "example": "minimum"
+                },
+                "message": {
+                    "description": "Описание нарушения",
+                    "type": "string",
+                    "example": "must be \u003e= 1"
+                },
+                "pointer": {
+                    "description": "JSON указатель на значение, пустой для всего текста",
+                    "type": "string",
+                    "example": "/spec/replicas"
+                }
+            }
+        },
+        "SearchPage": {
+            "description": "Страница результатов поиска.",
+            "type": "object",
+            "properties": {
+                "next": {
+                    "description": "Курсор следующей страницы, не указывается для последней страницы",
+                    "type": "string",
+                    "example": "MC4xOkhyRVFhRXZz"
+                },
+                "results": {
+                    "description": "Найденные пасты по убыванию релевантности",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SearchResult"
+                    }
+                }
+            }
+        },
+        "SearchResult": {
+            "description": "Найденная паста.",
             "type": "object",
             "properties": {
                 "created_at": {
@@ -539,7 +5981,7 @@ const docTemplate = `{
                     "example": "Sun, 29 Oct 2023 20:38:41 +08"
                 },
                 "expires_at": {
-                    "description": "Дата сгорания",
+                    "description": "Дата сгорания, не указывается для бессрочных паст",
                     "type": "string",
                     "example": "Sun, 29 Oct 2023 20:38:41 +08"
                 },
@@ -553,15 +5995,155 @@ const docTemplate = `{
                     "type": "string",
                     "example": "HrEQaEvs"
                 },
-                "text": {
-                    "description": "Текст",
+                "highlights": {
+                    "description": "Позиции совпадений во фрагменте в символах, начало включительно и конец не включительно",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        }
+                    }
+                },
+                "rank": {
+                    "description": "Релевантность пасты запросу",
+                    "type": "number",
+                    "example": 0.1
+                },
+                "snippet": {
+                    "description": "Фрагмент текста пасты с совпадениями",
                     "type": "string",
-                    "example": "The some paste"
+                    "example": "services: web: image: nginx"
+                },
+                "tags": {
+                    "description": "Теги пасты",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "example": "The paste"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                }
+            }
+        },
+        "StarredPaste": {
+            "description": "Паста в избранном пользователя.",
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "description": "Дата создания",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "expires_at": {
+                    "description": "Дата сгорания, не указывается для бессрочных паст",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
+                    "example": "plaintext"
+                },
+                "hash": {
+                    "description": "Уникальный идентификатор",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "starred_at": {
+                    "description": "Дата добавления в избранное",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "stars": {
+                    "description": "Количество звёзд",
+                    "type": "integer",
+                    "example": 3
+                },
+                "tags": {
+                    "description": "Теги пасты",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
                 },
                 "title": {
                     "description": "Название",
                     "type": "string",
                     "example": "The paste"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                }
+            }
+        },
+        "StarredPastesPage": {
+            "description": "Страница избранных паст.",
+            "type": "object",
+            "properties": {
+                "next": {
+                    "description": "Курсор следующей страницы, не указывается для последней страницы",
+                    "type": "string",
+                    "example": "c3RhcnJlZDpIckVRYUV2czoyMDIzLTEwLTI5VDIwOjM4OjQxKzA4OjAw"
+                },
+                "pastes": {
+                    "description": "Избранные пасты",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/StarredPaste"
+                    }
+                }
+            }
+        },
+        "TagCount": {
+            "description": "Тег и число паст с ним.",
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Число паст с тегом",
+                    "type": "integer",
+                    "example": 3
+                },
+                "tag": {
+                    "description": "Тег",
+                    "type": "string",
+                    "example": "go"
+                }
+            }
+        },
+        "TaggedPastesPage": {
+            "description": "Страница паст с тегами.",
+            "type": "object",
+            "properties": {
+                "next": {
+                    "description": "Курсор следующей страницы, не указывается для последней страницы",
+                    "type": "string",
+                    "example": "Y3JlYXRlZDpIckVRYUV2czoyMDIzLTEwLTI5VDIwOjM4OjQxKzA4OjAw"
+                },
+                "pastes": {
+                    "description": "Пасты",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PasteMeta"
+                    }
                 }
             }
         },
@@ -580,6 +6162,146 @@ const docTemplate = `{
                 }
             }
         },
+        "UpdateCollectionBody": {
+            "description": "Тело запроса для изменения коллекции. Пустые поля остаются без изменений.",
+            "type": "object",
+            "properties": {
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "Onboarding runbook"
+                },
+                "visibility": {
+                    "description": "Видимость коллекции",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "example": "private"
+                }
+            }
+        },
+        "UpdateCommentBody": {
+            "description": "Тело запроса для изменения комментария.",
+            "type": "object",
+            "required": [
+                "text"
+            ],
+            "properties": {
+                "text": {
+                    "description": "Текст комментария",
+                    "type": "string",
+                    "maxLength": 10000,
+                    "example": "Здесь лучше использовать errors.As"
+                }
+            }
+        },
+        "UpdatePasteBody": {
+            "description": "Тело запроса для изменения пасты. Пустые поля остаются без изменений.",
+            "type": "object",
+            "required": [
+                "tags"
+            ],
+            "properties": {
+                "expires": {
+                    "description": "Время, через которое паста становится не доступной, например ` + "`" + `30m` + "`" + ` или ` + "`" + `72h` + "`" + `.\nЗначение ` + "`" + `never` + "`" + ` доступно только авторизованным пользователям.",
+                    "type": "string",
+                    "example": "30m"
+                },
+                "expires_at": {
+                    "description": "Дата, после которой паста становится не доступной, в формате RFC 3339",
+                    "type": "string",
+                    "example": "2023-10-29T20:38:41+08:00"
+                },
+                "files": {
+                    "description": "Новые тексты файлов многофайловой пасты, остальные файлы остаются без изменений",
+                    "type": "array",
+                    "maxItems": 20,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/UpdatePasteFileBody"
+                    }
+                },
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
+                    "enum": [
+                        "json",
+                        "yaml",
+                        "toml"
+                    ],
+                    "example": "plaintext"
+                },
+                "password": {
+                    "description": "Пароль для получения доступа к пасте, пустая строка удаляет пароль",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "password for security"
+                },
+                "schema": {
+                    "description": "Хеш пасты с JSON схемой, заменяет текущую схему, пустая строка удаляет схему",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "tags": {
+                    "description": "Теги пасты, заменяют текущие теги, пустой список удаляет все теги",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
+                },
+                "text": {
+                    "description": "Текст",
+                    "type": "string",
+                    "example": "Some very secret text"
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "The private paste"
+                },
+                "visibility": {
+                    "description": "Видимость пасты",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "example": "private"
+                }
+            }
+        },
+        "UpdatePasteFileBody": {
+            "description": "Новый текст файла пасты.",
+            "type": "object",
+            "required": [
+                "name",
+                "text"
+            ],
+            "properties": {
+                "name": {
+                    "description": "Имя файла",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "docker-compose.yaml"
+                },
+                "text": {
+                    "description": "Текст",
+                    "type": "string",
+                    "example": "services: {}"
+                }
+            }
+        },
         "UserInfo": {
             "description": "Payload for getting user info.",
             "type": "object",
@@ -597,6 +6319,101 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "UserPaste": {
+            "description": "Метаданные пасты автора.",
+            "type": "object",
+            "properties": {
+                "burn_after_read": {
+                    "description": "Паста удаляется после первого прочтения",
+                    "type": "boolean",
+                    "example": false
+                },
+                "created_at": {
+                    "description": "Дата создания",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "expired": {
+                    "description": "Паста сгорела",
+                    "type": "boolean",
+                    "example": false
+                },
+                "expires_at": {
+                    "description": "Дата сгорания, не указывается для бессрочных паст",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "format": {
+                    "description": "Формат текста",
+                    "type": "string",
+                    "example": "plaintext"
+                },
+                "hash": {
+                    "description": "Уникальный идентификатор",
+                    "type": "string",
+                    "example": "HrEQaEvs"
+                },
+                "locked": {
+                    "description": "Паста защищена паролем",
+                    "type": "boolean",
+                    "example": false
+                },
+                "max_views": {
+                    "description": "Максимальное количество просмотров",
+                    "type": "integer",
+                    "example": 10
+                },
+                "tags": {
+                    "description": "Теги пасты",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "go",
+                        "k8s"
+                    ]
+                },
+                "title": {
+                    "description": "Название",
+                    "type": "string",
+                    "example": "The paste"
+                },
+                "updated_at": {
+                    "description": "Дата последнего изменения",
+                    "type": "string",
+                    "example": "Sun, 29 Oct 2023 20:38:41 +08"
+                },
+                "views": {
+                    "description": "Количество просмотров",
+                    "type": "integer",
+                    "example": 1
+                },
+                "visibility": {
+                    "description": "Видимость пасты",
+                    "type": "string",
+                    "example": "unlisted"
+                }
+            }
+        },
+        "UserPastesPage": {
+            "description": "Страница паст автора.",
+            "type": "object",
+            "properties": {
+                "next": {
+                    "description": "Курсор следующей страницы, не указывается для последней страницы",
+                    "type": "string",
+                    "example": "Y3JlYXRlZDpIckVRYUV2czoyMDIzLTEwLTI5VDIwOjM4OjQxKzA4OjAw"
+                },
+                "pastes": {
+                    "description": "Пасты автора",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserPaste"
+                    }
+                }
+            }
         }
     },
     "securityDefinitions": {
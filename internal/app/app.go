@@ -1,6 +1,7 @@
 package app
 
 import (
+	"context"
 	"net/http"
 	"os"
 	"os/signal"
@@ -10,16 +11,108 @@ import (
 	"github.com/romankravchuk/pastebin/config"
 	"github.com/romankravchuk/pastebin/internal/controller/http/response"
 	v1 "github.com/romankravchuk/pastebin/internal/controller/http/v1"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/internal/usecase/blob"
+	"github.com/romankravchuk/pastebin/internal/usecase/cache"
+	"github.com/romankravchuk/pastebin/internal/usecase/formats"
+	"github.com/romankravchuk/pastebin/internal/usecase/highlight"
+	"github.com/romankravchuk/pastebin/internal/usecase/repo"
+	"github.com/romankravchuk/pastebin/internal/usecase/webapi"
 	"github.com/romankravchuk/pastebin/pkg/httpserver"
 	"github.com/romankravchuk/pastebin/pkg/log"
+	"github.com/romankravchuk/pastebin/pkg/minio"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+	"github.com/romankravchuk/pastebin/pkg/redis"
 )
 
 func Run(cfg *config.Config) {
+	l := log.New(os.Stdout, log.Stol(cfg.Log.Level))
+
+	// Repository
+	postgreClient, err := postgres.New(cfg.Postgres.DSN)
+	if err != nil {
+		l.Error("initialize the postgres client", err, nil)
+
+		return
+	}
+	defer postgreClient.Close()
+
+	minioClient, err := minio.New(cfg.Minio.DSN, cfg.Minio.AccessKey, cfg.Minio.SecretKey)
+	if err != nil {
+		l.Error("initialize the minio client", err, nil)
+
+		return
+	}
+
+	redisClient, err := redis.New(cfg.Redis.DSN)
+	if err != nil {
+		l.Error("initialize the redis client", err, nil)
+
+		return
+	}
+
+	locker, err := cache.NewLocker(redisClient)
+	if err != nil {
+		l.Error("initialize the redis locker", err, nil)
+
+		return
+	}
+
+	// Use case
 	var (
-		err error
-		l   = log.New(os.Stdout, log.Stol(cfg.Log.Level))
+		pastesCache        = cache.NewPastesCache(redisClient)
+		viewsCounter       = cache.NewPasteViewsCounter(redisClient)
+		rendersCache       = cache.NewPastesRendersCache(redisClient)
+		highlighter        = highlight.NewHighlighter()
+		detector           = formats.NewDetector()
+		validator          = formats.NewValidator()
+		fmtConverter       = formats.NewConverter()
+		querier            = formats.NewQuerier(cfg.Pastes.Query.Timeout, cfg.Pastes.Query.MaxOutput)
+		schemas            = formats.NewSchemaValidator()
+		pastesBlob         = blob.NewPastesBlobStorage(minioClient)
+		pastesRepo         = repo.NewPastesRepositry(postgreClient)
+		revisionsRepo      = repo.NewPasteRevisionsRepository(postgreClient)
+		filesRepo          = repo.NewPasteFilesRepository(postgreClient)
+		searchRepo         = repo.NewPasteSearchRepository(postgreClient)
+		grantsRepo         = repo.NewPasteGrantsRepository(postgreClient)
+		feedsCache         = cache.NewPastesFeedsCache(redisClient)
+		tagsRepo           = repo.NewPasteTagsRepository(postgreClient)
+		starsRepo          = repo.NewPasteStarsRepository(postgreClient)
+		starsCounter       = cache.NewPasteStarsCounter(redisClient)
+		commentsRepo       = repo.NewPasteCommentsRepository(postgreClient)
+		collectionsRepo    = repo.NewCollectionsRepository(postgreClient)
+		usersRepo          = repo.NewUsersRepositry(postgreClient)
+		oauthapi           = webapi.NewGithubAPI(cfg.OAuth.ClientID, cfg.OAuth.ClientSecret)
+		tokensCache        = cache.NewAuthTokensCache(redisClient)
+		authUsecase        = usecase.NewAuth(usersRepo, oauthapi, tokensCache)
+		formatsUsecase     = usecase.NewFormats(detector)
+		pasteAccess        = usecase.NewPasteAccess(pastesRepo, grantsRepo)
+		collectionsUsecase = usecase.NewCollections(collectionsRepo, pasteAccess)
+		searchUsecase      = usecase.NewSearch(searchRepo, pastesRepo, pastesBlob, locker)
+		tagsUsecase        = usecase.NewTags(tagsRepo, pastesRepo)
+		feedsUsecase       = usecase.NewFeeds(feedsCache, pastesRepo, usersRepo)
+		starsUsecase       = usecase.NewStars(starsRepo, starsCounter, pasteAccess)
+		commentsUsecase    = usecase.NewComments(commentsRepo, pastesBlob, pasteAccess)
+		pastesUsecase      = usecase.NewPastes(
+			pastesRepo, pastesBlob, pastesCache, revisionsRepo, filesRepo, viewsCounter, locker, rendersCache, highlighter, detector, validator, fmtConverter, querier, schemas, searchRepo, pasteAccess, grantsRepo, feedsCache, tagsRepo, starsCounter,
+			usecase.ExpirationPolicy{
+				Min:        cfg.Pastes.Expiration.Min,
+				Max:        cfg.Pastes.Expiration.Max,
+				Default:    cfg.Pastes.Expiration.Default,
+				AllowNever: cfg.Pastes.Expiration.AllowNever,
+			},
+		)
 	)
 
+	// Workers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go runPeriodically(ctx, l, "flush paste views", cfg.Pastes.ViewsFlushInterval, pastesUsecase.FlushViews)
+	go runPeriodically(ctx, l, "flush paste stars", cfg.Pastes.StarsFlushInterval, starsUsecase.FlushStars)
+	go runPeriodically(ctx, l, "delete expired pastes", cfg.Pastes.ReaperInterval, pastesUsecase.DeleteExpired)
+	go runPeriodically(ctx, l, "index missing pastes", cfg.Pastes.IndexInterval, searchUsecase.IndexMissing)
+
 	// HTTP Server
 	handler := chi.NewMux()
 	handler.NotFound(func(w http.ResponseWriter, r *http.Request) {
@@ -29,15 +122,9 @@ func Run(cfg *config.Config) {
 		response.MethodNotAllowed(w, r)
 	})
 	handler.Route("/api/v1", func(r chi.Router) {
-		err = v1.NewRouter(r, cfg, l)
+		v1.NewRouter(r, l, cfg.HTTP.BaseURL, pastesUsecase, authUsecase, formatsUsecase, collectionsUsecase, searchUsecase, tagsUsecase, feedsUsecase, starsUsecase, commentsUsecase)
 	})
 
-	if err != nil {
-		l.Error("initialize the router", err, nil)
-
-		return
-	}
-
 	srv := httpserver.New(handler, httpserver.Port(cfg.HTTP.Port))
 
 	// Waiting signal
@@ -0,0 +1,26 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/romankravchuk/pastebin/pkg/log"
+)
+
+// runPeriodically calls fn every interval until the context is canceled.
+// Errors are logged and do not stop the worker.
+func runPeriodically(ctx context.Context, l *log.Logger, name string, interval time.Duration, fn func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				l.Error("failed to run the worker", err, log.FF{{Key: "worker", Value: name}})
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+)
+
+const bearerPrefix = "Bearer "
+
+// New returns a middleware which authenticates the request by bearer token
+// and stores user id in the request context by entity.UserIDKey.
+//
+// Requests without Authorization header are passed as anonymous.
+func New(uc usecase.Auth, l *log.Logger) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			token, ok := strings.CutPrefix(header, bearerPrefix)
+			if !ok || token == "" {
+				response.Unauthorized(w, r)
+
+				return
+			}
+
+			user, err := uc.Authenticate(r.Context(), token)
+			if err != nil {
+				l.Warn("failed to authenticate user", log.FF{{Key: "error", Value: err.Error()}})
+
+				response.Unauthorized(w, r)
+
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), entity.UserIDKey, user.ID)
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -15,6 +15,13 @@ func OK(w http.ResponseWriter, r *http.Request, v map[string]any) {
 	response(w, r, http.StatusOK, v)
 }
 
+// Raw writes the body as is with the content type.
+func Raw(w http.ResponseWriter, r *http.Request, contentType string, body []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	render.Data(w, r, body)
+}
+
 func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
 	v := map[string]any{"error": "method not allowed"}
 	response(w, r, http.StatusMethodNotAllowed, v)
@@ -50,6 +57,11 @@ func Conflict(w http.ResponseWriter, r *http.Request) {
 	response(w, r, http.StatusConflict, v)
 }
 
+func Gone(w http.ResponseWriter, r *http.Request) {
+	v := map[string]any{"error": "resource gone"}
+	response(w, r, http.StatusGone, v)
+}
+
 func UnprocessableEntity(w http.ResponseWriter, r *http.Request, errs map[string]string) {
 	v := map[string]any{"error": errs}
 	response(w, r, http.StatusUnprocessableEntity, v)
@@ -59,3 +71,8 @@ func TooManyRequests(w http.ResponseWriter, r *http.Request) {
 	v := map[string]any{"error": "too many requests"}
 	response(w, r, http.StatusTooManyRequests, v)
 }
+
+// NotModified tells the client that its cached copy is fresh, the body is empty.
+func NotModified(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotModified)
+}
@@ -0,0 +1,454 @@
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/converter"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+	"github.com/romankravchuk/pastebin/pkg/validator"
+)
+
+type handler struct {
+	l  *log.Logger
+	uc usecase.Collections
+	tm time.Duration
+}
+
+func MountRoutes(mux chi.Router, uc usecase.Collections, l *log.Logger) {
+	h := &handler{
+		l:  l,
+		uc: uc,
+		tm: 10 * time.Second,
+	}
+
+	mux.Route("/collections", func(r chi.Router) {
+		r.Post("/", h.HandleCreateCollection)
+		r.Route("/{hash}", func(r chi.Router) {
+			r.Get("/", h.HandleGetCollection)
+			r.Put("/", h.HandleUpdateCollection)
+			r.Patch("/", h.HandleUpdateCollection)
+			r.Delete("/", h.HandleDeleteCollection)
+			r.Post("/pastes", h.HandleAddCollectionPaste)
+			r.Put("/pastes", h.HandleReorderCollection)
+			r.Delete("/pastes/{paste}", h.HandleRemoveCollectionPaste)
+			r.Post("/grants", h.HandleGrantCollectionAccess)
+			r.Delete("/grants/{username}", h.HandleRevokeCollectionAccess)
+		})
+	})
+}
+
+// HandleCreateCollection godoc
+//
+//	@summary		Создание коллекции
+//	@description	Создаёт упорядоченную коллекцию паст текущего пользователя, доступную по собственному хешу.
+//	@description	Пользователь должен иметь доступ ко всем пастам коллекции.
+//	@tags			collections
+//	@accept			json
+//	@produce		json
+//	@param			collection	body		entity.CreateCollectionBody	true	"Коллекция"
+//	@success		200			{object}	any{message=string,data=any{collection=entity.CollectionResponse,location=string}}
+//	@failure		400			{object}	any{error=string}
+//	@failure		401			{object}	any{error=string}
+//	@failure		404			{object}	any{error=string}
+//	@failure		410			{object}	any{error=string}
+//	@failure		422			{object}	any{error=any{field=string}}
+//	@failure		500			{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections [post]
+func (h *handler) HandleCreateCollection(w http.ResponseWriter, r *http.Request) {
+	input := new(entity.CreateCollectionBody)
+	if !h.decode(w, r, input) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	c := converter.CreateCollectionToEntity(input)
+
+	if err := h.uc.Create(ctx, c); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "input", Value: input}})
+
+		return
+	}
+
+	location := fmt.Sprintf("%s/%s", r.URL.String(), c.Hash)
+
+	w.Header().Add("Location", location)
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"location":   location,
+			"collection": converter.CollectionToResponse(c),
+		},
+	})
+}
+
+// HandleGetCollection godoc
+//
+//	@summary		Получение коллекции
+//	@description	Возвращает коллекцию с метаданными паст в порядке следования без их текстов.
+//	@description	Сгоревшие пасты и приватные пасты, к которым у пользователя нет доступа, не возвращаются.
+//	@tags			collections
+//	@produce		json
+//	@param			hash	path		string	true	"Хеш коллекции"
+//	@success		200		{object}	any{message=string,data=any{collection=entity.CollectionResponse}}
+//	@failure		404		{object}	any{error=string}
+//	@failure		500		{object}	any{error=string}
+//	@router			/collections/{hash} [get]
+func (h *handler) HandleGetCollection(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	c, err := h.uc.Get(ctx, hash)
+	if err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"collection": converter.CollectionToResponse(c),
+		},
+	})
+}
+
+// HandleUpdateCollection godoc
+//
+//	@summary		Изменение коллекции
+//	@description	Переименовывает коллекцию или меняет её видимость. Изменить коллекцию может только её владелец.
+//	@tags			collections
+//	@accept			json
+//	@produce		json
+//	@param			hash		path		string						true	"Хеш коллекции"
+//	@param			collection	body		entity.UpdateCollectionBody	true	"Изменения"
+//	@success		200			{object}	any{message=string,data=any{collection=entity.CollectionResponse}}
+//	@failure		400			{object}	any{error=string}
+//	@failure		403			{object}	any{error=string}
+//	@failure		404			{object}	any{error=string}
+//	@failure		422			{object}	any{error=any{field=string}}
+//	@failure		500			{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections/{hash} [put]
+//	@router			/collections/{hash} [patch]
+func (h *handler) HandleUpdateCollection(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.UpdateCollectionBody)
+	if !h.decode(w, r, input) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	c := converter.UpdateCollectionToEntity(hash, input)
+
+	if err := h.uc.Update(ctx, c); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "input", Value: input}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"collection": converter.CollectionToResponse(c),
+		},
+	})
+}
+
+// HandleDeleteCollection godoc
+//
+//	@summary		Удаление коллекции
+//	@description	Удаляет коллекцию, пасты коллекции остаются. Удалить коллекцию может только её владелец.
+//	@tags			collections
+//	@produce		json
+//	@param			hash	path		string	true	"Хеш коллекции"
+//	@success		200		{object}	any{message=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections/{hash} [delete]
+func (h *handler) HandleDeleteCollection(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.Delete(ctx, hash); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+// HandleAddCollectionPaste godoc
+//
+//	@summary		Добавление пасты в коллекцию
+//	@description	Добавляет пасту в конец коллекции. Владелец коллекции должен иметь доступ к пасте.
+//	@tags			collections
+//	@accept			json
+//	@produce		json
+//	@param			hash	path		string							true	"Хеш коллекции"
+//	@param			paste	body		entity.AddCollectionPasteBody	true	"Паста"
+//	@success		200		{object}	any{message=string}
+//	@failure		400		{object}	any{error=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		409		{object}	any{error=string}
+//	@failure		410		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections/{hash}/pastes [post]
+func (h *handler) HandleAddCollectionPaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.AddCollectionPasteBody)
+	if !h.decode(w, r, input) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.AddPaste(ctx, hash, input.Hash); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "paste", Value: input.Hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+// HandleReorderCollection godoc
+//
+//	@summary		Изменение порядка паст коллекции
+//	@description	Задаёт новый порядок паст коллекции, в запросе должны быть перечислены все пасты коллекции.
+//	@tags			collections
+//	@accept			json
+//	@produce		json
+//	@param			hash	path		string							true	"Хеш коллекции"
+//	@param			order	body		entity.ReorderCollectionBody	true	"Порядок паст"
+//	@success		200		{object}	any{message=string}
+//	@failure		400		{object}	any{error=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections/{hash}/pastes [put]
+func (h *handler) HandleReorderCollection(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.ReorderCollectionBody)
+	if !h.decode(w, r, input) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.Reorder(ctx, hash, input.Pastes); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "input", Value: input}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+// HandleRemoveCollectionPaste godoc
+//
+//	@summary		Удаление пасты из коллекции
+//	@description	Убирает пасту из коллекции, сама паста остаётся.
+//	@tags			collections
+//	@produce		json
+//	@param			hash	path		string	true	"Хеш коллекции"
+//	@param			paste	path		string	true	"Хеш пасты"
+//	@success		200		{object}	any{message=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections/{hash}/pastes/{paste} [delete]
+func (h *handler) HandleRemoveCollectionPaste(w http.ResponseWriter, r *http.Request) {
+	var (
+		hash  = chi.URLParam(r, "hash")
+		paste = chi.URLParam(r, "paste")
+	)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.RemovePaste(ctx, hash, paste); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "paste", Value: paste}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+// HandleGrantCollectionAccess godoc
+//
+//	@summary		Выдача доступа к коллекции
+//	@description	Выдаёт пользователю доступ к приватной коллекции. Доступ к приватным пастам коллекции
+//	@description	выдаётся отдельно. Выдать доступ может только владелец коллекции.
+//	@tags			collections
+//	@accept			json
+//	@produce		json
+//	@param			hash	path		string					true	"Хеш коллекции"
+//	@param			grant	body		entity.GrantAccessBody	true	"Пользователь"
+//	@success		200		{object}	any{message=string}
+//	@failure		400		{object}	any{error=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections/{hash}/grants [post]
+func (h *handler) HandleGrantCollectionAccess(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.GrantAccessBody)
+	if !h.decode(w, r, input) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.GrantAccess(ctx, hash, input.Username); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "username", Value: input.Username}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+// HandleRevokeCollectionAccess godoc
+//
+//	@summary		Отзыв доступа к коллекции
+//	@description	Отзывает доступ пользователя к приватной коллекции. Отозвать доступ может только владелец коллекции.
+//	@tags			collections
+//	@produce		json
+//	@param			hash		path		string	true	"Хеш коллекции"
+//	@param			username	path		string	true	"Имя пользователя"
+//	@success		200			{object}	any{message=string}
+//	@failure		403			{object}	any{error=string}
+//	@failure		404			{object}	any{error=string}
+//	@failure		500			{object}	any{error=string}
+//	@security		Bearer
+//	@router			/collections/{hash}/grants/{username} [delete]
+func (h *handler) HandleRevokeCollectionAccess(w http.ResponseWriter, r *http.Request) {
+	var (
+		hash     = chi.URLParam(r, "hash")
+		username = chi.URLParam(r, "username")
+	)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.RevokeAccess(ctx, hash, username); err != nil {
+		h.handleError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "username", Value: username}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+// decode decodes and validates the request body into input.
+// On failure it writes the error response and returns false.
+func (h *handler) decode(w http.ResponseWriter, r *http.Request, input any) bool {
+	if err := render.DecodeJSON(r.Body, input); err != nil {
+		h.l.Error("failed to parse input data", err, log.FF{{Key: "input", Value: input}})
+
+		response.BadRequest(w, r)
+
+		return false
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err, log.FF{{Key: "input", Value: input}})
+
+		response.InternalServerError(w, r)
+
+		return false
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return false
+	}
+
+	return true
+}
+
+func (h *handler) handleError(w http.ResponseWriter, r *http.Request, err error, fields log.FF) {
+	switch {
+	case errors.Is(err, context.Canceled):
+	case errors.Is(err, usecase.ErrUnauthorized):
+		h.l.Warn("unable to create collection of anonymous user", fields)
+
+		response.Unauthorized(w, r)
+	case errors.Is(err, usecase.ErrCollectionNotFound),
+		errors.Is(err, usecase.ErrPasteNotFound),
+		errors.Is(err, usecase.ErrUserNotFound):
+		h.l.Warn("unable to manage collection", fields)
+
+		response.NotFound(w, r)
+	case errors.Is(err, usecase.ErrNotCollectionOwner):
+		h.l.Warn("unable to manage collection", fields)
+
+		response.Forbidden(w, r)
+	case errors.Is(err, usecase.ErrPasteExpired):
+		h.l.Warn("the paste is expired", fields)
+
+		response.Gone(w, r)
+	case errors.Is(err, usecase.ErrCollectionPasteExists):
+		h.l.Warn("unable to manage collection", fields)
+
+		response.Conflict(w, r)
+	case errors.Is(err, usecase.ErrCollectionFull):
+		h.l.Info("failed to validate input data", fields)
+
+		response.UnprocessableEntity(w, r, map[string]string{"Pastes": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidOrder):
+		h.l.Info("failed to validate input data", fields)
+
+		response.UnprocessableEntity(w, r, map[string]string{"Pastes": err.Error()})
+	default:
+		h.l.Error("failed to manage collection", err, fields)
+
+		response.InternalServerError(w, r)
+	}
+}
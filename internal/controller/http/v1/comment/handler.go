@@ -0,0 +1,331 @@
+package comment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/converter"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+	"github.com/romankravchuk/pastebin/pkg/validator"
+)
+
+// passwordHeader is a header with a password to unlock the paste on GET requests.
+const passwordHeader = "X-Paste-Password"
+
+type handler struct {
+	l  *log.Logger
+	uc usecase.Comments
+	tm time.Duration
+}
+
+func MountRoutes(mux chi.Router, uc usecase.Comments, l *log.Logger) {
+	h := &handler{
+		l:  l,
+		uc: uc,
+		tm: 10 * time.Second,
+	}
+
+	mux.Route("/pastes/{hash}/comments", func(r chi.Router) {
+		r.Get("/", h.HandleGetPasteComments)
+		r.Post("/", h.HandleCreatePasteComment)
+		r.Patch("/{id}", h.HandleUpdatePasteComment)
+		r.Delete("/{id}", h.HandleDeletePasteComment)
+	})
+}
+
+// HandleGetPasteComments godoc
+//
+//	@summary		Комментарии к пасте
+//	@description	Возвращает ветки комментариев к пасте от старых к новым, ответы вложены в комментарии.
+//	@description	Если паста защищена паролем, то его нужно передать в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{object}	any{message=string,data=any{comments=[]entity.CommentResponse}}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/comments [get]
+func (h *handler) HandleGetPasteComments(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	comments, err := h.uc.ListComments(ctx, hash, r.Header.Get(passwordHeader))
+	if err != nil {
+		h.handleCommentError(w, r, err, log.FF{{Key: "Hash", Value: hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"comments": converter.CommentsToResponse(comments),
+		},
+	})
+}
+
+// HandleCreatePasteComment godoc
+//
+//	@summary		Создание комментария к пасте
+//	@description	Комментирует пасту целиком или диапазон строк её текста, либо отвечает на комментарий.
+//	@description	Строки относятся к текущей ревизии пасты.
+//	@description	Если паста защищена паролем, то его нужно передать в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@accept			json
+//	@produce		json
+//	@param			hash				path		string						true	"Хеш пасты"
+//	@param			comment				body		entity.CreateCommentBody	true	"Комментарий"
+//	@param			X-Paste-Password	header		string						false	"Пароль пасты"
+//	@success		200					{object}	any{message=string,data=any{comment=entity.CommentResponse}}
+//	@failure		400					{object}	any{error=string}
+//	@failure		401					{object}	any{error=string}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		422					{object}	any{error=any{field=string}}
+//	@failure		500					{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/comments [post]
+func (h *handler) HandleCreatePasteComment(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.CreateCommentBody)
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		h.l.Error("failed to parse input data", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	comment := converter.CreateCommentToEntity(hash, input)
+
+	if err := h.uc.CreateComment(ctx, r.Header.Get(passwordHeader), comment); err != nil {
+		h.handleCommentError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "input", Value: input}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"comment": converter.CommentToResponse(comment),
+		},
+	})
+}
+
+// HandleUpdatePasteComment godoc
+//
+//	@summary		Изменение комментария к пасте
+//	@description	Изменяет текст комментария. Изменить комментарий может только его автор.
+//	@description	Если паста защищена паролем, то его нужно передать в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@accept			json
+//	@produce		json
+//	@param			hash				path		string						true	"Хеш пасты"
+//	@param			id					path		int							true	"Идентификатор комментария"
+//	@param			comment				body		entity.UpdateCommentBody	true	"Комментарий"
+//	@param			X-Paste-Password	header		string						false	"Пароль пасты"
+//	@success		200					{object}	any{message=string,data=any{comment=entity.CommentResponse}}
+//	@failure		400					{object}	any{error=string}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		422					{object}	any{error=any{field=string}}
+//	@failure		500					{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/comments/{id} [patch]
+func (h *handler) HandleUpdatePasteComment(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		h.l.Warn("invalid comment id", log.FF{{Key: "id", Value: chi.URLParam(r, "id")}})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	input := new(entity.UpdateCommentBody)
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		h.l.Error("failed to parse input data", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	comment := converter.UpdateCommentToEntity(hash, id, input)
+
+	if err := h.uc.UpdateComment(ctx, r.Header.Get(passwordHeader), comment); err != nil {
+		h.handleCommentError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "id", Value: id}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"comment": converter.CommentToResponse(comment),
+		},
+	})
+}
+
+// HandleDeletePasteComment godoc
+//
+//	@summary		Удаление комментария к пасте
+//	@description	Удаляет комментарий. Удалить комментарий может его автор или автор пасты.
+//	@description	Комментарий с ответами остаётся в ветке без текста.
+//	@description	Если паста защищена паролем, то его нужно передать в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			id					path		int		true	"Идентификатор комментария"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{object}	any{message=string}
+//	@failure		400					{object}	any{error=string}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		500					{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/comments/{id} [delete]
+func (h *handler) HandleDeletePasteComment(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		h.l.Warn("invalid comment id", log.FF{{Key: "id", Value: chi.URLParam(r, "id")}})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.DeleteComment(ctx, hash, r.Header.Get(passwordHeader), id); err != nil {
+		h.handleCommentError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "id", Value: id}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+func (h *handler) handleCommentError(w http.ResponseWriter, r *http.Request, err error, fields log.FF) {
+	switch {
+	case errors.Is(err, context.Canceled):
+	case errors.Is(err, usecase.ErrUnauthorized):
+		h.l.Warn("unable to comment paste by anonymous user", fields)
+
+		response.Unauthorized(w, r)
+	case errors.Is(err, usecase.ErrPasteNotFound), errors.Is(err, usecase.ErrCommentNotFound):
+		h.l.Warn("unable to get paste comment", fields)
+
+		response.NotFound(w, r)
+	case errors.Is(err, usecase.ErrPasteLocked):
+		h.l.Warn("the paste lock for public review", fields)
+
+		response.Forbidden(w, r)
+	case errors.Is(err, usecase.ErrNotCommentAuthor):
+		h.l.Warn("unable to change paste comment", fields)
+
+		response.Forbidden(w, r)
+	case errors.Is(err, usecase.ErrPasteExpired):
+		h.l.Warn("the paste is expired", fields)
+
+		response.Gone(w, r)
+	case errors.Is(err, usecase.ErrInvalidCommentLines):
+		h.l.Info("failed to validate input data", fields)
+
+		response.UnprocessableEntity(w, r, map[string]string{"line_start": err.Error()})
+	case errors.Is(err, usecase.ErrInvalidReply):
+		h.l.Info("failed to validate input data", fields)
+
+		response.UnprocessableEntity(w, r, map[string]string{"parent_id": err.Error()})
+	default:
+		h.l.Error("failed to manage paste comments", err, fields)
+
+		response.InternalServerError(w, r)
+	}
+}
@@ -0,0 +1,210 @@
+package feed
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/converter"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+)
+
+type handler struct {
+	l  *log.Logger
+	uc usecase.Feeds
+	tm time.Duration
+	// baseURL is the configured scheme and host of absolute links in feeds.
+	baseURL string
+}
+
+func MountRoutes(mux chi.Router, uc usecase.Feeds, l *log.Logger, baseURL string) {
+	h := &handler{
+		l:       l,
+		uc:      uc,
+		tm:      10 * time.Second,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+
+	mux.Get("/pastes/recent", h.HandleGetRecentPastes)
+	mux.Get("/users/{username}/feed.atom", h.HandleGetUserFeed)
+	mux.Get("/users/{username}/feed.rss", h.HandleGetUserFeed)
+}
+
+// HandleGetRecentPastes godoc
+//
+//	@summary		Лента новых паст
+//	@description	Возвращает последние публичные пасты всех пользователей от новых к старым с началом текста.
+//	@description	Пасты с паролем, сжигаемые после прочтения и с ограничением просмотров в ленту не попадают.
+//	@description	Поддерживаются условные запросы с заголовками `If-None-Match` и `If-Modified-Since`.
+//	@tags			pastes
+//	@produce		json
+//	@param			limit	query		int	false	"Число паст, от 1 до 50"	default(20)
+//	@success		200		{object}	any{message=string,data=any{pastes=[]entity.FeedEntryResponse}}
+//	@success		304
+//	@failure		422	{object}	any{error=any{field=string}}
+//	@failure		500	{object}	any{error=string}
+//	@router			/pastes/recent [get]
+func (h *handler) HandleGetRecentPastes(w http.ResponseWriter, r *http.Request) {
+	limit := feedLimit
+
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxFeedLimit {
+			h.l.Info("failed to validate input data", log.FF{{Key: "limit", Value: raw}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"limit": fmt.Sprintf("must be from 1 to %d", maxFeedLimit)})
+
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	feed, err := h.uc.Recent(ctx)
+	if err != nil {
+		h.handleFeedError(w, r, err, nil)
+
+		return
+	}
+
+	if len(feed.Entries) > limit {
+		feed.Entries = feed.Entries[:limit]
+	}
+
+	if notModified(w, r, feed) {
+		response.NotModified(w, r)
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"pastes": converter.FeedToResponse(feed),
+		},
+	})
+}
+
+// HandleGetUserFeed godoc
+//
+//	@summary		Лента пользователя
+//	@description	Возвращает ленту последних публичных паст пользователя в формате Atom или RSS
+//	@description	в зависимости от расширения пути. Записи содержат название, формат, дату создания
+//	@description	и начало текста пасты. Поддерживаются условные запросы с заголовками `If-None-Match`
+//	@description	и `If-Modified-Since`.
+//	@tags			pastes
+//	@produce		xml
+//	@param			username	path	string	true	"Имя пользователя"
+//	@success		200
+//	@success		304
+//	@failure		404	{object}	any{error=string}
+//	@failure		500	{object}	any{error=string}
+//	@router			/users/{username}/feed.atom [get]
+//	@router			/users/{username}/feed.rss [get]
+func (h *handler) HandleGetUserFeed(w http.ResponseWriter, r *http.Request) {
+	username := chi.URLParam(r, "username")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	feed, err := h.uc.UserFeed(ctx, username)
+	if err != nil {
+		h.handleFeedError(w, r, err, log.FF{{Key: "username", Value: username}})
+
+		return
+	}
+
+	if notModified(w, r, feed) {
+		response.NotModified(w, r)
+
+		return
+	}
+
+	var (
+		// api is the path prefix the router is mounted at.
+		api   = strings.TrimSuffix(r.URL.Path, "/users/"+username+"/"+path.Base(r.URL.Path))
+		base  = h.baseURL
+		title = fmt.Sprintf("Pastes of %s", username)
+		self  = base + r.URL.Path
+		link  = func(hash string) string { return base + api + "/pastes/" + hash + "/html" }
+		doc   any
+		ct    = "application/atom+xml; charset=utf-8"
+	)
+
+	if strings.HasSuffix(r.URL.Path, ".rss") {
+		doc, ct = converter.FeedToRSS(feed, title, self, link), "application/rss+xml; charset=utf-8"
+	} else {
+		doc = converter.FeedToAtom(feed, title, self, link)
+	}
+
+	body, err := xml.Marshal(doc)
+	if err != nil {
+		h.l.Error("failed to marshal feed", err, log.FF{{Key: "username", Value: username}})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	response.Raw(w, r, ct, append([]byte(xml.Header), body...))
+}
+
+func (h *handler) handleFeedError(w http.ResponseWriter, r *http.Request, err error, fields log.FF) {
+	switch {
+	case errors.Is(err, context.Canceled):
+	case errors.Is(err, usecase.ErrUserNotFound):
+		h.l.Warn("unable to get feed", fields)
+
+		response.NotFound(w, r)
+	default:
+		h.l.Error("failed to get feed", err, fields)
+
+		response.InternalServerError(w, r)
+	}
+}
+
+const (
+	// feedLimit is a default number of pastes in the recent pastes feed.
+	feedLimit = 20
+	// maxFeedLimit is a max number of pastes in the recent pastes feed.
+	maxFeedLimit = 50
+)
+
+// notModified sets validators of the feed and reports whether the client copy is fresh.
+// If-None-Match takes precedence over If-Modified-Since.
+func notModified(w http.ResponseWriter, r *http.Request, feed *entity.Feed) bool {
+	etag := `"` + feed.ETag() + `"`
+
+	w.Header().Set("ETag", etag)
+
+	if !feed.Updated.IsZero() {
+		w.Header().Set("Last-Modified", feed.Updated.UTC().Format(http.TimeFormat))
+	}
+
+	if match := r.Header.Get("If-None-Match"); match != "" {
+		for _, tag := range strings.Split(match, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == etag || tag == "*" {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+
+	return err == nil && !feed.Updated.IsZero() && !feed.Updated.Truncate(time.Second).After(since)
+}
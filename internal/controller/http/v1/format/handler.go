@@ -0,0 +1,84 @@
+package format
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/converter"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+	"github.com/romankravchuk/pastebin/pkg/validator"
+)
+
+type handler struct {
+	uc usecase.Formats
+	l  *log.Logger
+}
+
+func MountRoutes(mux chi.Router, uc usecase.Formats, l *log.Logger) {
+	h := &handler{
+		uc: uc,
+		l:  l,
+	}
+
+	mux.Route("/formats", func(r chi.Router) {
+		r.Post("/detect", h.HandleDetectFormat)
+	})
+}
+
+// HandleDetectFormat godoc
+//
+//	@summary	Определение формата текста без создания пасты
+//	@tags		formats
+//	@accept		json
+//	@produce	json
+//	@param		text	body		entity.DetectFormatBody	true	"Текст"
+//	@success	200		{object}	any{message=string,data=any{format=entity.FormatDetectionResponse}}
+//	@failure	400		{object}	any{message=string}
+//	@failure	422		{object}	any{message=string,errors=any{field=string,message=string}}
+//	@failure	500		{object}	any{message=string}
+//	@router		/formats/detect [post]
+func (h *handler) HandleDetectFormat(w http.ResponseWriter, r *http.Request) {
+	input := new(entity.DetectFormatBody)
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		h.l.Error("failed to parse input data", err, nil)
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err, nil)
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	d := h.uc.Detect(r.Context(), converter.DetectFormatToEntity(*input))
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"format": converter.FormatDetectionToResponse(d),
+		},
+	})
+}
@@ -1,11 +1,19 @@
 package paste
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -17,6 +25,9 @@ import (
 	"github.com/romankravchuk/pastebin/pkg/validator"
 )
 
+// passwordHeader is a header with a password to unlock the paste on GET requests.
+const passwordHeader = "X-Paste-Password"
+
 type handler struct {
 	l  *log.Logger
 	uc usecase.Pastes
@@ -31,14 +42,34 @@ func MountRoutes(mux chi.Router, uc usecase.Pastes, l *log.Logger) {
 		tm: 10 * time.Second,
 	}
 
-	mux.Route("/pastes", func(r chi.Router) {
-		r.Post("/", p.HandleCreatePaste)
-		r.Route("/{hash}", func(r chi.Router) {
-			r.Get("/", p.HandleGetPasteByHash)
-			r.Delete("/", p.HandleDeletePaste)
-			r.Post("/unlock", p.HandleUnlockPaste)
-		})
+	mux.Post("/pastes", p.HandleCreatePaste)
+	mux.Get("/pastes/diff", p.HandleDiffPastes)
+	mux.Route("/pastes/{hash}", func(r chi.Router) {
+		r.Get("/", p.HandleGetPasteByHash)
+		r.Get("/raw", p.HandleGetRawPaste)
+		r.Get("/raw/{name}", p.HandleGetRawPasteFile)
+		r.Get("/zip", p.HandleDownloadPasteZip)
+		r.Get("/html", p.HandleGetPasteHTML)
+		r.Get("/convert", p.HandleConvertPaste)
+		r.Post("/convert", p.HandleConvertPaste)
+		r.Get("/query", p.HandleQueryPaste)
+		r.Post("/validate", p.HandleValidatePaste)
+		r.Put("/", p.HandleUpdatePaste)
+		r.Patch("/", p.HandleUpdatePaste)
+		r.Delete("/", p.HandleDeletePaste)
+		r.Post("/unlock", p.HandleUnlockPaste)
+		r.Get("/revisions", p.HandleGetPasteRevisions)
+		r.Get("/revisions/{revision}", p.HandleGetPasteRevision)
+		r.Get("/diff", p.HandleDiffPasteRevisions)
+		r.Post("/fork", p.HandleForkPaste)
+		r.Get("/forks", p.HandleGetPasteForks)
+		r.Post("/extend", p.HandleExtendPaste)
+		r.Get("/grants", p.HandleGetPasteGrants)
+		r.Post("/grants", p.HandleGrantPasteAccess)
+		r.Delete("/grants/{username}", p.HandleRevokePasteAccess)
 	})
+
+	mux.Get("/users/me/pastes", p.HandleListUserPastes)
 }
 
 // HandleCreatePaste godoc
@@ -48,8 +79,10 @@ func MountRoutes(mux chi.Router, uc usecase.Pastes, l *log.Logger) {
 //	@accept		json
 //	@produce	json
 //	@param		paste	body		entity.CreatePasteBody	true	"Паста"
+//	@param		strict	query		bool					false	"Отклонять текст, не соответствующий формату json, yaml, toml или xml, по умолчанию true"
 //	@success	200		{object}	any{message=string,data=any{paste=entity.PasteResponse,url=string}}
 //	@failure	400		{object}	any{message=string}
+//	@failure	401		{object}	any{message=string}
 //	@failure	422		{object}	any{message=string,errors=any{field=string,message=string}}
 //	@failure	500		{object}	any{message=string}
 //	@router		/pastes [post]
@@ -96,6 +129,17 @@ func (h *handler) HandleCreatePaste(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 
 	e, err := converter.CreatePasteToEntity(input)
+	if errors.Is(err, converter.ErrInvalidExpiration) {
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: err},
+		})
+
+		response.UnprocessableEntity(w, r, map[string]string{"Expires": err.Error()})
+
+		return
+	}
+
 	if err != nil {
 		h.l.Error("failed to convert input data to entity", err,
 			log.FF{
@@ -107,11 +151,35 @@ func (h *handler) HandleCreatePaste(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	e.AllowInvalid = !queryStrict(r)
+
 	err = h.uc.Create(ctx, e)
 	if err != nil {
+		if h.handleSchemaError(w, r, err) {
+			return
+		}
+
+		var fe *entity.FormatError
+
 		switch {
 		case errors.Is(err, context.Canceled):
 			return
+		case errors.As(err, &fe):
+			h.l.Info("failed to validate paste format", log.FF{{Key: "error", Value: fe.Error()}})
+
+			response.UnprocessableEntity(w, r, formatErrors(fe))
+		case errors.Is(err, usecase.ErrUnauthorized):
+			h.l.Warn("unable to create never expiring or private paste", log.FF{{Key: "input", Value: input}})
+
+			response.Unauthorized(w, r)
+		case errors.Is(err, usecase.ErrInvalidExpiration):
+			h.l.Info("failed to validate input data", log.FF{{Key: "input", Value: input}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"Expires": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidTags):
+			h.l.Info("failed to validate input data", log.FF{{Key: "input", Value: input}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"Tags": err.Error()})
 		default:
 			h.l.Error("failed to create paste", err, log.FF{
 				{Key: "input", Value: input},
@@ -146,6 +214,7 @@ func (h *handler) HandleCreatePaste(w http.ResponseWriter, r *http.Request) {
 //	@success		200		{object}	any{message=string,data=any{paste=entity.PasteResponse}}
 //	@failure		403		{object}	any{error=string}
 //	@failure		404		{object}	any{error=string}
+//	@failure		410		{object}	any{error=string}
 //	@failure		500		{object}	any{error=string}
 //	@router			/pastes/{hash} [get]
 func (h *handler) HandleGetPasteByHash(w http.ResponseWriter, r *http.Request) {
@@ -162,6 +231,14 @@ func (h *handler) HandleGetPasteByHash(w http.ResponseWriter, r *http.Request) {
 			h.l.Warn("unable to get paste by hash", log.FF{{Key: "Hash", Value: hash}})
 
 			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrPasteGone):
+			h.l.Warn("the paste views limit is reached", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
+		case errors.Is(err, usecase.ErrPasteExpired):
+			h.l.Warn("the paste is expired", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
 		default:
 			h.l.Error("failed to get paste by hash", err, log.FF{{Key: "Hash", Value: hash}})
 
@@ -187,76 +264,431 @@ func (h *handler) HandleGetPasteByHash(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
-// HandleDeletePaste godoc
+// HandleGetRawPaste godoc
 //
-//	@summary	Удаление пасты по хешу
-//	@tags		pastes
-//	@accept		json
-//	@produce	json
-//	@param		hash	path		string	true	"Хеш пасты"
-//	@success	200		{object}	any{message=string}
-//	@failure	403		{object}	any{error=string}
-//	@failure	404		{object}	any{error=string}
-//	@failure	500		{object}	any{error=string}
-//	@security	Bearer
-//	@router		/pastes/{hash} [delete]
-func (h *handler) HandleDeletePaste(w http.ResponseWriter, r *http.Request) {
+//	@summary		Получение текста пасты
+//	@description	Возвращает только текст пасты с Content-Type, соответствующим формату пасты.
+//	@description	Пароль защищённой пасты передаётся в заголовке `X-Paste-Password`.
+//	@description	С параметром `download=1` текст отдаётся как файл с именем из названия пасты.
+//	@description	С параметром `ansi=1` или заголовком `Accept: text/x-ansi` текст подсвечивается ANSI кодами для терминала,
+//	@description	по умолчанию текст отдаётся без изменений.
+//	@tags			pastes
+//	@produce		plain
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			download			query		bool	false	"Скачать как файл"
+//	@param			ansi				query		bool	false	"Подсветка для терминала"
+//	@param			colors				query		string	false	"Палитра терминала"	Enums(256, truecolor)	default(256)
+//	@param			theme				query		string	false	"Тема подсветки"	default(github)
+//	@param			ln					query		bool	false	"Номера строк"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{string}	string
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		422					{object}	any{error=any{field=string}}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/raw [get]
+func (h *handler) HandleGetRawPaste(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Accept")
+
+	download, _ := strconv.ParseBool(r.URL.Query().Get("download"))
+
+	if output, ok := terminalOutput(r); ok && !download {
+		h.writeTerminalPaste(w, r, output)
+
+		return
+	}
+
+	paste, ok := h.readPaste(w, r)
+	if !ok {
+		return
+	}
+
+	if download {
+		attachment(w, filename(paste.Title, paste.Hash, paste.Format))
+	}
+
+	response.Raw(w, r, contentType(paste.Format), paste.File)
+}
+
+// writeTerminalPaste writes the paste highlighted with ANSI escape codes.
+func (h *handler) writeTerminalPaste(w http.ResponseWriter, r *http.Request, output entity.HighlightOutput) {
+	var (
+		hash  = chi.URLParam(r, "hash")
+		ln, _ = strconv.ParseBool(r.URL.Query().Get("ln"))
+		opts  = entity.HighlightOptions{
+			Output:      output,
+			Theme:       r.URL.Query().Get("theme"),
+			LineNumbers: ln,
+		}
+	)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	text, err := h.uc.Highlight(ctx, hash, r.Header.Get(passwordHeader), opts)
+	if err != nil {
+		h.handleReadError(w, r, err, hash)
+
+		return
+	}
+
+	response.Raw(w, r, contentType("plaintext"), text)
+}
+
+// HandleGetRawPasteFile godoc
+//
+//	@summary		Получение текста файла пасты
+//	@description	Возвращает только текст файла многофайловой пасты с Content-Type, соответствующим формату файла.
+//	@description	С параметром `download=1` текст отдаётся как файл с именем файла пасты.
+//	@tags			pastes
+//	@produce		plain
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			name				path		string	true	"Имя файла"
+//	@param			download			query		bool	false	"Скачать как файл"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{string}	string
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/raw/{name} [get]
+func (h *handler) HandleGetRawPasteFile(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "name")
+
+	paste, ok := h.readPaste(w, r)
+	if !ok {
+		return
+	}
+
+	var file *entity.PasteFile
+
+	for _, f := range paste.Files {
+		if f.Name == name {
+			file = f
+
+			break
+		}
+	}
+
+	if file == nil {
+		h.l.Warn("unable to get paste file", log.FF{{Key: "Hash", Value: paste.Hash}, {Key: "Name", Value: name}})
+
+		response.NotFound(w, r)
+
+		return
+	}
+
+	if download, _ := strconv.ParseBool(r.URL.Query().Get("download")); download {
+		attachment(w, file.Name)
+	}
+
+	response.Raw(w, r, contentType(file.Format), file.File)
+}
+
+// HandleDownloadPasteZip godoc
+//
+//	@summary		Скачивание пасты архивом
+//	@description	Возвращает zip архив со всеми файлами пасты.
+//	@tags			pastes
+//	@produce		application/zip
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{file}		binary
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/zip [get]
+func (h *handler) HandleDownloadPasteZip(w http.ResponseWriter, r *http.Request) {
+	paste, ok := h.readPaste(w, r)
+	if !ok {
+		return
+	}
+
+	files := paste.Files
+	if len(files) == 0 {
+		files = []*entity.PasteFile{{
+			Name:   filename(paste.Title, paste.Hash, paste.Format),
+			Format: paste.Format,
+			File:   paste.File,
+		}}
+	}
+
+	buf := new(bytes.Buffer)
+	zw := zip.NewWriter(buf)
+
+	for _, f := range files {
+		fw, err := zw.Create(f.Name)
+		if err == nil {
+			_, err = fw.Write(f.File)
+		}
+
+		if err != nil {
+			h.l.Error("failed to zip paste", err, log.FF{{Key: "Hash", Value: paste.Hash}})
+
+			response.InternalServerError(w, r)
+
+			return
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		h.l.Error("failed to zip paste", err, log.FF{{Key: "Hash", Value: paste.Hash}})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	attachment(w, basename(paste.Title, paste.Hash)+".zip")
+	response.Raw(w, r, "application/zip", buf.Bytes())
+}
+
+// handleReadError writes an error response for a failed read of the paste.
+func (h *handler) handleReadError(w http.ResponseWriter, r *http.Request, err error, hash string) {
+	switch {
+	case errors.Is(err, context.Canceled):
+	case errors.Is(err, usecase.ErrUnknownTheme):
+		h.l.Info("failed to validate input data", log.FF{{Key: "theme", Value: r.URL.Query().Get("theme")}})
+
+		response.UnprocessableEntity(w, r, map[string]string{"theme": err.Error()})
+	case errors.Is(err, usecase.ErrPasteNotFound):
+		h.l.Warn("unable to get paste by hash", log.FF{{Key: "Hash", Value: hash}})
+
+		response.NotFound(w, r)
+	case errors.Is(err, usecase.ErrPasteLocked):
+		h.l.Warn("the paste lock for public review", log.FF{{Key: "hash", Value: hash}})
+
+		response.Forbidden(w, r)
+	case errors.Is(err, usecase.ErrPasteGone):
+		h.l.Warn("the paste views limit is reached", log.FF{{Key: "Hash", Value: hash}})
+
+		response.Gone(w, r)
+	case errors.Is(err, usecase.ErrPasteExpired):
+		h.l.Warn("the paste is expired", log.FF{{Key: "Hash", Value: hash}})
+
+		response.Gone(w, r)
+	default:
+		h.l.Error("failed to get paste by hash", err, log.FF{{Key: "Hash", Value: hash}})
+
+		response.InternalServerError(w, r)
+	}
+}
+
+// readPaste returns a paste for raw representations. The password of a locked paste
+// is taken from the X-Paste-Password header. On failure the error response is
+// already written and ok is false.
+func (h *handler) readPaste(w http.ResponseWriter, r *http.Request) (paste *entity.Paste, ok bool) {
+	var (
+		hash     = chi.URLParam(r, "hash")
+		password = r.Header.Get(passwordHeader)
+		err      error
+	)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if password != "" {
+		paste, err = h.uc.Unlock(ctx, hash, password)
+	} else {
+		paste, err = h.uc.Get(ctx, hash)
+	}
+
+	if err != nil {
+		h.handleReadError(w, r, err, hash)
+
+		return nil, false
+	}
+
+	if password == "" && paste.Password.Hash != nil {
+		h.l.Warn("the paste lock for public review", log.FF{{Key: "hash", Value: hash}})
+
+		response.Forbidden(w, r)
+
+		return nil, false
+	}
+
+	return paste, true
+}
+
+// HandleGetPasteHTML godoc
+//
+//	@summary		Получение пасты с подсветкой синтаксиса
+//	@description	Возвращает HTML документ с текстом пасты, подсвеченным по формату пасты, и номерами строк.
+//	@description	Строки можно выделить параметром `hl`, например `10-20` или `1,5-7`.
+//	@description	Пароль защищённой пасты передаётся в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@produce		html
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			theme				query		string	false	"Тема подсветки"	default(github)
+//	@param			hl					query		string	false	"Выделенные строки, не более 20 диапазонов"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{string}	string
+//	@failure		400					{object}	any{error=string}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		422					{object}	any{error=any{field=string}}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/html [get]
+func (h *handler) HandleGetPasteHTML(w http.ResponseWriter, r *http.Request) {
 	hash := chi.URLParam(r, "hash")
 
+	lines, err := queryLines(r, "hl")
+	if err != nil {
+		h.l.Warn("invalid highlighted lines", log.FF{{Key: "hl", Value: r.URL.Query().Get("hl")}})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	opts := entity.HighlightOptions{
+		Theme: r.URL.Query().Get("theme"),
+		Lines: lines,
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
 	defer cancel()
 
-	err := h.uc.Delete(ctx, hash)
+	html, err := h.uc.Highlight(ctx, hash, r.Header.Get(passwordHeader), opts)
+	if err != nil {
+		h.handleReadError(w, r, err, hash)
+
+		return
+	}
+
+	response.Raw(w, r, "text/html; charset=utf-8", html)
+}
+
+// HandleConvertPaste godoc
+//
+//	@summary		Конвертация пасты в другой формат
+//	@description	Конвертирует текст пасты формата json, yaml, toml или xml в формат `to`, по умолчанию в формат пасты.
+//	@description	Текст форматируется с отступами, с параметром `minify=1` записывается компактно. Порядок ключей сохраняется,
+//	@description	комментарии сохраняются при конвертации из yaml в yaml.
+//	@description	Запрос POST с параметром `save=1` сохраняет результат как новую пасту, связанную с исходной,
+//	@description	с паролем, сжиганием после прочтения и лимитом просмотров исходной пасты,
+//	@description	и возвращает её в формате `{message, data: {location, paste}}`.
+//	@description	Пароль защищённой пасты передаётся в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@produce		plain
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			to					query		string	false	"Формат результата"	Enums(json, yaml, toml, xml)
+//	@param			minify				query		bool	false	"Записать компактно"
+//	@param			save				query		bool	false	"Сохранить как новую пасту, только для POST"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{string}	string
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		422					{object}	any{error=any{field=string}}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/convert [get]
+//	@router			/pastes/{hash}/convert [post]
+func (h *handler) HandleConvertPaste(w http.ResponseWriter, r *http.Request) {
+	var (
+		hash      = chi.URLParam(r, "hash")
+		query     = r.URL.Query()
+		minify, _ = strconv.ParseBool(query.Get("minify"))
+		save, _   = strconv.ParseBool(query.Get("save"))
+		opts      = entity.ConvertOptions{To: query.Get("to"), Minify: minify}
+	)
+
+	switch opts.To {
+	case "", "json", "yaml", "toml", "xml":
+	default:
+		h.l.Info("failed to validate input data", log.FF{{Key: "to", Value: opts.To}})
+
+		response.UnprocessableEntity(w, r, map[string]string{"to": "must be one of json yaml toml xml"})
+
+		return
+	}
+
+	save = save && r.Method == http.MethodPost
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	e := converter.ConvertPasteToEntity(hash)
+
+	err := h.uc.Convert(ctx, e, r.Header.Get(passwordHeader), opts, save)
 	if err != nil {
+		var fe *entity.FormatError
+
 		switch {
 		case errors.Is(err, context.Canceled):
-		case errors.Is(err, usecase.ErrPasteNotFound):
-			h.l.Warn("unable to delete paste by hash", log.FF{{Key: "Hash", Value: hash}})
+		case errors.Is(err, usecase.ErrNotConvertible):
+			h.l.Info("unable to convert paste", log.FF{{Key: "Hash", Value: hash}, {Key: "to", Value: opts.To}})
 
-			response.NotFound(w, r)
-		case errors.Is(err, usecase.ErrNotPasteAuthor):
-			h.l.Warn("unable to delete paste by hash", log.FF{{Key: "Hash", Value: hash}})
+			response.UnprocessableEntity(w, r, map[string]string{"to": err.Error()})
+		case errors.As(err, &fe):
+			h.l.Info("the paste text does not match its format", log.FF{{Key: "Hash", Value: hash}, {Key: "error", Value: fe.Error()}})
 
-			response.Forbidden(w, r)
+			response.UnprocessableEntity(w, r, formatErrors(fe))
 		default:
-			h.l.Error("unable to delete paste by hash", err, log.FF{{Key: "Hash", Value: hash}})
-
-			response.InternalServerError(w, r)
+			h.handleReadError(w, r, err, hash)
 		}
 
 		return
 	}
 
+	if !save {
+		response.Raw(w, r, contentType(e.Format), e.File)
+
+		return
+	}
+
+	location := fmt.Sprintf("%s/%s", strings.TrimSuffix(r.URL.Path, "/"+hash+"/convert"), e.Hash)
+
+	w.Header().Add("Location", location)
 	response.OK(w, r, render.M{
 		"message": "ok",
+		"data": render.M{
+			"location": location,
+			"paste":    converter.ModelToResponse(e),
+		},
 	})
 }
 
-// HandleUnlockPaste godoc
+// HandleQueryPaste godoc
 //
-//	@summary	Получение доступа к пасте с паролем.
-//	@tags		pastes
-//	@accept		json
-//	@produce	json
-//	@param		hash		path		string					true	"Хеш пасты"
-//	@param		credentials	body		entity.UnlockPasteBody	true	"Пароль"
-//	@success	200			{object}	any{message=string,data=any{paste=entity.PasteResponse}}
-//	@failure	403			{object}	any{error=string}
-//	@failure	404			{object}	any{error=string}
-//	@failure	500			{object}	any{error=string}
-//	@router		/pastes/{hash}/unlock [post]
-func (h *handler) HandleUnlockPaste(w http.ResponseWriter, r *http.Request) {
-	hash := chi.URLParam(r, "hash")
-
-	var input entity.UnlockPasteBody
+//	@summary		Запрос к структурированной пасте
+//	@description	Вычисляет выражение `expr` по тексту пасты формата json, yaml, toml или xml и возвращает найденные значения.
+//	@description	Поддерживаются выражения в стиле jq, например `.spec.containers[0].image` или `.items[] | select(.enabled) | .name`,
+//	@description	и JSONPath, начинающиеся с `$`, например `$..containers[?(@.name == 'app')].image`.
+//	@description	Время вычисления и размер результата ограничены. С параметром `raw=1` значения возвращаются
+//	@description	текстом по одному на строку, строки без кавычек.
+//	@description	Пароль защищённой пасты передаётся в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@produce		json
+//	@produce		plain
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			expr				query		string	true	"Выражение"
+//	@param			raw					query		bool	false	"Вернуть значения текстом"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{object}	any{message=string,data=any{results=[]any}}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		422					{object}	any{error=any{field=string}}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/query [get]
+func (h *handler) HandleQueryPaste(w http.ResponseWriter, r *http.Request) {
+	var (
+		hash   = chi.URLParam(r, "hash")
+		expr   = r.URL.Query().Get("expr")
+		raw, _ = strconv.ParseBool(r.URL.Query().Get("raw"))
+	)
 
-	if err := render.DecodeJSON(r.Body, &input); err != nil {
-		h.l.Error("failed to parse input data", err,
-			log.FF{
-				{Key: "input", Value: input},
-			})
+	if strings.TrimSpace(expr) == "" {
+		h.l.Info("failed to validate input data", log.FF{{Key: "expr", Value: expr}})
 
-		response.BadRequest(w, r)
+		response.UnprocessableEntity(w, r, map[string]string{"expr": "required"})
 
 		return
 	}
@@ -264,27 +696,40 @@ func (h *handler) HandleUnlockPaste(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
 	defer cancel()
 
-	paste, err := h.uc.Get(ctx, hash)
+	results, err := h.uc.Query(ctx, hash, r.Header.Get(passwordHeader), expr)
 	if err != nil {
+		var (
+			qe *entity.QueryError
+			fe *entity.FormatError
+		)
+
 		switch {
 		case errors.Is(err, context.Canceled):
-		case errors.Is(err, usecase.ErrPasteNotFound):
-			h.l.Warn("unable to get paste by hash", log.FF{{Key: "Hash", Value: hash}})
+		case errors.As(err, &qe):
+			h.l.Info("failed to parse query expression", log.FF{{Key: "expr", Value: expr}, {Key: "error", Value: qe.Error()}})
 
-			response.NotFound(w, r)
-		default:
-			h.l.Error("failed to get paste by hash", err, log.FF{{Key: "Hash", Value: hash}})
+			response.UnprocessableEntity(w, r, map[string]string{"expr": qe.Error()})
+		case errors.Is(err, usecase.ErrQueryLimit):
+			h.l.Warn("the query exceeds the limits", log.FF{{Key: "Hash", Value: hash}, {Key: "expr", Value: expr}})
 
-			response.InternalServerError(w, r)
+			response.UnprocessableEntity(w, r, map[string]string{"expr": usecase.ErrQueryLimit.Error()})
+		case errors.Is(err, usecase.ErrNotQueryable):
+			h.l.Info("unable to query paste", log.FF{{Key: "Hash", Value: hash}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"format": usecase.ErrNotQueryable.Error()})
+		case errors.As(err, &fe):
+			h.l.Info("the paste text does not match its format", log.FF{{Key: "Hash", Value: hash}, {Key: "error", Value: fe.Error()}})
+
+			response.UnprocessableEntity(w, r, formatErrors(fe))
+		default:
+			h.handleReadError(w, r, err, hash)
 		}
 
 		return
 	}
 
-	if !paste.Password.Matches(input.Password) {
-		h.l.Warn("failed to unlock paste: invalid password", log.FF{{Key: "hash", Value: hash}})
-
-		response.Forbidden(w, r)
+	if raw {
+		response.Raw(w, r, contentType("plaintext"), rawResults(results))
 
 		return
 	}
@@ -292,7 +737,1392 @@ func (h *handler) HandleUnlockPaste(w http.ResponseWriter, r *http.Request) {
 	response.OK(w, r, render.M{
 		"message": "ok",
 		"data": render.M{
-			"paste": converter.ModelToResponse(paste),
+			"results": results,
 		},
 	})
 }
+
+// rawResults writes query results one per line, strings are written without quotes.
+func rawResults(results []json.RawMessage) []byte {
+	var b bytes.Buffer
+
+	for _, res := range results {
+		var s string
+		if err := json.Unmarshal(res, &s); err == nil {
+			b.WriteString(s)
+		} else {
+			b.Write(res)
+		}
+
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes()
+}
+
+// HandleValidatePaste godoc
+//
+//	@summary		Проверка пасты по JSON схеме
+//	@description	Проверяет текст пасты формата json, yaml или toml по JSON схеме из пасты `schema`,
+//	@description	по умолчанию по схеме, указанной при создании пасты. Возвращает нарушения схемы с JSON указателями на значения.
+//	@description	Паста со схемой не должна быть защищена паролем. Поддерживаются локальные ссылки `$ref`.
+//	@description	Пароль защищённой пасты передаётся в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			schema				query		string	false	"Хеш пасты с JSON схемой"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{object}	any{message=string,data=any{validation=entity.SchemaValidationResponse}}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		422					{object}	any{error=any{field=string}}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/validate [post]
+func (h *handler) HandleValidatePaste(w http.ResponseWriter, r *http.Request) {
+	var (
+		hash   = chi.URLParam(r, "hash")
+		schema = r.URL.Query().Get("schema")
+	)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	violations, err := h.uc.ValidateSchema(ctx, hash, r.Header.Get(passwordHeader), schema)
+	if err != nil {
+		if h.handleSchemaError(w, r, err) {
+			return
+		}
+
+		var fe *entity.FormatError
+
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.As(err, &fe):
+			h.l.Info("the paste text does not match its format", log.FF{{Key: "Hash", Value: hash}, {Key: "error", Value: fe.Error()}})
+
+			response.UnprocessableEntity(w, r, formatErrors(fe))
+		default:
+			h.handleReadError(w, r, err, hash)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"validation": converter.SchemaValidationToResponse(violations),
+		},
+	})
+}
+
+// handleSchemaError writes the response of JSON schema errors and reports whether the error is one of them.
+func (h *handler) handleSchemaError(w http.ResponseWriter, r *http.Request, err error) bool {
+	var se *entity.SchemaError
+
+	switch {
+	case errors.As(err, &se):
+		h.l.Info("the paste text does not match its schema", log.FF{{Key: "error", Value: se.Error()}})
+
+		response.UnprocessableEntity(w, r, schemaErrors(se))
+	case errors.Is(err, usecase.ErrSchemaNotFound):
+		h.l.Info("unable to get paste schema", log.FF{{Key: "schema", Value: r.URL.Query().Get("schema")}})
+
+		response.UnprocessableEntity(w, r, map[string]string{"schema": usecase.ErrSchemaNotFound.Error()})
+	case errors.Is(err, usecase.ErrInvalidSchema):
+		h.l.Info("the schema paste is invalid", log.FF{{Key: "error", Value: err.Error()}})
+
+		response.UnprocessableEntity(w, r, map[string]string{"schema": err.Error()})
+	case errors.Is(err, usecase.ErrSchemaNotApplicable):
+		h.l.Info("unable to validate paste against schema", log.FF{{Key: "error", Value: err.Error()}})
+
+		response.UnprocessableEntity(w, r, map[string]string{"format": usecase.ErrSchemaNotApplicable.Error()})
+	default:
+		return false
+	}
+
+	return true
+}
+
+// HandleUpdatePaste godoc
+//
+//	@summary		Изменение пасты по хешу
+//	@description	Изменяет текст, название, формат, пароль и время жизни пасты.
+//	@description	Пустые поля остаются без изменений. Изменять пасту может только её автор.
+//	@description	Тексты файлов многофайловой пасты изменяются по именам файлов, неизвестное имя файла возвращает 422.
+//	@tags			pastes
+//	@accept			json
+//	@produce		json
+//	@param			hash	path		string					true	"Хеш пасты"
+//	@param			paste	body		entity.UpdatePasteBody	true	"Изменения"
+//	@param			strict	query		bool					false	"Отклонять текст, не соответствующий формату json, yaml, toml или xml, по умолчанию true"
+//	@success		200		{object}	any{message=string,data=any{paste=entity.PasteResponse}}
+//	@failure		400		{object}	any{error=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash} [put]
+//	@router			/pastes/{hash} [patch]
+func (h *handler) HandleUpdatePaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.UpdatePasteBody)
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		h.l.Error("failed to parse input data", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	e, err := converter.UpdatePasteToEntity(hash, input)
+	if errors.Is(err, converter.ErrInvalidExpiration) {
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: err},
+		})
+
+		response.UnprocessableEntity(w, r, map[string]string{"Expires": err.Error()})
+
+		return
+	}
+
+	if err != nil {
+		h.l.Error("failed to convert input data to entity", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	e.AllowInvalid = !queryStrict(r)
+
+	err = h.uc.Update(ctx, e)
+	if err != nil {
+		if h.handleSchemaError(w, r, err) {
+			return
+		}
+
+		var fe *entity.FormatError
+
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.As(err, &fe):
+			h.l.Info("failed to validate paste format", log.FF{{Key: "error", Value: fe.Error()}})
+
+			response.UnprocessableEntity(w, r, formatErrors(fe))
+		case errors.Is(err, usecase.ErrPasteNotFound):
+			h.l.Warn("unable to update paste by hash", log.FF{{Key: "Hash", Value: hash}})
+
+			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrNotPasteAuthor):
+			h.l.Warn("unable to update paste by hash", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Forbidden(w, r)
+		case errors.Is(err, usecase.ErrInvalidExpiration):
+			h.l.Info("failed to validate input data", log.FF{{Key: "input", Value: input}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"Expires": err.Error()})
+		case errors.Is(err, usecase.ErrInvalidTags):
+			h.l.Info("failed to validate input data", log.FF{{Key: "input", Value: input}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"Tags": err.Error()})
+		case errors.Is(err, usecase.ErrFileNotFound):
+			h.l.Info("failed to validate input data", log.FF{{Key: "input", Value: input}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"Files": err.Error()})
+		default:
+			h.l.Error("unable to update paste by hash", err, log.FF{{Key: "Hash", Value: hash}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"paste": converter.ModelToResponse(e),
+		},
+	})
+}
+
+// HandleDeletePaste godoc
+//
+//	@summary	Удаление пасты по хешу
+//	@tags		pastes
+//	@accept		json
+//	@produce	json
+//	@param		hash	path		string	true	"Хеш пасты"
+//	@success	200		{object}	any{message=string}
+//	@failure	403		{object}	any{error=string}
+//	@failure	404		{object}	any{error=string}
+//	@failure	500		{object}	any{error=string}
+//	@security	Bearer
+//	@router		/pastes/{hash} [delete]
+func (h *handler) HandleDeletePaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	err := h.uc.Delete(ctx, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrPasteNotFound):
+			h.l.Warn("unable to delete paste by hash", log.FF{{Key: "Hash", Value: hash}})
+
+			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrNotPasteAuthor):
+			h.l.Warn("unable to delete paste by hash", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Forbidden(w, r)
+		default:
+			h.l.Error("unable to delete paste by hash", err, log.FF{{Key: "Hash", Value: hash}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+	})
+}
+
+// HandleUnlockPaste godoc
+//
+//	@summary	Получение доступа к пасте с паролем.
+//	@tags		pastes
+//	@accept		json
+//	@produce	json
+//	@param		hash		path		string					true	"Хеш пасты"
+//	@param		credentials	body		entity.UnlockPasteBody	true	"Пароль"
+//	@success	200			{object}	any{message=string,data=any{paste=entity.PasteResponse}}
+//	@failure	403			{object}	any{error=string}
+//	@failure	404			{object}	any{error=string}
+//	@failure	410			{object}	any{error=string}
+//	@failure	500			{object}	any{error=string}
+//	@router		/pastes/{hash}/unlock [post]
+func (h *handler) HandleUnlockPaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	var input entity.UnlockPasteBody
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		h.l.Error("failed to parse input data", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	paste, err := h.uc.Unlock(ctx, hash, input.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrPasteNotFound):
+			h.l.Warn("unable to get paste by hash", log.FF{{Key: "Hash", Value: hash}})
+
+			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrPasteLocked):
+			h.l.Warn("failed to unlock paste: invalid password", log.FF{{Key: "hash", Value: hash}})
+
+			response.Forbidden(w, r)
+		case errors.Is(err, usecase.ErrPasteGone):
+			h.l.Warn("the paste views limit is reached", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
+		case errors.Is(err, usecase.ErrPasteExpired):
+			h.l.Warn("the paste is expired", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
+		default:
+			h.l.Error("failed to get paste by hash", err, log.FF{{Key: "Hash", Value: hash}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"paste": converter.ModelToResponse(paste),
+		},
+	})
+}
+
+// HandleGetPasteRevisions godoc
+//
+//	@summary		Получение списка ревизий пасты.
+//	@description	Список заканчивается текущей ревизией. Ревизии возвращаются без текста.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{object}	any{message=string,data=any{revisions=[]entity.RevisionResponse}}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/revisions [get]
+func (h *handler) HandleGetPasteRevisions(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	revs, err := h.uc.GetRevisions(ctx, hash, r.Header.Get(passwordHeader))
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrPasteNotFound):
+			h.l.Warn("unable to get paste revisions", log.FF{{Key: "Hash", Value: hash}})
+
+			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrPasteLocked):
+			h.l.Warn("the paste lock for public review", log.FF{{Key: "hash", Value: hash}})
+
+			response.Forbidden(w, r)
+		case errors.Is(err, usecase.ErrPasteExpired):
+			h.l.Warn("the paste is expired", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
+		default:
+			h.l.Error("failed to get paste revisions", err, log.FF{{Key: "Hash", Value: hash}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"revisions": converter.RevisionsToResponse(revs),
+		},
+	})
+}
+
+// HandleGetPasteRevision godoc
+//
+//	@summary	Получение ревизии пасты.
+//	@tags		pastes
+//	@produce	json
+//	@param		hash				path		string	true	"Хеш пасты"
+//	@param		revision			path		int		true	"Номер ревизии"
+//	@param		X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success	200					{object}	any{message=string,data=any{revision=entity.RevisionResponse}}
+//	@failure	400					{object}	any{error=string}
+//	@failure	403					{object}	any{error=string}
+//	@failure	404					{object}	any{error=string}
+//	@failure	410					{object}	any{error=string}
+//	@failure	500					{object}	any{error=string}
+//	@router		/pastes/{hash}/revisions/{revision} [get]
+func (h *handler) HandleGetPasteRevision(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil || revision < 1 {
+		h.l.Warn("invalid revision number", log.FF{{Key: "revision", Value: chi.URLParam(r, "revision")}})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	rev, err := h.uc.GetRevision(ctx, hash, r.Header.Get(passwordHeader), revision)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrPasteNotFound), errors.Is(err, usecase.ErrRevisionNotFound):
+			h.l.Warn("unable to get paste revision", log.FF{
+				{Key: "Hash", Value: hash},
+				{Key: "revision", Value: revision},
+			})
+
+			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrPasteLocked):
+			h.l.Warn("the paste lock for public review", log.FF{{Key: "hash", Value: hash}})
+
+			response.Forbidden(w, r)
+		case errors.Is(err, usecase.ErrPasteExpired):
+			h.l.Warn("the paste is expired", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
+		default:
+			h.l.Error("failed to get paste revision", err, log.FF{
+				{Key: "Hash", Value: hash},
+				{Key: "revision", Value: revision},
+			})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"revision": converter.RevisionToResponse(rev),
+		},
+	})
+}
+
+// HandleDiffPasteRevisions godoc
+//
+//	@summary		Разница между ревизиями пасты.
+//	@description	По умолчанию сравнивается текущая ревизия с предыдущей.
+//	@description	Если паста защищена паролем, то его нужно передать в заголовке `X-Paste-Password`.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash				path		string	true	"Хеш пасты"
+//	@param			from				query		int		false	"Исходная ревизия"
+//	@param			to					query		int		false	"Новая ревизия"
+//	@param			X-Paste-Password	header		string	false	"Пароль пасты"
+//	@success		200					{object}	any{message=string,data=any{diff=entity.DiffResponse}}
+//	@failure		400					{object}	any{error=string}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/{hash}/diff [get]
+func (h *handler) HandleDiffPasteRevisions(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	from, err := queryRevision(r, "from")
+	if err != nil {
+		h.l.Warn("invalid revision number", log.FF{{Key: "from", Value: r.URL.Query().Get("from")}})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	to, err := queryRevision(r, "to")
+	if err != nil {
+		h.l.Warn("invalid revision number", log.FF{{Key: "to", Value: r.URL.Query().Get("to")}})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	diff, err := h.uc.DiffRevisions(ctx, hash, r.Header.Get(passwordHeader), from, to)
+	if err != nil {
+		h.handleDiffError(w, r, err, log.FF{{Key: "Hash", Value: hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"diff": converter.DiffToResponse(diff),
+		},
+	})
+}
+
+// HandleDiffPastes godoc
+//
+//	@summary		Разница между двумя пастами.
+//	@description	Если пасты защищены паролем, то их нужно передать в заголовках `X-Paste-Password-A` и `X-Paste-Password-B`.
+//	@tags			pastes
+//	@produce		json
+//	@param			a					query		string	true	"Хеш исходной пасты"
+//	@param			b					query		string	true	"Хеш новой пасты"
+//	@param			X-Paste-Password-A	header		string	false	"Пароль исходной пасты"
+//	@param			X-Paste-Password-B	header		string	false	"Пароль новой пасты"
+//	@success		200					{object}	any{message=string,data=any{diff=entity.DiffResponse}}
+//	@failure		400					{object}	any{error=string}
+//	@failure		403					{object}	any{error=string}
+//	@failure		404					{object}	any{error=string}
+//	@failure		410					{object}	any{error=string}
+//	@failure		500					{object}	any{error=string}
+//	@router			/pastes/diff [get]
+func (h *handler) HandleDiffPastes(w http.ResponseWriter, r *http.Request) {
+	var (
+		query = r.URL.Query()
+		from  = entity.DiffSource{Hash: query.Get("a"), Password: r.Header.Get(passwordHeader + "-A")}
+		to    = entity.DiffSource{Hash: query.Get("b"), Password: r.Header.Get(passwordHeader + "-B")}
+	)
+
+	if from.Hash == "" || to.Hash == "" {
+		h.l.Warn("missing pastes to diff", log.FF{{Key: "query", Value: query}})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	diff, err := h.uc.Diff(ctx, from, to)
+	if err != nil {
+		h.handleDiffError(w, r, err, log.FF{{Key: "a", Value: from.Hash}, {Key: "b", Value: to.Hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"diff": converter.DiffToResponse(diff),
+		},
+	})
+}
+
+// HandleListUserPastes godoc
+//
+//	@summary		Пасты текущего пользователя
+//	@description	Возвращает метаданные паст текущего пользователя без текстов.
+//	@description	По умолчанию пасты упорядочены по дате создания от новых к старым, по дате сгорания
+//	@description	и названию от меньших к большим, бессрочные пасты идут после сгорающих.
+//	@description	Следующая страница запрашивается с курсором `next` из ответа и той же сортировкой.
+//	@tags			pastes
+//	@produce		json
+//	@param			format	query		string		false	"Формат пасты"
+//	@param			status	query		string		false	"Статус пасты"	Enums(active, expired)
+//	@param			locked	query		bool		false	"Паста защищена паролем"
+//	@param			tag		query		[]string	false	"Теги пасты"								collectionFormat(multi)
+//	@param			match	query		string		false	"Пасты со всеми тегами или с любым из них"	Enums(all, any)					default(all)
+//	@param			sort	query		string		false	"Поле сортировки"							Enums(created, expires, title)	default(created)
+//	@param			order	query		string		false	"Порядок сортировки"						Enums(asc, desc)
+//	@param			limit	query		int			false	"Число паст на странице, от 1 до 100"		default(20)
+//	@param			cursor	query		string		false	"Курсор следующей страницы"
+//	@success		200		{object}	any{message=string,data=any{pastes=entity.UserPastesPageResponse}}
+//	@failure		401		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/users/me/pastes [get]
+func (h *handler) HandleListUserPastes(w http.ResponseWriter, r *http.Request) {
+	q, errs := pasteListQuery(r)
+	if len(errs) > 0 {
+		h.l.Info("failed to validate input data", log.FF{{Key: "query", Value: r.URL.Query()}})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	page, err := h.uc.ListUserPastes(ctx, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrUnauthorized):
+			h.l.Warn("unable to list pastes of anonymous user", nil)
+
+			response.Unauthorized(w, r)
+		case errors.Is(err, usecase.ErrInvalidTags):
+			h.l.Info("failed to validate input data", log.FF{{Key: "query", Value: r.URL.Query()}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"tag": err.Error()})
+		default:
+			h.l.Error("failed to list user pastes", err, nil)
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"pastes": converter.UserPastesPageToResponse(page),
+		},
+	})
+}
+
+// HandleForkPaste godoc
+//
+//	@summary		Создание форка пасты
+//	@description	Копирует текст и метаданные пасты в новую пасту текущего пользователя.
+//	@description	Форк защищённой пасты защищён тем же паролем.
+//	@tags			pastes
+//	@accept			json
+//	@produce		json
+//	@param			hash	path		string					true	"Хеш исходной пасты"
+//	@param			fork	body		entity.ForkPasteBody	false	"Параметры форка"
+//	@success		200		{object}	any{message=string,data=any{paste=entity.PasteResponse,location=string}}
+//	@failure		400		{object}	any{error=string}
+//	@failure		401		{object}	any{error=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		410		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/fork [post]
+func (h *handler) HandleForkPaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.ForkPasteBody)
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil && !errors.Is(err, io.EOF) {
+		h.l.Error("failed to parse input data", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	e := converter.ForkPasteToEntity(hash, input)
+
+	err = h.uc.Fork(ctx, e, input.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrUnauthorized):
+			h.l.Warn("unable to fork paste", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Unauthorized(w, r)
+		case errors.Is(err, usecase.ErrPasteNotFound):
+			h.l.Warn("unable to fork paste", log.FF{{Key: "Hash", Value: hash}})
+
+			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrPasteLocked):
+			h.l.Warn("failed to unlock paste: invalid password", log.FF{{Key: "hash", Value: hash}})
+
+			response.Forbidden(w, r)
+		case errors.Is(err, usecase.ErrPasteExpired):
+			h.l.Warn("the paste is expired", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
+		default:
+			h.l.Error("failed to fork paste", err, log.FF{{Key: "Hash", Value: hash}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	location := fmt.Sprintf("%s/%s", strings.TrimSuffix(r.URL.Path, "/"+hash+"/fork"), e.Hash)
+
+	w.Header().Add("Location", location)
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"location": location,
+			"paste":    converter.ModelToResponse(e),
+		},
+	})
+}
+
+// HandleGetPasteForks godoc
+//
+//	@summary	Получение списка форков пасты
+//	@tags		pastes
+//	@produce	json
+//	@param		hash	path		string	true	"Хеш пасты"
+//	@success	200		{object}	any{message=string,data=any{forks=[]entity.PasteMetaResponse}}
+//	@failure	404		{object}	any{error=string}
+//	@failure	500		{object}	any{error=string}
+//	@router		/pastes/{hash}/forks [get]
+func (h *handler) HandleGetPasteForks(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	forks, err := h.uc.GetForks(ctx, hash)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrPasteNotFound):
+			h.l.Warn("unable to get paste forks", log.FF{{Key: "Hash", Value: hash}})
+
+			response.NotFound(w, r)
+		default:
+			h.l.Error("failed to get paste forks", err, log.FF{{Key: "Hash", Value: hash}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"forks": converter.ModelsToMetaResponse(forks),
+		},
+	})
+}
+
+// HandleExtendPaste godoc
+//
+//	@summary		Продление пасты
+//	@description	Устанавливает новую дату сгорания пасты, продлить можно только ещё не сгоревшую пасту.
+//	@tags			pastes
+//	@accept			json
+//	@produce		json
+//	@param			hash		path		string					true	"Хеш пасты"
+//	@param			expiration	body		entity.ExtendPasteBody	false	"Новое время жизни"
+//	@success		200			{object}	any{message=string,data=any{paste=entity.PasteMetaResponse}}
+//	@failure		400			{object}	any{error=string}
+//	@failure		403			{object}	any{error=string}
+//	@failure		404			{object}	any{error=string}
+//	@failure		410			{object}	any{error=string}
+//	@failure		422			{object}	any{error=any{field=string}}
+//	@failure		500			{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/extend [post]
+func (h *handler) HandleExtendPaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.ExtendPasteBody)
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil && !errors.Is(err, io.EOF) {
+		h.l.Error("failed to parse input data", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	e, err := converter.ExpirationToEntity(input.Expires, input.ExpiresAt)
+	if err != nil {
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: err},
+		})
+
+		response.UnprocessableEntity(w, r, map[string]string{"Expires": err.Error()})
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	paste, err := h.uc.Extend(ctx, hash, e)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrPasteNotFound):
+			h.l.Warn("unable to extend paste", log.FF{{Key: "Hash", Value: hash}})
+
+			response.NotFound(w, r)
+		case errors.Is(err, usecase.ErrNotPasteAuthor):
+			h.l.Warn("unable to extend paste", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Forbidden(w, r)
+		case errors.Is(err, usecase.ErrPasteExpired):
+			h.l.Warn("the paste is expired", log.FF{{Key: "Hash", Value: hash}})
+
+			response.Gone(w, r)
+		case errors.Is(err, usecase.ErrInvalidExpiration):
+			h.l.Info("failed to validate input data", log.FF{{Key: "input", Value: input}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"Expires": err.Error()})
+		default:
+			h.l.Error("failed to extend paste", err, log.FF{{Key: "Hash", Value: hash}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"paste": converter.ModelToMetaResponse(paste),
+		},
+	})
+}
+
+// HandleGetPasteGrants godoc
+//
+//	@summary		Пользователи с доступом к пасте
+//	@description	Возвращает имена пользователей, которым автор выдал доступ к приватной пасте.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash	path		string	true	"Хеш пасты"
+//	@success		200		{object}	any{message=string,data=any{grants=[]string}}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/grants [get]
+func (h *handler) HandleGetPasteGrants(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	usernames, err := h.uc.GetGrants(ctx, hash)
+	if err != nil {
+		h.handleGrantError(w, r, err, log.FF{{Key: "Hash", Value: hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"grants": usernames,
+		},
+	})
+}
+
+// HandleGrantPasteAccess godoc
+//
+//	@summary		Выдача доступа к пасте
+//	@description	Выдаёт пользователю доступ к приватной пасте. Выдать доступ может только автор пасты.
+//	@tags			pastes
+//	@accept			json
+//	@produce		json
+//	@param			hash	path		string					true	"Хеш пасты"
+//	@param			grant	body		entity.GrantAccessBody	true	"Пользователь"
+//	@success		200		{object}	any{message=string}
+//	@failure		400		{object}	any{error=string}
+//	@failure		403		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/grants [post]
+func (h *handler) HandleGrantPasteAccess(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	input := new(entity.GrantAccessBody)
+
+	if err := render.DecodeJSON(r.Body, &input); err != nil {
+		h.l.Error("failed to parse input data", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.BadRequest(w, r)
+
+		return
+	}
+
+	v, err := validator.New()
+	if err != nil {
+		h.l.Error("failed to create validator", err,
+			log.FF{
+				{Key: "input", Value: input},
+			})
+
+		response.InternalServerError(w, r)
+
+		return
+	}
+
+	if !v.Valid(input) {
+		errs := v.Errors()
+
+		h.l.Info("failed to validate input data", log.FF{
+			{Key: "input", Value: input},
+			{Key: "errors", Value: errs},
+		})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.GrantAccess(ctx, hash, input.Username); err != nil {
+		h.handleGrantError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "username", Value: input.Username}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+// HandleRevokePasteAccess godoc
+//
+//	@summary		Отзыв доступа к пасте
+//	@description	Отзывает доступ пользователя к приватной пасте. Отозвать доступ может только автор пасты.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash		path		string	true	"Хеш пасты"
+//	@param			username	path		string	true	"Имя пользователя"
+//	@success		200			{object}	any{message=string}
+//	@failure		403			{object}	any{error=string}
+//	@failure		404			{object}	any{error=string}
+//	@failure		500			{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/grants/{username} [delete]
+func (h *handler) HandleRevokePasteAccess(w http.ResponseWriter, r *http.Request) {
+	var (
+		hash     = chi.URLParam(r, "hash")
+		username = chi.URLParam(r, "username")
+	)
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	if err := h.uc.RevokeAccess(ctx, hash, username); err != nil {
+		h.handleGrantError(w, r, err, log.FF{{Key: "Hash", Value: hash}, {Key: "username", Value: username}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{"message": "ok"})
+}
+
+func (h *handler) handleGrantError(w http.ResponseWriter, r *http.Request, err error, fields log.FF) {
+	switch {
+	case errors.Is(err, context.Canceled):
+	case errors.Is(err, usecase.ErrPasteNotFound), errors.Is(err, usecase.ErrUserNotFound):
+		h.l.Warn("unable to manage paste access", fields)
+
+		response.NotFound(w, r)
+	case errors.Is(err, usecase.ErrNotPasteAuthor):
+		h.l.Warn("unable to manage paste access", fields)
+
+		response.Forbidden(w, r)
+	default:
+		h.l.Error("failed to manage paste access", err, fields)
+
+		response.InternalServerError(w, r)
+	}
+}
+
+func (h *handler) handleDiffError(w http.ResponseWriter, r *http.Request, err error, fields log.FF) {
+	switch {
+	case errors.Is(err, context.Canceled):
+	case errors.Is(err, usecase.ErrPasteNotFound), errors.Is(err, usecase.ErrRevisionNotFound):
+		h.l.Warn("unable to diff pastes", fields)
+
+		response.NotFound(w, r)
+	case errors.Is(err, usecase.ErrPasteLocked):
+		h.l.Warn("the paste lock for public review", fields)
+
+		response.Forbidden(w, r)
+	case errors.Is(err, usecase.ErrPasteExpired):
+		h.l.Warn("the paste is expired", fields)
+
+		response.Gone(w, r)
+	default:
+		h.l.Error("failed to diff pastes", err, fields)
+
+		response.InternalServerError(w, r)
+	}
+}
+
+// queryRevision returns a revision number from the query parameter.
+// Missing parameter means zero revision.
+func queryRevision(r *http.Request, key string) (int, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+
+	revision, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, err
+	}
+
+	if revision < 1 {
+		return 0, fmt.Errorf("invalid revision %d", revision)
+	}
+
+	return revision, nil
+}
+
+// maxLineRanges is a max number of line ranges of a query parameter.
+const maxLineRanges = 20
+
+// queryLines returns line ranges from the query parameter like 1,5-7.
+// Missing parameter means no ranges.
+func queryLines(r *http.Request, key string) ([][2]int, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, ",")
+	if len(parts) > maxLineRanges {
+		return nil, fmt.Errorf("more than %d lines ranges", maxLineRanges)
+	}
+
+	lines := make([][2]int, 0, len(parts))
+
+	for _, part := range parts {
+		from, to, found := strings.Cut(part, "-")
+
+		start, err := strconv.Atoi(from)
+		if err != nil {
+			return nil, err
+		}
+
+		end := start
+		if found {
+			if end, err = strconv.Atoi(to); err != nil {
+				return nil, err
+			}
+		}
+
+		if start < 1 || end < start {
+			return nil, fmt.Errorf("invalid lines range %q", part)
+		}
+
+		lines = append(lines, [2]int{start, end})
+	}
+
+	return lines, nil
+}
+
+const (
+	// listLimit is a default number of listed pastes on a page.
+	listLimit = 20
+	// maxListLimit is a max number of listed pastes on a page.
+	maxListLimit = 100
+)
+
+// pasteListQuery returns a paste list query from the query parameters
+// and errors of invalid parameters keyed by the parameter.
+func pasteListQuery(r *http.Request) (entity.PasteListQuery, map[string]string) {
+	var (
+		query = r.URL.Query()
+		errs  = make(map[string]string)
+		q     = entity.PasteListQuery{
+			Format: query.Get("format"),
+			Sort:   entity.SortCreated,
+			Limit:  listLimit,
+		}
+		err error
+	)
+
+	switch status := query.Get("status"); status {
+	case "":
+	case "active", "expired":
+		expired := status == "expired"
+		q.Expired = &expired
+	default:
+		errs["status"] = "must be active or expired"
+	}
+
+	if raw := query.Get("locked"); raw != "" {
+		locked, err := strconv.ParseBool(raw)
+		if err != nil {
+			errs["locked"] = "must be a boolean"
+		}
+
+		q.Locked = &locked
+	}
+
+	q.Tags = query["tag"]
+
+	if q.AnyTag, err = converter.TagMatchToEntity(query.Get("match")); err != nil {
+		errs["match"] = err.Error()
+	}
+
+	switch sort := entity.PasteSort(query.Get("sort")); sort {
+	case "":
+	case entity.SortCreated, entity.SortExpires, entity.SortTitle:
+		q.Sort = sort
+	default:
+		errs["sort"] = "must be created, expires or title"
+	}
+
+	switch order := query.Get("order"); order {
+	case "":
+		q.Desc = q.Sort == entity.SortCreated
+	case "asc", "desc":
+		q.Desc = order == "desc"
+	default:
+		errs["order"] = "must be asc or desc"
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		if q.Limit, err = strconv.Atoi(raw); err != nil || q.Limit < 1 || q.Limit > maxListLimit {
+			errs["limit"] = fmt.Sprintf("must be from 1 to %d", maxListLimit)
+		}
+	}
+
+	if q.After, err = converter.PasteListCursorToEntity(query.Get("cursor"), q.Sort); err != nil {
+		errs["cursor"] = err.Error()
+	}
+
+	return q, errs
+}
+
+// ansiType is a media type of the Accept header requesting text highlighted for terminals.
+const ansiType = "text/x-ansi"
+
+// terminalOutput returns a terminal output for the request. Texts are highlighted only
+// on request with the ansi query parameter or the text/x-ansi Accept header, so raw texts
+// stay byte-exact for terminal clients by default.
+func terminalOutput(r *http.Request) (entity.HighlightOutput, bool) {
+	ansi, err := strconv.ParseBool(r.URL.Query().Get("ansi"))
+	if err != nil {
+		for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+			mediaType, _, _ := mime.ParseMediaType(accept)
+			if mediaType == ansiType {
+				ansi = true
+
+				break
+			}
+		}
+	}
+
+	if !ansi {
+		return "", false
+	}
+
+	if r.URL.Query().Get("colors") == "truecolor" {
+		return entity.HighlightTrueColor, true
+	}
+
+	return entity.HighlightANSI256, true
+}
+
+// queryStrict reports whether texts must match their formats, it is true unless strict=false.
+func queryStrict(r *http.Request) bool {
+	strict, err := strconv.ParseBool(r.URL.Query().Get("strict"))
+
+	return err != nil || strict
+}
+
+// formatErrors returns the format error with its position keyed by the invalid field.
+func formatErrors(fe *entity.FormatError) map[string]string {
+	field := "Text"
+	if fe.File != "" {
+		field = fmt.Sprintf("Files[%s].Text", fe.File)
+	}
+
+	errs := map[string]string{field: fe.Error()}
+
+	if fe.Line > 0 {
+		errs[field+".Line"] = strconv.Itoa(fe.Line)
+	}
+
+	if fe.Column > 0 {
+		errs[field+".Column"] = strconv.Itoa(fe.Column)
+	}
+
+	return errs
+}
+
+// schemaErrors returns the schema violations keyed by the JSON pointers of the paste text.
+func schemaErrors(se *entity.SchemaError) map[string]string {
+	errs := make(map[string]string, len(se.Violations))
+
+	for _, v := range se.Violations {
+		field := "Text" + v.Pointer
+		if msg, ok := errs[field]; ok {
+			errs[field] = msg + "; " + v.Msg
+		} else {
+			errs[field] = v.Msg
+		}
+	}
+
+	return errs
+}
+
+// attachment makes the response downloadable as a file with the name.
+func attachment(w http.ResponseWriter, name string) {
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+}
+
+// contentTypes maps paste formats to media types.
+var contentTypes = map[string]string{
+	"json":      "application/json",
+	"yaml":      "application/yaml",
+	"toml":      "application/toml",
+	"xml":       "application/xml",
+	"plaintext": "text/plain",
+}
+
+// extensions maps paste formats to file extensions.
+var extensions = map[string]string{
+	"json":       ".json",
+	"yaml":       ".yaml",
+	"toml":       ".toml",
+	"xml":        ".xml",
+	"plaintext":  ".txt",
+	"c":          ".c",
+	"dockerfile": ".dockerfile",
+	"go":         ".go",
+	"html":       ".html",
+	"java":       ".java",
+	"javascript": ".js",
+	"markdown":   ".md",
+	"php":        ".php",
+	"python":     ".py",
+	"rust":       ".rs",
+	"shell":      ".sh",
+	"sql":        ".sql",
+	"typescript": ".ts",
+}
+
+// contentType returns a media type with charset for the paste format.
+// Unknown formats are served as plain text.
+func contentType(format string) string {
+	t, ok := contentTypes[format]
+	if !ok {
+		t = contentTypes["plaintext"]
+	}
+
+	return t + "; charset=utf-8"
+}
+
+// filename returns a file name for the paste built from its title
+// with an extension of the paste format.
+func filename(title, hash, format string) string {
+	name := basename(title, hash)
+
+	ext, ok := extensions[format]
+	if !ok {
+		ext = extensions["plaintext"]
+	}
+
+	if strings.HasSuffix(strings.ToLower(name), ext) {
+		return name
+	}
+
+	return name + ext
+}
+
+// basename returns a file name without extension built from the paste title.
+// Characters unsafe for file names are replaced with underscores,
+// the hash is used for pastes without title.
+func basename(title, hash string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, strings.TrimSpace(title))
+
+	name = strings.Trim(name, "._")
+	if name == "" {
+		return hash
+	}
+
+	return name
+}
@@ -0,0 +1,94 @@
+package paste
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/romankravchuk/pastebin/pkg/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRouter(t *testing.T) (chi.Router, *mocks.Pastes) {
+	t.Helper()
+
+	var (
+		mux = chi.NewRouter()
+		uc  = mocks.NewPastes(t)
+	)
+
+	MountRoutes(mux, uc, log.New(io.Discard, log.Stol("error")))
+
+	return mux, uc
+}
+
+func TestHandler_HandleGetRawPaste(t *testing.T) {
+	t.Parallel()
+
+	text := entity.File(`{"a": "\u001b[31m"}` + "\n")
+
+	t.Run("Get raw text for terminal clients", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mux, uc = newRouter(t)
+			w       = httptest.NewRecorder()
+			r       = httptest.NewRequest(http.MethodGet, "/pastes/hash/raw", nil)
+		)
+
+		r.Header.Set("User-Agent", "curl/8.4.0")
+
+		uc.On("Get", mock.Anything, "hash").
+			Once().
+			Return(&entity.Paste{Hash: "hash", Format: "json", File: text}, nil)
+
+		mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, []byte(text), w.Body.Bytes())
+	})
+
+	t.Run("Get highlighted text with ansi parameter", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mux, uc = newRouter(t)
+			w       = httptest.NewRecorder()
+			r       = httptest.NewRequest(http.MethodGet, "/pastes/hash/raw?ansi=1", nil)
+		)
+
+		uc.On("Highlight", mock.Anything, "hash", "", entity.HighlightOptions{Output: entity.HighlightANSI256}).
+			Once().
+			Return([]byte("\x1b[38;5;1m{}\x1b[0m"), nil)
+
+		mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "\x1b[38;5;1m{}\x1b[0m", w.Body.String())
+	})
+
+	t.Run("Get highlighted text with ansi media type", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mux, uc = newRouter(t)
+			w       = httptest.NewRecorder()
+			r       = httptest.NewRequest(http.MethodGet, "/pastes/hash/raw?colors=truecolor", nil)
+		)
+
+		r.Header.Set("Accept", "text/x-ansi, */*;q=0.1")
+
+		uc.On("Highlight", mock.Anything, "hash", "", entity.HighlightOptions{Output: entity.HighlightTrueColor}).
+			Once().
+			Return([]byte("{}"), nil)
+
+		mux.ServeHTTP(w, r)
+
+		require.Equal(t, http.StatusOK, w.Code)
+	})
+}
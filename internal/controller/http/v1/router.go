@@ -9,20 +9,20 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/go-chi/render"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"github.com/romankravchuk/pastebin/config"
+	authmw "github.com/romankravchuk/pastebin/internal/controller/http/middleware/auth"
 	"github.com/romankravchuk/pastebin/internal/controller/http/middleware/logger"
 	"github.com/romankravchuk/pastebin/internal/controller/http/response"
 	"github.com/romankravchuk/pastebin/internal/controller/http/v1/auth"
+	"github.com/romankravchuk/pastebin/internal/controller/http/v1/collection"
+	"github.com/romankravchuk/pastebin/internal/controller/http/v1/comment"
+	"github.com/romankravchuk/pastebin/internal/controller/http/v1/feed"
+	"github.com/romankravchuk/pastebin/internal/controller/http/v1/format"
 	"github.com/romankravchuk/pastebin/internal/controller/http/v1/paste"
+	"github.com/romankravchuk/pastebin/internal/controller/http/v1/search"
+	"github.com/romankravchuk/pastebin/internal/controller/http/v1/star"
+	"github.com/romankravchuk/pastebin/internal/controller/http/v1/tag"
 	"github.com/romankravchuk/pastebin/internal/usecase"
-	"github.com/romankravchuk/pastebin/internal/usecase/blob"
-	"github.com/romankravchuk/pastebin/internal/usecase/cache"
-	"github.com/romankravchuk/pastebin/internal/usecase/repo"
-	"github.com/romankravchuk/pastebin/internal/usecase/webapi"
 	"github.com/romankravchuk/pastebin/pkg/log"
-	"github.com/romankravchuk/pastebin/pkg/minio"
-	"github.com/romankravchuk/pastebin/pkg/postgres"
-	"github.com/romankravchuk/pastebin/pkg/redis"
 	swagger "github.com/swaggo/http-swagger/v2"
 
 	_ "github.com/romankravchuk/pastebin/docs" //
@@ -40,43 +40,32 @@ import (
 //	@securitydefinitions.apiKey	Bearer
 //	@in							header
 //	@name						Authorization
-func NewRouter(mux chi.Router, cfg *config.Config, l *log.Logger) error {
-	postgreClient, err := postgres.New(cfg.Postgres.DSN)
-	if err != nil {
-		return err
-	}
-
-	minioClient, err := minio.New(cfg.Minio.DSN, cfg.Minio.AccessKey, cfg.Minio.SecretKey)
-	if err != nil {
-		return err
-	}
-
-	redisClient, err := redis.New(cfg.Redis.DSN)
-	if err != nil {
-		return err
-	}
-
-	var (
-		pastesCache   = cache.NewPastesCache(redisClient)
-		pastesBlob    = blob.NewPastesBlobStorage(minioClient)
-		pastesRepo    = repo.NewPastesRepositry(postgreClient)
-		usersRepo     = repo.NewUsersRepositry(postgreClient)
-		oauthapi      = webapi.NewGithubAPI(cfg.OAuth.ClientID, cfg.OAuth.ClientSecret)
-		authUsecase   = usecase.NewAuth(usersRepo, oauthapi)
-		pastesUsecase = usecase.NewPastes(pastesRepo, pastesBlob, pastesCache)
-	)
-
+func NewRouter(
+	mux chi.Router,
+	l *log.Logger,
+	baseURL string,
+	pastesUsecase usecase.Pastes,
+	authUsecase usecase.Auth,
+	formatsUsecase usecase.Formats,
+	collectionsUsecase usecase.Collections,
+	searchUsecase usecase.Search,
+	tagsUsecase usecase.Tags,
+	feedsUsecase usecase.Feeds,
+	starsUsecase usecase.Stars,
+	commentsUsecase usecase.Comments,
+) {
 	mux.Use(middleware.RedirectSlashes)
 	mux.Use(middleware.RealIP)
 	mux.Use(logger.New(l))
 	mux.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://*", "https://*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "X-Paste-Password", "X-Paste-Password-A", "X-Paste-Password-B"},
 		AllowCredentials: true,
-		ExposedHeaders:   []string{"Link", "Location"},
+		ExposedHeaders:   []string{"Content-Disposition", "Link", "Location"},
 		MaxAge:           300,
 	}))
+	mux.Use(authmw.New(authUsecase, l))
 
 	mux.Get("/swagger/*", swagger.Handler())
 
@@ -88,5 +77,17 @@ func NewRouter(mux chi.Router, cfg *config.Config, l *log.Logger) error {
 
 	paste.MountRoutes(mux, pastesUsecase, l)
 
-	return nil
+	search.MountRoutes(mux, searchUsecase, l)
+
+	tag.MountRoutes(mux, tagsUsecase, l)
+
+	feed.MountRoutes(mux, feedsUsecase, l, baseURL)
+
+	star.MountRoutes(mux, starsUsecase, l)
+
+	comment.MountRoutes(mux, commentsUsecase, l)
+
+	format.MountRoutes(mux, formatsUsecase, l)
+
+	collection.MountRoutes(mux, collectionsUsecase, l)
 }
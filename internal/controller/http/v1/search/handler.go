@@ -0,0 +1,163 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/converter"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+)
+
+type handler struct {
+	l  *log.Logger
+	uc usecase.Search
+	tm time.Duration
+}
+
+func MountRoutes(mux chi.Router, uc usecase.Search, l *log.Logger) {
+	h := &handler{
+		l:  l,
+		uc: uc,
+		tm: 10 * time.Second,
+	}
+
+	mux.Get("/pastes/search", h.HandleSearchPastes)
+}
+
+// HandleSearchPastes godoc
+//
+//	@summary		Поиск паст
+//	@description	Полнотекстовый поиск по заголовкам и текстам паст, включая все файлы пасты.
+//	@description	Запрос `q` поддерживает синтаксис веб-поиска: фразы в кавычках, `or` и исключение слов через `-`.
+//	@description	Заголовки, похожие на запрос, находятся даже с опечатками. Результаты упорядочены по релевантности
+//	@description	и содержат фрагменты текста с позициями совпадений. Ищутся только публичные пасты, а также
+//	@description	скрытые и приватные пасты текущего пользователя. Пасты с паролем, сжигаемые после прочтения
+//	@description	и с ограничением просмотров не ищутся. Следующая страница запрашивается с курсором `next` из ответа.
+//	@tags			pastes
+//	@produce		json
+//	@param			q		query		string	true	"Поисковый запрос"
+//	@param			format	query		string	false	"Формат пасты"
+//	@param			owner	query		string	false	"Имя автора пасты"
+//	@param			from	query		string	false	"Создана не раньше, RFC 3339 или YYYY-MM-DD"
+//	@param			to		query		string	false	"Создана раньше, RFC 3339 или YYYY-MM-DD включительно"
+//	@param			limit	query		int		false	"Число результатов на странице, от 1 до 100"	default(20)
+//	@param			cursor	query		string	false	"Курсор следующей страницы"
+//	@success		200		{object}	any{message=string,data=any{search=entity.SearchPageResponse}}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@router			/pastes/search [get]
+func (h *handler) HandleSearchPastes(w http.ResponseWriter, r *http.Request) {
+	q, errs := searchQuery(r)
+	if len(errs) > 0 {
+		h.l.Info("failed to validate input data", log.FF{{Key: "query", Value: r.URL.Query()}})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	page, err := h.uc.Search(ctx, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		default:
+			h.l.Error("failed to search pastes", err, log.FF{{Key: "q", Value: q.Query}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"search": converter.SearchPageToResponse(page),
+		},
+	})
+}
+
+const (
+	// searchLimit is a default number of search results on a page.
+	searchLimit = 20
+	// maxSearchLimit is a max number of search results on a page.
+	maxSearchLimit = 100
+)
+
+// searchQuery returns a search query from the query parameters
+// and errors of invalid parameters keyed by the parameter.
+func searchQuery(r *http.Request) (entity.SearchQuery, map[string]string) {
+	var (
+		query = r.URL.Query()
+		errs  = make(map[string]string)
+		q     = entity.SearchQuery{
+			Query:  strings.TrimSpace(query.Get("q")),
+			Format: query.Get("format"),
+			Owner:  query.Get("owner"),
+			Limit:  searchLimit,
+		}
+		err error
+	)
+
+	if q.Query == "" {
+		errs["q"] = "required"
+	}
+
+	if q.From, err = queryDate(r, "from", false); err != nil {
+		errs["from"] = "must be RFC 3339 date or YYYY-MM-DD"
+	}
+
+	if q.To, err = queryDate(r, "to", true); err != nil {
+		errs["to"] = "must be RFC 3339 date or YYYY-MM-DD"
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		if q.Limit, err = strconv.Atoi(raw); err != nil || q.Limit < 1 || q.Limit > maxSearchLimit {
+			errs["limit"] = fmt.Sprintf("must be from 1 to %d", maxSearchLimit)
+		}
+	}
+
+	if q.After, err = converter.CursorToEntity(query.Get("cursor")); err != nil {
+		errs["cursor"] = err.Error()
+	}
+
+	return q, errs
+}
+
+// queryDate returns a date from the query parameter as RFC 3339 date or YYYY-MM-DD.
+// A day is the start of the day, or the start of the next day if end is true,
+// so end days are included in exclusive ranges. Missing parameter means zero time.
+func queryDate(r *http.Request, key string, end bool) (time.Time, error) {
+	raw := r.URL.Query().Get(key)
+	if raw == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if end {
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
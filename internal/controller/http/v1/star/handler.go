@@ -0,0 +1,206 @@
+package star
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/converter"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+)
+
+const (
+	// listLimit is a default number of listed pastes on a page.
+	listLimit = 20
+	// maxListLimit is a max number of listed pastes on a page.
+	maxListLimit = 100
+)
+
+type handler struct {
+	l  *log.Logger
+	uc usecase.Stars
+	tm time.Duration
+}
+
+func MountRoutes(mux chi.Router, uc usecase.Stars, l *log.Logger) {
+	h := &handler{
+		l:  l,
+		uc: uc,
+		tm: 10 * time.Second,
+	}
+
+	mux.Post("/pastes/{hash}/star", h.HandleStarPaste)
+	mux.Delete("/pastes/{hash}/star", h.HandleUnstarPaste)
+	mux.Get("/users/me/stars", h.HandleListStarredPastes)
+}
+
+// HandleStarPaste godoc
+//
+//	@summary		Добавление пасты в избранное
+//	@description	Ставит звезду пасте от текущего пользователя. Повторная звезда ничего не меняет.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash	path		string	true	"Хеш пасты"
+//	@success		200		{object}	any{message=string,data=any{stars=int}}
+//	@failure		401		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		410		{object}	any{error=string}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/star [post]
+func (h *handler) HandleStarPaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	stars, err := h.uc.Star(ctx, hash)
+	if err != nil {
+		h.handleStarError(w, r, err, log.FF{{Key: "Hash", Value: hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"stars": stars,
+		},
+	})
+}
+
+// HandleUnstarPaste godoc
+//
+//	@summary		Удаление пасты из избранного
+//	@description	Снимает звезду текущего пользователя с пасты. Снятие отсутствующей звезды ничего не меняет.
+//	@tags			pastes
+//	@produce		json
+//	@param			hash	path		string	true	"Хеш пасты"
+//	@success		200		{object}	any{message=string,data=any{stars=int}}
+//	@failure		401		{object}	any{error=string}
+//	@failure		404		{object}	any{error=string}
+//	@failure		410		{object}	any{error=string}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/pastes/{hash}/star [delete]
+func (h *handler) HandleUnstarPaste(w http.ResponseWriter, r *http.Request) {
+	hash := chi.URLParam(r, "hash")
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	stars, err := h.uc.Unstar(ctx, hash)
+	if err != nil {
+		h.handleStarError(w, r, err, log.FF{{Key: "Hash", Value: hash}})
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"stars": stars,
+		},
+	})
+}
+
+func (h *handler) handleStarError(w http.ResponseWriter, r *http.Request, err error, fields log.FF) {
+	switch {
+	case errors.Is(err, context.Canceled):
+	case errors.Is(err, usecase.ErrUnauthorized):
+		h.l.Warn("unable to star paste by anonymous user", fields)
+
+		response.Unauthorized(w, r)
+	case errors.Is(err, usecase.ErrPasteNotFound):
+		h.l.Warn("the paste not found", fields)
+
+		response.NotFound(w, r)
+	case errors.Is(err, usecase.ErrPasteExpired):
+		h.l.Warn("the paste is expired", fields)
+
+		response.Gone(w, r)
+	default:
+		h.l.Error("failed to star paste", err, fields)
+
+		response.InternalServerError(w, r)
+	}
+}
+
+// HandleListStarredPastes godoc
+//
+//	@summary		Избранные пасты текущего пользователя
+//	@description	Возвращает метаданные не сгоревших паст со звездой текущего пользователя,
+//	@description	от недавно добавленных в избранное к давним. Приватные пасты, к которым
+//	@description	у пользователя больше нет доступа, не возвращаются.
+//	@description	Следующая страница запрашивается с курсором `next` из ответа.
+//	@tags			pastes
+//	@produce		json
+//	@param			limit	query		int		false	"Число паст на странице, от 1 до 100"	default(20)
+//	@param			cursor	query		string	false	"Курсор следующей страницы"
+//	@success		200		{object}	any{message=string,data=any{pastes=entity.StarredPastesPageResponse}}
+//	@failure		401		{object}	any{error=string}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@security		Bearer
+//	@router			/users/me/stars [get]
+func (h *handler) HandleListStarredPastes(w http.ResponseWriter, r *http.Request) {
+	var (
+		query = r.URL.Query()
+		errs  = make(map[string]string)
+		q     = entity.StarListQuery{Limit: listLimit}
+		err   error
+	)
+
+	if raw := query.Get("limit"); raw != "" {
+		if q.Limit, err = strconv.Atoi(raw); err != nil || q.Limit < 1 || q.Limit > maxListLimit {
+			errs["limit"] = fmt.Sprintf("must be from 1 to %d", maxListLimit)
+		}
+	}
+
+	if q.After, err = converter.PasteListCursorToEntity(query.Get("cursor"), entity.SortStarred); err != nil {
+		errs["cursor"] = err.Error()
+	}
+
+	if len(errs) > 0 {
+		h.l.Info("failed to validate input data", log.FF{{Key: "query", Value: query}})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	page, err := h.uc.ListStarred(ctx, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrUnauthorized):
+			h.l.Warn("unable to list stars of anonymous user", nil)
+
+			response.Unauthorized(w, r)
+		default:
+			h.l.Error("failed to list starred pastes", err, nil)
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"pastes": converter.StarsPageToResponse(page),
+		},
+	})
+}
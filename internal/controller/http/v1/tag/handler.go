@@ -0,0 +1,155 @@
+package tag
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/romankravchuk/pastebin/internal/controller/http/response"
+	"github.com/romankravchuk/pastebin/internal/converter"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/log"
+)
+
+const (
+	// listLimit is a default number of listed pastes on a page.
+	listLimit = 20
+	// maxListLimit is a max number of listed pastes on a page.
+	maxListLimit = 100
+)
+
+type handler struct {
+	l  *log.Logger
+	uc usecase.Tags
+	tm time.Duration
+}
+
+func MountRoutes(mux chi.Router, uc usecase.Tags, l *log.Logger) {
+	h := &handler{
+		l:  l,
+		uc: uc,
+		tm: 10 * time.Second,
+	}
+
+	mux.Get("/pastes", h.HandleListTaggedPastes)
+	mux.Get("/tags", h.HandleGetTags)
+}
+
+// HandleListTaggedPastes godoc
+//
+//	@summary		Пасты по тегам
+//	@description	Возвращает метаданные не сгоревших паст с тегами от новых к старым.
+//	@description	Анонимным пользователям доступны только публичные пасты, авторизованным также
+//	@description	их собственные пасты и приватные пасты, к которым им выдан доступ.
+//	@description	Без тегов возвращаются все доступные пасты.
+//	@description	Следующая страница запрашивается с курсором `next` из ответа.
+//	@tags			pastes
+//	@produce		json
+//	@param			tag		query		[]string	false	"Теги пасты"								collectionFormat(multi)
+//	@param			match	query		string		false	"Пасты со всеми тегами или с любым из них"	Enums(all, any)	default(all)
+//	@param			limit	query		int			false	"Число паст на странице, от 1 до 100"		default(20)
+//	@param			cursor	query		string		false	"Курсор следующей страницы"
+//	@success		200		{object}	any{message=string,data=any{pastes=entity.TaggedPastesPageResponse}}
+//	@failure		422		{object}	any{error=any{field=string}}
+//	@failure		500		{object}	any{error=string}
+//	@router			/pastes [get]
+func (h *handler) HandleListTaggedPastes(w http.ResponseWriter, r *http.Request) {
+	var (
+		query = r.URL.Query()
+		errs  = make(map[string]string)
+		q     = entity.TagQuery{Limit: listLimit}
+		err   error
+	)
+
+	q.Tags = query["tag"]
+
+	if q.Any, err = converter.TagMatchToEntity(query.Get("match")); err != nil {
+		errs["match"] = err.Error()
+	}
+
+	if raw := query.Get("limit"); raw != "" {
+		if q.Limit, err = strconv.Atoi(raw); err != nil || q.Limit < 1 || q.Limit > maxListLimit {
+			errs["limit"] = fmt.Sprintf("must be from 1 to %d", maxListLimit)
+		}
+	}
+
+	if q.After, err = converter.PasteListCursorToEntity(query.Get("cursor"), entity.SortCreated); err != nil {
+		errs["cursor"] = err.Error()
+	}
+
+	if len(errs) > 0 {
+		h.l.Info("failed to validate input data", log.FF{{Key: "query", Value: query}})
+
+		response.UnprocessableEntity(w, r, errs)
+
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	page, err := h.uc.ListTagged(ctx, q)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		case errors.Is(err, usecase.ErrInvalidTags):
+			h.l.Info("failed to validate input data", log.FF{{Key: "query", Value: query}})
+
+			response.UnprocessableEntity(w, r, map[string]string{"tag": err.Error()})
+		default:
+			h.l.Error("failed to list tagged pastes", err, log.FF{{Key: "query", Value: query}})
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"pastes": converter.TaggedPastesPageToResponse(page),
+		},
+	})
+}
+
+// HandleGetTags godoc
+//
+//	@summary		Популярные теги
+//	@description	Возвращает до 100 самых используемых тегов с числом не сгоревших паст,
+//	@description	доступных пользователю, как в списке паст по тегам.
+//	@tags			pastes
+//	@produce		json
+//	@success		200	{object}	any{message=string,data=any{tags=[]entity.TagCountResponse}}
+//	@failure		500	{object}	any{error=string}
+//	@router			/tags [get]
+func (h *handler) HandleGetTags(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), h.tm)
+	defer cancel()
+
+	counts, err := h.uc.Tags(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, context.Canceled):
+		default:
+			h.l.Error("failed to get tags", err, nil)
+
+			response.InternalServerError(w, r)
+		}
+
+		return
+	}
+
+	response.OK(w, r, render.M{
+		"message": "ok",
+		"data": render.M{
+			"tags": converter.TagCountsToResponse(counts),
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+func CreateCollectionToEntity(body *entity.CreateCollectionBody) *entity.Collection {
+	c := &entity.Collection{
+		Hash:       generateHash(body.Title),
+		Title:      body.Title,
+		Visibility: entity.Visibility(body.Visibility),
+	}
+
+	for _, hash := range body.Pastes {
+		c.Pastes = append(c.Pastes, &entity.Paste{Hash: hash})
+	}
+
+	return c
+}
+
+func UpdateCollectionToEntity(hash string, body *entity.UpdateCollectionBody) *entity.Collection {
+	return &entity.Collection{
+		Hash:       hash,
+		Title:      body.Title,
+		Visibility: entity.Visibility(body.Visibility),
+	}
+}
+
+func CollectionToResponse(model *entity.Collection) *entity.CollectionResponse {
+	return &entity.CollectionResponse{
+		Hash:       model.Hash,
+		Title:      model.Title,
+		Visibility: string(model.Visibility),
+		CreatedAt:  model.CreatedAt.Format(time.RFC1123),
+		UpdatedAt:  model.UpdatedAt.Format(time.RFC1123),
+		Pastes:     ModelsToMetaResponse(model.Pastes),
+	}
+}
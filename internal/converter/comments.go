@@ -0,0 +1,62 @@
+package converter
+
+import (
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+func CreateCommentToEntity(hash string, body *entity.CreateCommentBody) *entity.PasteComment {
+	return &entity.PasteComment{
+		PasteHash: hash,
+		ParentID:  body.ParentID,
+		LineStart: body.LineStart,
+		LineEnd:   body.LineEnd,
+		Text:      body.Text,
+	}
+}
+
+func UpdateCommentToEntity(hash string, id int64, body *entity.UpdateCommentBody) *entity.PasteComment {
+	return &entity.PasteComment{
+		ID:        id,
+		PasteHash: hash,
+		Text:      body.Text,
+	}
+}
+
+func CommentToResponse(model *entity.PasteComment) *entity.CommentResponse {
+	return &entity.CommentResponse{
+		ID:        model.ID,
+		Author:    model.Author,
+		Revision:  model.Revision,
+		LineStart: model.LineStart,
+		LineEnd:   model.LineEnd,
+		Text:      model.Text,
+		Deleted:   model.Deleted,
+		CreatedAt: model.CreatedAt.Format(time.RFC1123),
+		UpdatedAt: model.UpdatedAt.Format(time.RFC1123),
+		Replies:   make([]*entity.CommentResponse, 0),
+	}
+}
+
+// CommentsToResponse builds threads of comments listed in order of creation.
+// Top level comments are returned with replies nested into the comments they reply to.
+func CommentsToResponse(models []*entity.PasteComment) []*entity.CommentResponse {
+	var (
+		threads = make([]*entity.CommentResponse, 0)
+		byID    = make(map[int64]*entity.CommentResponse, len(models))
+	)
+
+	for _, m := range models {
+		c := CommentToResponse(m)
+		byID[m.ID] = c
+
+		if parent, ok := byID[m.ParentID]; ok {
+			parent.Replies = append(parent.Replies, c)
+		} else {
+			threads = append(threads, c)
+		}
+	}
+
+	return threads
+}
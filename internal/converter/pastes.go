@@ -2,8 +2,13 @@ package converter
 
 import (
 	"crypto/sha256"
+	"database/sql"
 	"encoding/base64"
+	"errors"
+	"fmt"
 	"math/rand"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/romankravchuk/pastebin/internal/entity"
@@ -11,6 +16,15 @@ import (
 
 const hashLen = 8
 
+// ErrInvalidExpiration is returned when the expires field is neither a duration nor never.
+var ErrInvalidExpiration = errors.New("expires must be a duration like 30m or never")
+
+// ErrInvalidCursor is returned when a page cursor is not the one returned with a previous page.
+var ErrInvalidCursor = errors.New("the cursor is invalid")
+
+// ErrInvalidTagMatch is returned when the match parameter is neither all nor any.
+var ErrInvalidTagMatch = errors.New("must be all or any")
+
 func generateHash(text string) string {
 	var (
 		b       = make([]byte, hashLen)
@@ -28,33 +42,516 @@ func generateHash(text string) string {
 
 func CreatePasteToEntity(body *entity.CreatePasteBody) (*entity.Paste, error) {
 	p := &entity.Paste{
-		Hash:      generateHash(body.Text),
-		Title:     body.Title,
-		Format:    body.Format,
-		ExpiresAt: time.Now().Add(2 * 365 * 24 * time.Hour),
-		File:      entity.File(body.Text),
+		Hash:          generateHash(body.Text),
+		Title:         body.Title,
+		Format:        body.Format,
+		File:          entity.File(body.Text),
+		BurnAfterRead: body.BurnAfterRead,
+		MaxViews:      body.MaxViews,
+		Visibility:    entity.Visibility(body.Visibility),
+		Tags:          body.Tags,
 	}
 	p.Password.Set(body.Password)
 
-	if body.Expires != "" {
-		d, err := time.ParseDuration(body.Expires)
-		if err != nil {
-			return nil, err
+	if body.Schema != "" {
+		p.Schema.String, p.Schema.Valid = body.Schema, true
+	}
+
+	if len(body.Files) > 0 {
+		p.Hash = generateHash(body.Files[0].Text)
+		p.Files = FilesToEntity(p.Hash, body.Files)
+		p.File, p.Format = p.Files[0].File, p.Files[0].Format
+	}
+
+	var err error
+
+	p.Expiration, err = ExpirationToEntity(body.Expires, body.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// FilesToEntity returns files of the paste in the order of the body.
+func FilesToEntity(hash string, body []entity.PasteFileBody) []*entity.PasteFile {
+	files := make([]*entity.PasteFile, 0, len(body))
+	for i, f := range body {
+		files = append(files, &entity.PasteFile{
+			Hash:     hash,
+			Position: i,
+			Name:     f.Name,
+			Format:   f.Format,
+			File:     entity.File(f.Text),
+		})
+	}
+
+	return files
+}
+
+// UpdatePasteToEntity returns a paste with only the fields that
+// should be changed. Fields omitted from the body stay zero valued.
+func UpdatePasteToEntity(hash string, body *entity.UpdatePasteBody) (*entity.Paste, error) {
+	p := &entity.Paste{
+		Hash:       hash,
+		Title:      body.Title,
+		Format:     body.Format,
+		Visibility: entity.Visibility(body.Visibility),
+	}
+
+	// Nil password leaves the paste password unchanged, while an empty one removes it.
+	if body.Password != nil {
+		p.Password.Set(*body.Password)
+
+		if *body.Password == "" {
+			p.Password.Hash = []byte{}
 		}
+	}
+
+	if body.Text != "" {
+		p.File = entity.File(body.Text)
+	}
 
-		p.ExpiresAt = time.Now().Add(d)
+	// Nil tags leave the paste tags unchanged, while empty tags remove them.
+	if body.Tags != nil {
+		p.Tags = *body.Tags
+	}
+
+	// Nil schema leaves the paste schema unchanged, while an empty one removes it.
+	if body.Schema != nil {
+		p.Schema.String, p.Schema.Valid = *body.Schema, true
+	}
+
+	for _, f := range body.Files {
+		p.Files = append(p.Files, &entity.PasteFile{Hash: hash, Name: f.Name, File: entity.File(f.Text)})
+	}
+
+	var err error
+
+	p.Expiration, err = ExpirationToEntity(body.Expires, body.ExpiresAt)
+	if err != nil {
+		return nil, err
 	}
 
 	return p, nil
 }
 
+// ExpirationToEntity parses the expires and expires_at fields of a request body.
+// Both fields empty means the default expiration.
+// expiresAt must be already validated as RFC 3339 date.
+func ExpirationToEntity(expires, expiresAt string) (entity.Expiration, error) {
+	var e entity.Expiration
+
+	switch {
+	case expires == entity.ExpiresNever:
+		e.Never = true
+	case expires != "":
+		d, err := time.ParseDuration(expires)
+		if err != nil {
+			return e, ErrInvalidExpiration
+		}
+
+		e.Duration = d
+	case expiresAt != "":
+		t, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return e, err
+		}
+
+		e.At = t
+	}
+
+	return e, nil
+}
+
+// TagMatchToEntity reports whether pastes must have any of the queried tags rather than all.
+// Empty match means all tags.
+func TagMatchToEntity(match string) (bool, error) {
+	switch match {
+	case "", "all":
+		return false, nil
+	case "any":
+		return true, nil
+	default:
+		return false, ErrInvalidTagMatch
+	}
+}
+
+// ForkPasteToEntity returns a fork of the source paste with a new hash.
+// The rest of the fork is filled from the source paste.
+func ForkPasteToEntity(source string, body *entity.ForkPasteBody) *entity.Paste {
+	return &entity.Paste{
+		Hash:       generateHash(source),
+		Title:      body.Title,
+		ForkedFrom: sql.NullString{String: source, Valid: true},
+	}
+}
+
+// ConvertPasteToEntity returns a conversion of the source paste with a new hash.
+// The text, format and title of the conversion are filled from the source paste.
+func ConvertPasteToEntity(source string) *entity.Paste {
+	return &entity.Paste{
+		Hash:          generateHash(source),
+		ConvertedFrom: sql.NullString{String: source, Valid: true},
+	}
+}
+
 func ModelToResponse(model *entity.Paste) *entity.PasteResponse {
 	return &entity.PasteResponse{
+		Hash:             model.Hash,
+		Title:            model.Title,
+		Text:             string(model.File),
+		Format:           model.Format,
+		FormatConfidence: model.FormatConfidence,
+		ExpiresAt:        formatExpiresAt(model.ExpiresAt),
+		CreatedAt:        model.CreatedAt.Format(time.RFC1123),
+		UpdatedAt:        model.UpdatedAt.Format(time.RFC1123),
+		Revision:         model.Revision,
+		ForkedFrom:       model.ForkedFrom.String,
+		ConvertedFrom:    model.ConvertedFrom.String,
+		Schema:           model.Schema.String,
+		Forks:            model.Forks,
+		BurnAfterRead:    model.BurnAfterRead,
+		Views:            model.Views,
+		MaxViews:         model.MaxViews,
+		Stars:            model.Stars,
+		Visibility:       string(model.Visibility),
+		Tags:             model.Tags,
+		Valid:            model.Valid,
+		Files:            FilesToResponse(model.Files),
+	}
+}
+
+func FilesToResponse(models []*entity.PasteFile) []entity.PasteFileResponse {
+	if len(models) == 0 {
+		return nil
+	}
+
+	resp := make([]entity.PasteFileResponse, 0, len(models))
+	for _, m := range models {
+		resp = append(resp, entity.PasteFileResponse{
+			Name:   m.Name,
+			Format: m.Format,
+			Text:   string(m.File),
+		})
+	}
+
+	return resp
+}
+
+// formatExpiresAt returns an empty string for pastes that never expire.
+func formatExpiresAt(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.Format(time.RFC1123)
+}
+
+func ModelToMetaResponse(model *entity.Paste) *entity.PasteMetaResponse {
+	return &entity.PasteMetaResponse{
 		Hash:      model.Hash,
 		Title:     model.Title,
+		Format:    model.Format,
+		CreatedAt: model.CreatedAt.Format(time.RFC1123),
+		UpdatedAt: model.UpdatedAt.Format(time.RFC1123),
+		ExpiresAt: formatExpiresAt(model.ExpiresAt),
+		Tags:      model.Tags,
+	}
+}
+
+func ModelsToMetaResponse(models []*entity.Paste) []*entity.PasteMetaResponse {
+	resp := make([]*entity.PasteMetaResponse, 0, len(models))
+	for _, m := range models {
+		resp = append(resp, ModelToMetaResponse(m))
+	}
+
+	return resp
+}
+
+func RevisionToResponse(model *entity.PasteRevision) *entity.RevisionResponse {
+	return &entity.RevisionResponse{
+		Revision:  model.Revision,
+		Title:     model.Title,
 		Text:      string(model.File),
 		Format:    model.Format,
-		ExpiresAt: model.ExpiresAt.Format(time.RFC1123),
 		CreatedAt: model.CreatedAt.Format(time.RFC1123),
+		Files:     FilesToResponse(model.Files),
+	}
+}
+
+func RevisionsToResponse(models []*entity.PasteRevision) []*entity.RevisionResponse {
+	resp := make([]*entity.RevisionResponse, 0, len(models))
+	for _, m := range models {
+		resp = append(resp, RevisionToResponse(m))
+	}
+
+	return resp
+}
+
+func DiffToResponse(model *entity.Diff) *entity.DiffResponse {
+	return &entity.DiffResponse{
+		From:    model.From,
+		To:      model.To,
+		Unified: model.Unified,
+		Hunks:   model.Hunks,
+	}
+}
+
+func DetectFormatToEntity(body entity.DetectFormatBody) entity.File {
+	return entity.File(body.Text)
+}
+
+func FormatDetectionToResponse(d entity.FormatDetection) *entity.FormatDetectionResponse {
+	return &entity.FormatDetectionResponse{
+		Format:     d.Format,
+		Confidence: d.Confidence,
 	}
 }
+
+func SchemaValidationToResponse(violations []entity.SchemaViolation) *entity.SchemaValidationResponse {
+	resp := &entity.SchemaValidationResponse{
+		Valid:      len(violations) == 0,
+		Violations: make([]entity.SchemaViolationResponse, 0, len(violations)),
+	}
+
+	for _, v := range violations {
+		resp.Violations = append(resp.Violations, entity.SchemaViolationResponse{
+			Pointer: v.Pointer,
+			Keyword: v.Keyword,
+			Message: v.Msg,
+		})
+	}
+
+	return resp
+}
+
+// CursorToEntity decodes a search cursor returned with the previous page.
+// Empty cursor means the first page.
+func CursorToEntity(cursor string) (*entity.SearchCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	rank, hash, ok := strings.Cut(string(raw), ":")
+	if !ok || len(hash) != hashLen {
+		return nil, ErrInvalidCursor
+	}
+
+	r, err := strconv.ParseFloat(rank, 32)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	return &entity.SearchCursor{Rank: float32(r), Hash: hash}, nil
+}
+
+// CursorToResponse encodes a search cursor, nil cursor is encoded as empty string.
+func CursorToResponse(c *entity.SearchCursor) string {
+	if c == nil {
+		return ""
+	}
+
+	raw := fmt.Sprintf("%s:%s", strconv.FormatFloat(float64(c.Rank), 'g', -1, 32), c.Hash)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func SearchPageToResponse(page *entity.SearchPage) *entity.SearchPageResponse {
+	resp := &entity.SearchPageResponse{
+		Results: make([]*entity.SearchResultResponse, 0, len(page.Results)),
+		Next:    CursorToResponse(page.Next),
+	}
+
+	for _, res := range page.Results {
+		resp.Results = append(resp.Results, &entity.SearchResultResponse{
+			PasteMetaResponse: *ModelToMetaResponse(res.Paste),
+			Rank:              res.Rank,
+			Snippet:           res.Snippet,
+			Highlights:        res.Highlights,
+		})
+	}
+
+	return resp
+}
+
+// PasteListCursorToEntity decodes a paste list cursor returned with the previous page
+// of pastes listed by the sort field. Empty cursor means the first page.
+func PasteListCursorToEntity(cursor string, sort entity.PasteSort) (*entity.PasteListCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 || entity.PasteSort(parts[0]) != sort || len(parts[1]) != hashLen {
+		return nil, ErrInvalidCursor
+	}
+
+	c := &entity.PasteListCursor{Sort: sort, Hash: parts[1], Key: parts[2]}
+
+	if sort != entity.SortTitle && c.Key != "infinity" {
+		if _, err := time.Parse(time.RFC3339Nano, c.Key); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+
+	return c, nil
+}
+
+// PasteListCursorToResponse encodes a paste list cursor, nil cursor is encoded as empty string.
+func PasteListCursorToResponse(c *entity.PasteListCursor) string {
+	if c == nil {
+		return ""
+	}
+
+	raw := fmt.Sprintf("%s:%s:%s", c.Sort, c.Hash, c.Key)
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func UserPastesPageToResponse(page *entity.PastesPage) *entity.UserPastesPageResponse {
+	resp := &entity.UserPastesPageResponse{
+		Pastes: make([]*entity.UserPasteResponse, 0, len(page.Pastes)),
+		Next:   PasteListCursorToResponse(page.Next),
+	}
+
+	for _, p := range page.Pastes {
+		resp.Pastes = append(resp.Pastes, &entity.UserPasteResponse{
+			PasteMetaResponse: *ModelToMetaResponse(p),
+			Locked:            p.Password.Hash != nil,
+			Expired:           p.Expired(),
+			BurnAfterRead:     p.BurnAfterRead,
+			Views:             p.Views,
+			MaxViews:          p.MaxViews,
+			Visibility:        string(p.Visibility),
+		})
+	}
+
+	return resp
+}
+
+func TaggedPastesPageToResponse(page *entity.PastesPage) *entity.TaggedPastesPageResponse {
+	return &entity.TaggedPastesPageResponse{
+		Pastes: ModelsToMetaResponse(page.Pastes),
+		Next:   PasteListCursorToResponse(page.Next),
+	}
+}
+
+func StarsPageToResponse(page *entity.StarsPage) *entity.StarredPastesPageResponse {
+	resp := &entity.StarredPastesPageResponse{
+		Pastes: make([]*entity.StarredPasteResponse, 0, len(page.Pastes)),
+		Next:   PasteListCursorToResponse(page.Next),
+	}
+
+	for _, s := range page.Pastes {
+		resp.Pastes = append(resp.Pastes, &entity.StarredPasteResponse{
+			PasteMetaResponse: *ModelToMetaResponse(s.Paste),
+			Stars:             s.Paste.Stars,
+			StarredAt:         s.StarredAt.Format(time.RFC1123),
+		})
+	}
+
+	return resp
+}
+
+func TagCountsToResponse(counts []*entity.TagCount) []*entity.TagCountResponse {
+	resp := make([]*entity.TagCountResponse, 0, len(counts))
+	for _, c := range counts {
+		resp = append(resp, &entity.TagCountResponse{Tag: c.Tag, Count: c.Count})
+	}
+
+	return resp
+}
+
+func FeedToResponse(feed *entity.Feed) []*entity.FeedEntryResponse {
+	resp := make([]*entity.FeedEntryResponse, 0, len(feed.Entries))
+	for _, e := range feed.Entries {
+		resp = append(resp, &entity.FeedEntryResponse{
+			PasteMetaResponse: *ModelToMetaResponse(e.Paste),
+			Author:            e.Author,
+			Excerpt:           e.Excerpt,
+		})
+	}
+
+	return resp
+}
+
+// FeedToAtom returns the feed as Atom document. Links of pastes are made by link
+// from paste hashes, self is the URL of the document and title is the feed title.
+func FeedToAtom(feed *entity.Feed, title, self string, link func(hash string) string) *entity.AtomFeed {
+	doc := &entity.AtomFeed{
+		ID:      self,
+		Title:   title,
+		Updated: feed.Updated.UTC().Format(time.RFC3339),
+		Links:   []entity.AtomLink{{Href: self, Rel: "self", Type: "application/atom+xml"}},
+		Entries: make([]*entity.AtomEntry, 0, len(feed.Entries)),
+	}
+
+	// Atom feeds must have the updated date even if they are empty.
+	if feed.Updated.IsZero() {
+		doc.Updated = time.Unix(0, 0).UTC().Format(time.RFC3339)
+	}
+
+	for _, e := range feed.Entries {
+		entry := &entity.AtomEntry{
+			ID:        link(e.Paste.Hash),
+			Title:     e.Paste.Title,
+			Updated:   e.Paste.UpdatedAt.UTC().Format(time.RFC3339),
+			Published: e.Paste.CreatedAt.UTC().Format(time.RFC3339),
+			Links:     []entity.AtomLink{{Href: link(e.Paste.Hash), Rel: "alternate", Type: "text/html"}},
+			Category:  entity.AtomCategory{Term: e.Paste.Format},
+			Summary:   e.Excerpt,
+		}
+
+		// Entries of anonymous pastes have no author, so the feed must have one.
+		if e.Author != "" {
+			entry.Author = &entity.AtomAuthor{Name: e.Author}
+		} else {
+			doc.Author = &entity.AtomAuthor{Name: "anonymous"}
+		}
+
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return doc
+}
+
+// FeedToRSS returns the feed as RSS document, arguments are the same as for FeedToAtom.
+func FeedToRSS(feed *entity.Feed, title, self string, link func(hash string) string) *entity.RSSFeed {
+	doc := &entity.RSSFeed{
+		Version: "2.0",
+		Channel: entity.RSSChannel{
+			Title:       title,
+			Link:        self,
+			Description: title,
+			Items:       make([]*entity.RSSItem, 0, len(feed.Entries)),
+		},
+	}
+
+	if !feed.Updated.IsZero() {
+		doc.Channel.LastBuildDate = feed.Updated.UTC().Format(time.RFC1123Z)
+	}
+
+	for _, e := range feed.Entries {
+		doc.Channel.Items = append(doc.Channel.Items, &entity.RSSItem{
+			Title:       e.Paste.Title,
+			Link:        link(e.Paste.Hash),
+			GUID:        entity.RSSGUID{Value: link(e.Paste.Hash), IsPermaLink: true},
+			PubDate:     e.Paste.CreatedAt.UTC().Format(time.RFC1123Z),
+			Category:    e.Paste.Format,
+			Description: e.Excerpt,
+		})
+	}
+
+	return doc
+}
@@ -0,0 +1,62 @@
+package entity
+
+import "time"
+
+// Collection is an ordered list of pastes owned by a user and shared by its own hash.
+type Collection struct {
+	Hash       string     `db:"hash"`
+	UserID     string     `db:"user_id"`
+	Title      string     `db:"title"`
+	Visibility Visibility `db:"visibility"`
+	CreatedAt  time.Time  `db:"created_at"`
+	UpdatedAt  time.Time  `db:"updated_at"`
+	// Pastes are metadata of pastes of the collection in order, without texts.
+	Pastes []*Paste
+}
+
+// @description Тело запроса для создания коллекции.
+type CreateCollectionBody struct {
+	// Название
+	Title string `json:"title" example:"Onboarding runbook" validate:"required,max=255"`
+	// Видимость коллекции, как у паст. По умолчанию скрытая.
+	Visibility string `json:"visibility" example:"unlisted" enums:"public,unlisted,private" validate:"omitempty,oneof=public unlisted private"`
+	// Хеши паст коллекции в порядке следования
+	Pastes []string `json:"pastes" example:"HrEQaEvs,aB3dE5gH" validate:"omitempty,max=100,unique,dive,len=8"`
+} // @name CreateCollectionBody
+
+// @description Тело запроса для изменения коллекции.
+// @description Пустые поля остаются без изменений.
+type UpdateCollectionBody struct {
+	// Название
+	Title string `json:"title" example:"Onboarding runbook" validate:"omitempty,max=255"`
+	// Видимость коллекции
+	Visibility string `json:"visibility" example:"private" enums:"public,unlisted,private" validate:"omitempty,oneof=public unlisted private"`
+} // @name UpdateCollectionBody
+
+// @description Тело запроса для добавления пасты в коллекцию.
+type AddCollectionPasteBody struct {
+	// Хеш пасты, паста добавляется в конец коллекции
+	Hash string `json:"hash" example:"HrEQaEvs" validate:"required,len=8"`
+} // @name AddCollectionPasteBody
+
+// @description Тело запроса для изменения порядка паст коллекции.
+type ReorderCollectionBody struct {
+	// Хеши всех паст коллекции в новом порядке
+	Pastes []string `json:"pastes" example:"aB3dE5gH,HrEQaEvs" validate:"required,max=100,unique,dive,len=8"`
+} // @name ReorderCollectionBody
+
+// @description Коллекция паст.
+type CollectionResponse struct {
+	// Уникальный идентификатор
+	Hash string `json:"hash" example:"Kx8QaEvs"`
+	// Название
+	Title string `json:"title" example:"Onboarding runbook"`
+	// Видимость коллекции
+	Visibility string `json:"visibility" example:"unlisted"`
+	// Дата создания
+	CreatedAt string `json:"created_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Дата последнего изменения
+	UpdatedAt string `json:"updated_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Метаданные паст коллекции в порядке следования
+	Pastes []*PasteMetaResponse `json:"pastes"`
+} // @name Collection
@@ -0,0 +1,74 @@
+package entity
+
+import "time"
+
+// PasteComment is a comment on a paste, optionally anchored to a range of lines
+// of the paste text. Replies share the anchor of the comment they reply to.
+type PasteComment struct {
+	ID        int64  `db:"id"`
+	PasteHash string `db:"paste_hash"`
+	UserID    string `db:"user_id"`
+	// Author is the name of the comment author.
+	Author string `db:"username"`
+	// ParentID is the comment this one replies to, zero for top level comments.
+	ParentID int64 `db:"parent_id"`
+	// Revision is the paste revision the comment is made on, lines refer to its text.
+	Revision int `db:"revision"`
+	// LineStart and LineEnd are the first and the last commented lines starting from 1,
+	// zero for comments on the whole paste.
+	LineStart int    `db:"line_start"`
+	LineEnd   int    `db:"line_end"`
+	Text      string `db:"text"`
+	// Deleted comments are kept without text while they have replies.
+	Deleted   bool      `db:"deleted"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+// Anchored reports whether the comment is anchored to lines of the paste text.
+func (c *PasteComment) Anchored() bool {
+	return c.LineStart > 0 || c.LineEnd > 0
+}
+
+// @description Тело запроса для создания комментария.
+// @description Ответ наследует строки комментария, на который он отвечает.
+type CreateCommentBody struct {
+	// Текст комментария
+	Text string `json:"text" example:"Здесь лучше использовать errors.Is" validate:"required,max=10000"`
+	// Идентификатор комментария, на который дан ответ
+	ParentID int64 `json:"parent_id" example:"1" validate:"omitempty,min=1"`
+	// Первая комментируемая строка, начиная с 1
+	LineStart int `json:"line_start" example:"3" validate:"omitempty,min=1"`
+	// Последняя комментируемая строка, по умолчанию равна первой
+	LineEnd int `json:"line_end" example:"5" validate:"omitempty,min=1"`
+} // @name CreateCommentBody
+
+// @description Тело запроса для изменения комментария.
+type UpdateCommentBody struct {
+	// Текст комментария
+	Text string `json:"text" example:"Здесь лучше использовать errors.As" validate:"required,max=10000"`
+} // @name UpdateCommentBody
+
+// @description Комментарий к пасте.
+type CommentResponse struct {
+	// Идентификатор комментария
+	ID int64 `json:"id" example:"1"`
+	// Имя автора
+	Author string `json:"author" example:"octocat"`
+	// Ревизия пасты, к которой оставлен комментарий
+	Revision int `json:"revision" example:"1"`
+	// Первая комментируемая строка, не указывается для комментариев ко всей пасте
+	LineStart int `json:"line_start,omitempty" example:"3"`
+	// Последняя комментируемая строка
+	LineEnd int `json:"line_end,omitempty" example:"5"`
+	// Текст комментария, пустой у удалённых комментариев
+	Text string `json:"text" example:"Здесь лучше использовать errors.Is"`
+	// Комментарий удалён, но на него есть ответы
+	Deleted bool `json:"deleted,omitempty" example:"false"`
+	// Дата создания
+	CreatedAt string `json:"created_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Дата последнего изменения
+	UpdatedAt string `json:"updated_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Ответы на комментарий от старых к новым
+	Replies []*CommentResponse `json:"replies"`
+} // @name Comment
@@ -0,0 +1,51 @@
+package entity
+
+// DiffSource points to a paste version to compare.
+type DiffSource struct {
+	Hash string
+	// Revision is a paste revision number, zero means the current one.
+	Revision int
+	Password string
+}
+
+// Diff is a line based difference between two paste versions.
+type Diff struct {
+	From    string
+	To      string
+	Unified string
+	Hunks   []DiffHunk
+}
+
+// @description Группа изменённых строк.
+type DiffHunk struct {
+	// Номер первой строки в исходной версии
+	OldStart int `json:"old_start" example:"1"`
+	// Количество строк в исходной версии
+	OldLines int `json:"old_lines" example:"3"`
+	// Номер первой строки в новой версии
+	NewStart int `json:"new_start" example:"1"`
+	// Количество строк в новой версии
+	NewLines int `json:"new_lines" example:"4"`
+	// Строки
+	Lines []DiffLine `json:"lines"`
+} // @name DiffHunk
+
+// @description Строка группы изменений.
+type DiffLine struct {
+	// Операция: " " - без изменений, "-" - удалена, "+" - добавлена
+	Op string `json:"op" example:"+" enums:" ,-,+"`
+	// Текст строки
+	Text string `json:"text" example:"key: value"`
+} // @name DiffLine
+
+// @description Разница между версиями паст.
+type DiffResponse struct {
+	// Исходная версия
+	From string `json:"from" example:"HrEQaEvs@1"`
+	// Новая версия
+	To string `json:"to" example:"HrEQaEvs@2"`
+	// Разница в формате unified diff
+	Unified string `json:"unified" example:"--- HrEQaEvs@1\n+++ HrEQaEvs@2\n"`
+	// Группы изменений
+	Hunks []DiffHunk `json:"hunks"`
+} // @name DiffInfo
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"time"
+)
+
+// FeedEntry is a public paste in a feed.
+type FeedEntry struct {
+	// Paste is the paste metadata.
+	Paste *Paste
+	// Author is the name of the paste author, empty for anonymous pastes.
+	Author string
+	// Excerpt is the beginning of the paste text.
+	Excerpt string
+}
+
+// Feed is a list of recent public pastes, newest first.
+type Feed struct {
+	Entries []*FeedEntry
+	// Updated is the last time a paste of the feed was created or changed,
+	// zero for an empty feed.
+	Updated time.Time
+}
+
+// ETag returns a strong validator of the feed that changes with its entries.
+func (f *Feed) ETag() string {
+	h := sha256.New()
+
+	for _, e := range f.Entries {
+		h.Write([]byte(e.Paste.Hash))
+		h.Write([]byte(e.Paste.UpdatedAt.UTC().Format(time.RFC3339Nano)))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// @description Паста в ленте.
+type FeedEntryResponse struct {
+	PasteMetaResponse
+	// Имя автора, не указывается для анонимных паст
+	Author string `json:"author,omitempty" example:"octocat"`
+	// Начало текста пасты
+	Excerpt string `json:"excerpt" example:"services: web: image: nginx"`
+} // @name FeedEntry
+
+// AtomFeed is an Atom 1.0 feed document.
+type AtomFeed struct {
+	XMLName xml.Name     `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string       `xml:"id"`
+	Title   string       `xml:"title"`
+	Updated string       `xml:"updated"`
+	Links   []AtomLink   `xml:"link"`
+	Author  *AtomAuthor  `xml:"author,omitempty"`
+	Entries []*AtomEntry `xml:"entry"`
+}
+
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type AtomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type AtomEntry struct {
+	ID        string       `xml:"id"`
+	Title     string       `xml:"title"`
+	Updated   string       `xml:"updated"`
+	Published string       `xml:"published"`
+	Links     []AtomLink   `xml:"link"`
+	Author    *AtomAuthor  `xml:"author,omitempty"`
+	Category  AtomCategory `xml:"category"`
+	Summary   string       `xml:"summary"`
+}
+
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// RSSFeed is an RSS 2.0 feed document.
+type RSSFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel RSSChannel `xml:"channel"`
+}
+
+type RSSChannel struct {
+	Title         string     `xml:"title"`
+	Link          string     `xml:"link"`
+	Description   string     `xml:"description"`
+	LastBuildDate string     `xml:"lastBuildDate,omitempty"`
+	Items         []*RSSItem `xml:"item"`
+}
+
+type RSSItem struct {
+	Title       string  `xml:"title"`
+	Link        string  `xml:"link"`
+	GUID        RSSGUID `xml:"guid"`
+	PubDate     string  `xml:"pubDate"`
+	Category    string  `xml:"category"`
+	Description string  `xml:"description"`
+}
+
+type RSSGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatPlaintext is a format of texts without structure or syntax.
+const FormatPlaintext = "plaintext"
+
+// FormatDetection is a format guessed from a text.
+type FormatDetection struct {
+	// Format is a detected format, plaintext if nothing matched.
+	Format string
+	// Confidence is a score from 0 to 1 of how likely the format is right.
+	Confidence float64
+}
+
+// FormatError is an error of a text that does not match its format.
+type FormatError struct {
+	// Format is a format of the text.
+	Format string
+	// File is a name of the invalid file of a multi-file paste.
+	File string
+	// Line and Column are 1-based position of the error, 0 if unknown.
+	Line   int
+	Column int
+	// Msg is a parser error message.
+	Msg string
+}
+
+func (e *FormatError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "invalid %s", e.Format)
+
+	if e.Line > 0 {
+		fmt.Fprintf(&b, " at line %d", e.Line)
+	}
+
+	if e.Column > 0 {
+		fmt.Fprintf(&b, ", column %d", e.Column)
+	}
+
+	fmt.Fprintf(&b, ": %s", e.Msg)
+
+	return b.String()
+}
+
+// QueryError is an error of an invalid query expression.
+type QueryError struct {
+	// Column is a 1-based position of the error in the expression, 0 if unknown.
+	Column int
+	// Msg is an error message.
+	Msg string
+}
+
+func (e *QueryError) Error() string {
+	if e.Column > 0 {
+		return fmt.Sprintf("invalid query at column %d: %s", e.Column, e.Msg)
+	}
+
+	return fmt.Sprintf("invalid query: %s", e.Msg)
+}
+
+// ConvertOptions are options of a text converted to another format.
+type ConvertOptions struct {
+	// To is a target format, empty means the source one.
+	To string
+	// Minify writes the text as compact as the format allows, it is pretty printed otherwise.
+	Minify bool
+}
+
+// @description Тело запроса для определения формата текста.
+type DetectFormatBody struct {
+	// Текст
+	Text string `json:"text" example:"{\"key\": \"value\"}" validate:"required"`
+} // @name DetectFormatBody
+
+// @description Определенный формат текста.
+type FormatDetectionResponse struct {
+	// Формат текста
+	Format string `json:"format" example:"json"`
+	// Уверенность в формате от 0 до 1
+	Confidence float64 `json:"confidence" example:"1"`
+} // @name FormatDetection
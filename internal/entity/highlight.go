@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+)
+
+// HighlightOutput is an output of a paste rendered with syntax highlighting.
+type HighlightOutput string
+
+const (
+	// HighlightHTML is a HTML document, it is the default output.
+	HighlightHTML HighlightOutput = ""
+	// HighlightANSI256 is a text with ANSI escape codes for 256-color terminals.
+	HighlightANSI256 HighlightOutput = "ansi256"
+	// HighlightTrueColor is a text with ANSI escape codes for truecolor terminals.
+	HighlightTrueColor HighlightOutput = "truecolor"
+)
+
+// HighlightOptions are options of a paste rendered with syntax highlighting.
+type HighlightOptions struct {
+	// Output is a rendered output, HTML by default.
+	Output HighlightOutput
+	// Theme is a name of the color theme, empty means the default one.
+	Theme string
+	// Lines are inclusive ranges of highlighted lines.
+	Lines [][2]int
+	// LineNumbers adds line numbers to terminal outputs, HTML always has them.
+	LineNumbers bool
+}
+
+// Key returns a string identifying the rendered paste, pastes rendered
+// with equal options have equal keys.
+func (o HighlightOptions) Key() string {
+	var b strings.Builder
+
+	b.WriteString(string(o.Output))
+	b.WriteByte(':')
+	b.WriteString(o.Theme)
+
+	if o.LineNumbers {
+		b.WriteString(":ln")
+	}
+
+	for _, l := range o.Lines {
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(l[0]))
+		b.WriteByte('-')
+		b.WriteString(strconv.Itoa(l[1]))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,95 @@
+package entity
+
+import "time"
+
+// PasteSort is a field that pastes are listed by.
+type PasteSort string
+
+const (
+	SortCreated PasteSort = "created"
+	SortExpires PasteSort = "expires"
+	SortTitle   PasteSort = "title"
+	// SortStarred orders starred pastes by the star date, it is used only by starred lists.
+	SortStarred PasteSort = "starred"
+)
+
+// Cursor returns a cursor of the paste in pastes listed by the sort field.
+// Never expiring pastes are listed after all expiring ones.
+func (s PasteSort) Cursor(p *Paste) *PasteListCursor {
+	c := &PasteListCursor{Sort: s, Hash: p.Hash}
+
+	switch s {
+	case SortExpires:
+		c.Key = "infinity"
+		if !p.ExpiresAt.IsZero() {
+			c.Key = p.ExpiresAt.Format(time.RFC3339Nano)
+		}
+	case SortTitle:
+		c.Key = p.Title
+	default:
+		c.Key = p.CreatedAt.Format(time.RFC3339Nano)
+	}
+
+	return c
+}
+
+// PasteListQuery is a listing of pastes of a user.
+type PasteListQuery struct {
+	UserID string
+	// Format filters pastes by format, empty value matches all.
+	Format string
+	// Expired lists only expired pastes if true and only active if false, nil lists all.
+	Expired *bool
+	// Locked lists only pastes with password if true and only without if false, nil lists all.
+	Locked *bool
+	// Tags filters pastes by tags, empty tags match all.
+	Tags []string
+	// AnyTag lists pastes with any of the tags, otherwise pastes must have all of them.
+	AnyTag bool
+	Sort   PasteSort
+	Desc   bool
+	// After is a cursor of the last paste of the previous page, nil for the first page.
+	After *PasteListCursor
+	// Limit is a max number of pastes of the page.
+	Limit int
+}
+
+// PasteListCursor is a position of a paste in pastes ordered by the sort field and hash.
+type PasteListCursor struct {
+	Sort PasteSort
+	// Key is the sort field value of the paste as text.
+	Key  string
+	Hash string
+}
+
+// PastesPage is a page of listed pastes.
+type PastesPage struct {
+	Pastes []*Paste
+	// Next is a cursor of the next page, nil for the last page.
+	Next *PasteListCursor
+}
+
+// @description Метаданные пасты автора.
+type UserPasteResponse struct {
+	PasteMetaResponse
+	// Паста защищена паролем
+	Locked bool `json:"locked" example:"false"`
+	// Паста сгорела
+	Expired bool `json:"expired" example:"false"`
+	// Паста удаляется после первого прочтения
+	BurnAfterRead bool `json:"burn_after_read" example:"false"`
+	// Количество просмотров
+	Views int `json:"views" example:"1"`
+	// Максимальное количество просмотров
+	MaxViews int `json:"max_views,omitempty" example:"10"`
+	// Видимость пасты
+	Visibility string `json:"visibility" example:"unlisted"`
+} // @name UserPaste
+
+// @description Страница паст автора.
+type UserPastesPageResponse struct {
+	// Пасты автора
+	Pastes []*UserPasteResponse `json:"pastes"`
+	// Курсор следующей страницы, не указывается для последней страницы
+	Next string `json:"next,omitempty" example:"Y3JlYXRlZDpIckVRYUV2czoyMDIzLTEwLTI5VDIwOjM4OjQxKzA4OjAw"`
+} // @name UserPastesPage
@@ -11,14 +11,92 @@ import (
 var ErrPasteNotFound = errors.New("paste not found")
 
 type Paste struct {
-	Hash      string         `db:"hash"`
-	UserID    sql.NullString `db:"user_id"`
-	Title     string         `db:"title"`
-	Format    string         `db:"format"`
-	CreatedAt time.Time      `db:"created_at"`
-	ExpiresAt time.Time      `db:"expires_at"`
-	File      File
-	Password  Password
+	Hash   string         `db:"hash"`
+	UserID sql.NullString `db:"user_id"`
+	Title  string         `db:"title"`
+	Format string         `db:"format"`
+	// FormatConfidence is a confidence of the detected format, 1 for formats set by the author.
+	FormatConfidence float64        `db:"format_confidence"`
+	CreatedAt        time.Time      `db:"created_at"`
+	UpdatedAt        time.Time      `db:"updated_at"`
+	ExpiresAt        time.Time      `db:"expires_at"`
+	Revision         int            `db:"revision"`
+	ForkedFrom       sql.NullString `db:"forked_from"`
+	ConvertedFrom    sql.NullString `db:"converted_from"`
+	Schema           sql.NullString `db:"schema"`
+	Forks            int            `db:"forks"`
+	BurnAfterRead    bool           `db:"burn_after_read"`
+	Views            int            `db:"views"`
+	MaxViews         int            `db:"max_views"`
+	Visibility       Visibility     `db:"visibility"`
+	Stars            int            `db:"stars"`
+	// Tags are normalized tags of the paste ordered by name.
+	// Nil tags of a paste update leave the paste tags unchanged.
+	Tags []string `db:"tags"`
+	// Valid is false for pastes stored with texts that do not match their formats.
+	Valid bool `db:"valid"`
+	// AllowInvalid stores the paste even if its text does not match the format.
+	AllowInvalid bool `db:"-" json:"-"`
+	File         File
+	Password     Password
+	Expiration   Expiration `db:"-" json:"-"`
+	Files        []*PasteFile
+}
+
+// PasteFile is a named file of a multi-file paste.
+//
+// The first file of a multi-file paste is the paste text itself,
+// so its format and text are always the paste ones.
+type PasteFile struct {
+	Hash     string `db:"paste_hash"`
+	Position int    `db:"position"`
+	Name     string `db:"name"`
+	Format   string `db:"format"`
+	File     File
+}
+
+// Visibility is a level of paste visibility.
+type Visibility string
+
+const (
+	// VisibilityPublic pastes are listed in feeds and search.
+	VisibilityPublic Visibility = "public"
+	// VisibilityUnlisted pastes are readable by anyone who knows the hash.
+	VisibilityUnlisted Visibility = "unlisted"
+	// VisibilityPrivate pastes are readable only by the author and users granted access.
+	VisibilityPrivate Visibility = "private"
+)
+
+// ExpiresNever is a value of expires field for pastes that never expire.
+const ExpiresNever = "never"
+
+// Expiration is a requested lifetime of a paste.
+// Zero Expiration means the default lifetime.
+type Expiration struct {
+	// Duration is a lifetime from now.
+	Duration time.Duration
+	// At is an absolute expiration date.
+	At time.Time
+	// Never means the paste never expires.
+	Never bool
+}
+
+func (e Expiration) IsZero() bool {
+	return e == Expiration{}
+}
+
+// PasteRevision is a previous version of a paste.
+type PasteRevision struct {
+	Hash      string    `db:"paste_hash"`
+	Revision  int       `db:"revision"`
+	Title     string    `db:"title"`
+	Format    string    `db:"format"`
+	CreatedAt time.Time `db:"created_at"`
+	// HasFiles is true for revisions of multi-file pastes that keep texts of all files.
+	// Revisions stored before files were kept have only the paste text.
+	HasFiles bool `db:"files"`
+	File     File
+	Files    []*PasteFile
 }
 
 func (p *Paste) UnmarshalBinary(raw []byte) error {
@@ -29,6 +107,25 @@ func (p *Paste) MarshalBinary() ([]byte, error) {
 	return json.Marshal(p)
 }
 
+// Expired reports whether the paste expiration date has passed.
+// A paste without expiration date never expires.
+func (p *Paste) Expired() bool {
+	return !p.ExpiresAt.IsZero() && p.ExpiresAt.Before(time.Now())
+}
+
+// Revisioned returns the current state of the paste as a revision.
+func (p *Paste) Revisioned() *PasteRevision {
+	return &PasteRevision{
+		Hash:      p.Hash,
+		Revision:  p.Revision,
+		Title:     p.Title,
+		Format:    p.Format,
+		CreatedAt: p.UpdatedAt,
+		HasFiles:  len(p.Files) > 0,
+		File:      p.File,
+	}
+}
+
 type Password struct {
 	Plaintext string `db:"-"`
 	Hash      []byte `db:"password_hash"`
@@ -56,18 +153,80 @@ func (f File) Size() int64 {
 
 // @description Тело запроса для создания пасты.
 type CreatePasteBody struct {
-	// Текст
-	Text string `json:"text" example:"Some very secret text" validate:"required"`
-	// Формат текста
-	Format string `json:"format" example:"plaintext" enums:"json,yaml,toml" validate:"required,oneof=json plaintext toml yaml xml"`
-	// Время, через которое паста становится не доступной
-	Expires string `json:"expires" example:"30m" validate:"omitempty,oneof=30m 1h 168h 5040h"`
+	// Текст, не указывается вместе с файлами
+	Text string `json:"text" example:"Some very secret text" validate:"required_without=Files,excluded_with=Files"`
+	// Формат текста, не указывается вместе с файлами.
+	// Если не указан, определяется по тексту.
+	Format string `json:"format" example:"plaintext" enums:"json,yaml,toml" validate:"excluded_with=Files,omitempty,oneof=json plaintext toml yaml xml c dockerfile go html java javascript markdown php python rust shell sql typescript"`
+	// Файлы пасты, первый файл считается основным текстом пасты
+	Files []PasteFileBody `json:"files" validate:"omitempty,max=20,unique=Name,dive"`
+	// Время, через которое паста становится не доступной, например `30m` или `72h`.
+	// Значение `never` доступно только авторизованным пользователям.
+	Expires string `json:"expires" example:"30m" validate:"omitempty,excluded_with=ExpiresAt"`
+	// Дата, после которой паста становится не доступной, в формате RFC 3339
+	ExpiresAt string `json:"expires_at" example:"2023-10-29T20:38:41+08:00" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
 	// Пароль для получения доступа к пасте
 	Password string `json:"password" example:"password for security" validate:"omitempty,max=255"`
 	// Название
 	Title string `json:"title" example:"The private paste" validate:"omitempty,max=255"`
+	// Удалить пасту после первого прочтения
+	BurnAfterRead bool `json:"burn_after_read" example:"false"`
+	// Максимальное количество просмотров, после которого паста становится не доступной
+	MaxViews int `json:"max_views" example:"10" validate:"omitempty,min=1"`
+	// Хеш пасты с JSON схемой, которой должен соответствовать текст при создании и каждом изменении
+	Schema string `json:"schema" example:"HrEQaEvs" validate:"omitempty,len=8"`
+	// Видимость пасты: публичные пасты видны в ленте и поиске, скрытые доступны по хешу,
+	// приватные только автору и пользователям с доступом. По умолчанию скрытая.
+	// Приватные пасты доступны только авторизованным пользователям.
+	Visibility string `json:"visibility" example:"unlisted" enums:"public,unlisted,private" validate:"omitempty,oneof=public unlisted private"`
+	// Теги пасты, приводятся к нижнему регистру, пробелы заменяются на дефисы
+	Tags []string `json:"tags" example:"go,k8s" validate:"omitempty,max=10,dive,required,max=32"`
 } // @name CreatePasteBody
 
+// @description Файл пасты.
+type PasteFileBody struct {
+	// Имя файла
+	Name string `json:"name" example:"docker-compose.yaml" validate:"required,max=255,excludesall=/\\,ne=.,ne=.."`
+	// Текст
+	Text string `json:"text" example:"services: {}" validate:"required"`
+	// Формат текста, если не указан, определяется по тексту
+	Format string `json:"format" example:"yaml" enums:"json,yaml,toml" validate:"omitempty,oneof=json plaintext toml yaml xml c dockerfile go html java javascript markdown php python rust shell sql typescript"`
+} // @name PasteFileBody
+
+// @description Тело запроса для изменения пасты.
+// @description Пустые поля остаются без изменений.
+type UpdatePasteBody struct {
+	// Текст
+	Text string `json:"text" example:"Some very secret text"`
+	// Формат текста
+	Format string `json:"format" example:"plaintext" enums:"json,yaml,toml" validate:"omitempty,oneof=json plaintext toml yaml xml c dockerfile go html java javascript markdown php python rust shell sql typescript"`
+	// Время, через которое паста становится не доступной, например `30m` или `72h`.
+	// Значение `never` доступно только авторизованным пользователям.
+	Expires string `json:"expires" example:"30m" validate:"omitempty,excluded_with=ExpiresAt"`
+	// Дата, после которой паста становится не доступной, в формате RFC 3339
+	ExpiresAt string `json:"expires_at" example:"2023-10-29T20:38:41+08:00" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	// Пароль для получения доступа к пасте, пустая строка удаляет пароль
+	Password *string `json:"password" example:"password for security" validate:"omitempty,max=255"`
+	// Название
+	Title string `json:"title" example:"The private paste" validate:"omitempty,max=255"`
+	// Видимость пасты
+	Visibility string `json:"visibility" example:"private" enums:"public,unlisted,private" validate:"omitempty,oneof=public unlisted private"`
+	// Теги пасты, заменяют текущие теги, пустой список удаляет все теги
+	Tags *[]string `json:"tags" example:"go,k8s" validate:"omitempty,max=10,dive,required,max=32"`
+	// Хеш пасты с JSON схемой, заменяет текущую схему, пустая строка удаляет схему
+	Schema *string `json:"schema" example:"HrEQaEvs" validate:"omitempty,len=0|len=8"`
+	// Новые тексты файлов многофайловой пасты, остальные файлы остаются без изменений
+	Files []UpdatePasteFileBody `json:"files" validate:"omitempty,max=20,unique=Name,dive"`
+} // @name UpdatePasteBody
+
+// @description Новый текст файла пасты.
+type UpdatePasteFileBody struct {
+	// Имя файла
+	Name string `json:"name" example:"docker-compose.yaml" validate:"required,max=255"`
+	// Текст
+	Text string `json:"text" example:"services: {}" validate:"required"`
+} // @name UpdatePasteFileBody
+
 // @description Тело ответа на создание пасты.
 type PasteResponse struct {
 	// Уникальный идентификатор
@@ -78,12 +237,109 @@ type PasteResponse struct {
 	Text string `json:"text" example:"The some paste"`
 	// Формат текста
 	Format string `json:"format" example:"plaintext"`
+	// Уверенность в формате от 0 до 1, меньше 1 если формат определен по тексту
+	FormatConfidence float64 `json:"format_confidence" example:"1"`
 	// Дата создания
 	CreatedAt string `json:"created_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
-	// Дата сгорания
-	ExpiresAt string `json:"expires_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Дата последнего изменения
+	UpdatedAt string `json:"updated_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Дата сгорания, не указывается для бессрочных паст
+	ExpiresAt string `json:"expires_at,omitempty" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Номер текущей ревизии
+	Revision int `json:"revision" example:"1"`
+	// Хеш пасты, из которой сделан форк
+	ForkedFrom string `json:"forked_from,omitempty" example:"HrEQaEvs"`
+	// Хеш пасты, из которой сконвертирована паста
+	ConvertedFrom string `json:"converted_from,omitempty" example:"HrEQaEvs"`
+	// Хеш пасты с JSON схемой текста
+	Schema string `json:"schema,omitempty" example:"HrEQaEvs"`
+	// Количество форков
+	Forks int `json:"forks" example:"0"`
+	// Паста удаляется после первого прочтения
+	BurnAfterRead bool `json:"burn_after_read" example:"false"`
+	// Количество просмотров
+	Views int `json:"views" example:"1"`
+	// Максимальное количество просмотров
+	MaxViews int `json:"max_views,omitempty" example:"10"`
+	// Количество звёзд
+	Stars int `json:"stars" example:"3"`
+	// Видимость пасты
+	Visibility string `json:"visibility" example:"unlisted"`
+	// Теги пасты
+	Tags []string `json:"tags,omitempty" example:"go,k8s"`
+	// Текст соответствует формату
+	Valid bool `json:"valid" example:"true"`
+	// Файлы пасты, первый файл совпадает с текстом пасты
+	Files []PasteFileResponse `json:"files,omitempty"`
 } // @name PasteInfo
 
+// @description Файл пасты.
+type PasteFileResponse struct {
+	// Имя файла
+	Name string `json:"name" example:"docker-compose.yaml"`
+	// Формат текста
+	Format string `json:"format" example:"yaml"`
+	// Текст
+	Text string `json:"text" example:"services: {}"`
+} // @name PasteFileInfo
+
+// @description Метаданные пасты без текста.
+type PasteMetaResponse struct {
+	// Уникальный идентификатор
+	Hash string `json:"hash" example:"HrEQaEvs"`
+	// Название
+	Title string `json:"title,omitempty" example:"The paste"`
+	// Формат текста
+	Format string `json:"format" example:"plaintext"`
+	// Дата создания
+	CreatedAt string `json:"created_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Дата последнего изменения
+	UpdatedAt string `json:"updated_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Дата сгорания, не указывается для бессрочных паст
+	ExpiresAt string `json:"expires_at,omitempty" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Теги пасты
+	Tags []string `json:"tags,omitempty" example:"go,k8s"`
+} // @name PasteMeta
+
+// @description Тело запроса для выдачи доступа к приватной пасте.
+type GrantAccessBody struct {
+	// Имя пользователя
+	Username string `json:"username" example:"octocat" validate:"required,max=255"`
+} // @name GrantAccessBody
+
+// @description Тело запроса для создания форка пасты.
+type ForkPasteBody struct {
+	// Пароль исходной пасты, если она защищена
+	Password string `json:"password" example:"password for security" validate:"omitempty,max=255"`
+	// Название форка, по умолчанию название исходной пасты
+	Title string `json:"title" example:"My fork" validate:"omitempty,max=255"`
+} // @name ForkPasteBody
+
+// @description Тело запроса для продления пасты.
+// @description Без полей паста продлевается на время жизни по умолчанию.
+type ExtendPasteBody struct {
+	// Время, через которое паста становится не доступной, например `30m` или `72h`, или `never`
+	Expires string `json:"expires" example:"168h" validate:"omitempty,excluded_with=ExpiresAt"`
+	// Дата, после которой паста становится не доступной, в формате RFC 3339
+	ExpiresAt string `json:"expires_at" example:"2023-10-29T20:38:41+08:00" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+} // @name ExtendPasteBody
+
+// @description Ревизия пасты.
+type RevisionResponse struct {
+	// Номер ревизии
+	Revision int `json:"revision" example:"1"`
+	// Название
+	Title string `json:"title,omitempty" example:"The paste"`
+	// Текст
+	Text string `json:"text,omitempty" example:"The some paste"`
+	// Формат текста
+	Format string `json:"format" example:"plaintext"`
+	// Дата создания ревизии
+	CreatedAt string `json:"created_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+	// Файлы многофайловой пасты в этой ревизии
+	Files []PasteFileResponse `json:"files,omitempty"`
+} // @name RevisionInfo
+
 // @description Тело запроса для разблокировки пасты.
 type UnlockPasteBody struct {
 	// Пароль
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaViolation is a value of a text that does not match a JSON schema.
+type SchemaViolation struct {
+	// Pointer is a JSON pointer to the invalid value, empty for the whole text.
+	Pointer string
+	// Keyword is a schema keyword that the value violates.
+	Keyword string
+	// Msg is a description of the violation.
+	Msg string
+}
+
+// SchemaError is an error of a paste text that does not match the paste schema.
+type SchemaError struct {
+	// Schema is a hash of the schema paste.
+	Schema     string
+	Violations []SchemaViolation
+}
+
+func (e *SchemaError) Error() string {
+	msgs := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", v.Pointer, v.Msg))
+	}
+
+	return fmt.Sprintf("the text does not match the schema %s: %s", e.Schema, strings.Join(msgs, "; "))
+}
+
+// @description Нарушение JSON схемы.
+type SchemaViolationResponse struct {
+	// JSON указатель на значение, пустой для всего текста
+	Pointer string `json:"pointer" example:"/spec/replicas"`
+	// Ключевое слово схемы
+	Keyword string `json:"keyword" example:"minimum"`
+	// Описание нарушения
+	Message string `json:"message" example:"must be >= 1"`
+} // @name SchemaViolation
+
+// @description Результат проверки пасты по JSON схеме.
+type SchemaValidationResponse struct {
+	// Соответствует ли паста схеме
+	Valid bool `json:"valid" example:"false"`
+	// Нарушения схемы
+	Violations []SchemaViolationResponse `json:"violations"`
+} // @name SchemaValidation
@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+// SearchQuery is a full-text search of pastes by titles and texts.
+type SearchQuery struct {
+	// Query is a search query in the web search syntax: quoted phrases, or and -excluded words.
+	Query string
+	// Format, Owner and the creation date range filter found pastes, empty values match all.
+	Format string
+	Owner  string
+	From   time.Time
+	To     time.Time
+	// UserID is an id of the searching user, whose unlisted and private pastes are found too.
+	UserID string
+	// After is a cursor of the last result of the previous page, nil for the first page.
+	After *SearchCursor
+	// Limit is a max number of results of the page.
+	Limit int
+}
+
+// SearchCursor is a position of a result in results ordered by rank and hash.
+type SearchCursor struct {
+	Rank float32
+	Hash string
+}
+
+// SearchResult is a paste found by a search.
+type SearchResult struct {
+	// Paste is the paste metadata.
+	Paste *Paste
+	// Rank is a relevance of the paste to the query.
+	Rank float32
+	// Snippet is a fragment of the paste text with matches.
+	Snippet string
+	// Highlights are [start, end) rune offsets of matches in the snippet.
+	Highlights [][2]int
+}
+
+// SearchPage is a page of search results.
+type SearchPage struct {
+	Results []*SearchResult
+	// Next is a cursor of the next page, nil for the last page.
+	Next *SearchCursor
+}
+
+// @description Найденная паста.
+type SearchResultResponse struct {
+	PasteMetaResponse
+	// Релевантность пасты запросу
+	Rank float32 `json:"rank" example:"0.1"`
+	// Фрагмент текста пасты с совпадениями
+	Snippet string `json:"snippet" example:"services: web: image: nginx"`
+	// Позиции совпадений во фрагменте в символах, начало включительно и конец не включительно
+	Highlights [][2]int `json:"highlights"`
+} // @name SearchResult
+
+// @description Страница результатов поиска.
+type SearchPageResponse struct {
+	// Найденные пасты по убыванию релевантности
+	Results []*SearchResultResponse `json:"results"`
+	// Курсор следующей страницы, не указывается для последней страницы
+	Next string `json:"next,omitempty" example:"MC4xOkhyRVFhRXZz"`
+} // @name SearchPage
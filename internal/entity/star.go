@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// StarListQuery is a listing of pastes starred by a user, newest stars first.
+type StarListQuery struct {
+	UserID string
+	// After is a cursor of the last paste of the previous page, nil for the first page.
+	After *PasteListCursor
+	// Limit is a max number of pastes of the page.
+	Limit int
+}
+
+// StarredPaste is a paste starred by a user.
+type StarredPaste struct {
+	Paste     *Paste
+	StarredAt time.Time
+}
+
+// StarsPage is a page of starred pastes.
+type StarsPage struct {
+	Pastes []*StarredPaste
+	// Next is a cursor of the next page, nil for the last page.
+	Next *PasteListCursor
+}
+
+// @description Паста в избранном пользователя.
+type StarredPasteResponse struct {
+	PasteMetaResponse
+	// Количество звёзд
+	Stars int `json:"stars" example:"3"`
+	// Дата добавления в избранное
+	StarredAt string `json:"starred_at" example:"Sun, 29 Oct 2023 20:38:41 +08"`
+} // @name StarredPaste
+
+// @description Страница избранных паст.
+type StarredPastesPageResponse struct {
+	// Избранные пасты
+	Pastes []*StarredPasteResponse `json:"pastes"`
+	// Курсор следующей страницы, не указывается для последней страницы
+	Next string `json:"next,omitempty" example:"c3RhcnJlZDpIckVRYUV2czoyMDIzLTEwLTI5VDIwOjM4OjQxKzA4OjAw"`
+} // @name StarredPastesPage
@@ -0,0 +1,37 @@
+package entity
+
+// TagQuery is a listing of pastes by tags visible to the user.
+type TagQuery struct {
+	// UserID is the user listing pastes, empty for anonymous users.
+	UserID string
+	// Tags filters pastes by tags, empty tags match all.
+	Tags []string
+	// Any lists pastes with any of the tags, otherwise pastes must have all of them.
+	Any bool
+	// After is a cursor of the last paste of the previous page, nil for the first page.
+	After *PasteListCursor
+	// Limit is a max number of pastes of the page.
+	Limit int
+}
+
+// TagCount is a number of pastes with the tag.
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// @description Тег и число паст с ним.
+type TagCountResponse struct {
+	// Тег
+	Tag string `json:"tag" example:"go"`
+	// Число паст с тегом
+	Count int `json:"count" example:"3"`
+} // @name TagCount
+
+// @description Страница паст с тегами.
+type TaggedPastesPageResponse struct {
+	// Пасты
+	Pastes []*PasteMetaResponse `json:"pastes"`
+	// Курсор следующей страницы, не указывается для последней страницы
+	Next string `json:"next,omitempty" example:"Y3JlYXRlZDpIckVRYUV2czoyMDIzLTEwLTI5VDIwOjM4OjQxKzA4OjAw"`
+} // @name TaggedPastesPage
@@ -37,7 +37,14 @@ func (t *AccessToken) GenerateFrom(token []byte) error {
 }
 
 func (t AccessToken) Matches(token []byte) bool {
-	return bcrypt.CompareHashAndPassword(t, token) != nil
+	return bcrypt.CompareHashAndPassword(t, token) == nil
+}
+
+// CachedToken is an access token checked against the stored one.
+type CachedToken struct {
+	Email string
+	// Stored is the stored access token the token matched.
+	Stored AccessToken
 }
 
 // @description Payload for creating a new user if not exists and get access token.
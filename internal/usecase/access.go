@@ -0,0 +1,119 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+var _ PasteAccess = (*PasteAccessChecker)(nil)
+
+// PasteAccessChecker checks that the user from context can read pastes,
+// it is shared by use cases working with pastes of other users.
+type PasteAccessChecker struct {
+	repo   PastesRepo
+	grants PasteGrantsRepo
+}
+
+func NewPasteAccess(r PastesRepo, g PasteGrantsRepo) *PasteAccessChecker {
+	return &PasteAccessChecker{
+		repo:   r,
+		grants: g,
+	}
+}
+
+// Check checks that the user from context can read the paste.
+// Private pastes are readable only by their authors and users granted access,
+// for others they do not exist, so returns ErrPasteNotFound. Grants are checked
+// on every read, so revoked access is not kept by cached pastes.
+func (a *PasteAccessChecker) Check(ctx context.Context, paste *entity.Paste) error {
+	if paste.Visibility != entity.VisibilityPrivate {
+		return nil
+	}
+
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return ErrPasteNotFound
+	}
+
+	if paste.UserID.String == userID {
+		return nil
+	}
+
+	granted, err := a.grants.Exists(ctx, paste.Hash, userID)
+	if err != nil {
+		return err
+	}
+
+	if !granted {
+		return ErrPasteNotFound
+	}
+
+	return nil
+}
+
+// Readable returns a paste metadata from database if the user from context can read
+// the paste as in Check. The password of the paste is not checked.
+// If the paste is expired returns ErrPasteExpired.
+func (a *PasteAccessChecker) Readable(ctx context.Context, hash string) (*entity.Paste, error) {
+	paste, err := a.repo.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrPasteNotFound
+		}
+
+		return nil, err
+	}
+
+	if err := a.Check(ctx, paste); err != nil {
+		return nil, err
+	}
+
+	if paste.Expired() {
+		return nil, ErrPasteExpired
+	}
+
+	return paste, nil
+}
+
+// Meta returns a paste metadata from database for operations which do not read
+// the paste as Get does, so they neither count views nor burn the paste.
+// The paste must be readable as in Readable. If the paste is locked with other
+// password returns ErrPasteLocked, the author of the paste needs no password.
+func (a *PasteAccessChecker) Meta(ctx context.Context, hash, password string) (*entity.Paste, error) {
+	paste, err := a.Readable(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	if paste.Password.Hash != nil && !paste.Password.Matches(password) && !authored(ctx, paste) {
+		return nil, ErrPasteLocked
+	}
+
+	return paste, nil
+}
+
+// Text returns a paste metadata from database for operations which read the paste
+// text without counting views, as Meta. Pastes which must be burned after read or have
+// a views limit are readable only by Get and Unlock, which count views, so for users other
+// than the author of the paste returns ErrPasteLocked.
+func (a *PasteAccessChecker) Text(ctx context.Context, hash, password string) (*entity.Paste, error) {
+	paste, err := a.Meta(ctx, hash, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if (paste.BurnAfterRead || paste.MaxViews > 0) && !authored(ctx, paste) {
+		return nil, ErrPasteLocked
+	}
+
+	return paste, nil
+}
+
+// authored reports whether the user from context is the author of the paste.
+func authored(ctx context.Context, paste *entity.Paste) bool {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+
+	return ok && paste.UserID.Valid && paste.UserID.String == userID
+}
@@ -1,23 +1,27 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 
 	"github.com/romankravchuk/pastebin/internal/entity"
+	"golang.org/x/oauth2"
 )
 
 var _ Auth = &AuthUseCase{}
 
 type AuthUseCase struct {
-	users UsersRepo
-	oauth AuthWebAPI
+	users  UsersRepo
+	oauth  AuthWebAPI
+	tokens AuthTokensCache
 }
 
-func NewAuth(users UsersRepo, oauth AuthWebAPI) *AuthUseCase {
+func NewAuth(users UsersRepo, oauth AuthWebAPI, tokens AuthTokensCache) *AuthUseCase {
 	return &AuthUseCase{
-		oauth: oauth,
-		users: users,
+		oauth:  oauth,
+		users:  users,
+		tokens: tokens,
 	}
 }
 
@@ -75,3 +79,55 @@ func (uc *AuthUseCase) CreateUser(ctx context.Context, req entity.CreateTokenReq
 
 	return user, nil
 }
+
+// Authenticate returns the user which owns the access token.
+//
+// The user info is requested from oauth provider and the token is compared
+// with the stored one. If they do not match returns ErrInvalidToken.
+// Checked tokens are cached with the email of the user and the stored token,
+// so the oauth provider is requested and the token is compared only once per token
+// for the cache lifetime. A cached token is checked again if the stored token has changed.
+func (uc *AuthUseCase) Authenticate(ctx context.Context, token string) (*entity.User, error) {
+	cached, ok, err := uc.tokens.Get(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cached token: %w", err)
+	}
+
+	var email string
+
+	if ok {
+		email = cached.Email
+	} else {
+		apiUser, err := uc.oauth.GetUserInfo(ctx, &oauth2.Token{AccessToken: token, TokenType: "Bearer"})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get user info: %w", err)
+		}
+
+		email = apiUser.Email
+	}
+
+	user, err := uc.users.GetByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user by email %q: %w", email, err)
+	}
+
+	if ok && !bytes.Equal(cached.Stored, user.AccessToken) {
+		if err := uc.tokens.Delete(ctx, token); err != nil {
+			return nil, fmt.Errorf("failed to delete cached token: %w", err)
+		}
+
+		ok = false
+	}
+
+	if !ok {
+		if !user.AccessToken.Matches([]byte(token)) {
+			return nil, ErrInvalidToken
+		}
+
+		if err := uc.tokens.Set(ctx, token, &entity.CachedToken{Email: email, Stored: user.AccessToken}); err != nil {
+			return nil, fmt.Errorf("failed to cache token: %w", err)
+		}
+	}
+
+	return user, nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type authMocks struct {
+	users  *mocks.UsersRepo
+	oauth  *mocks.AuthWebAPI
+	tokens *mocks.AuthTokensCache
+}
+
+func newAuthUseCase(t *testing.T) (*AuthUseCase, *authMocks) {
+	t.Helper()
+
+	m := &authMocks{
+		users:  mocks.NewUsersRepo(t),
+		oauth:  mocks.NewAuthWebAPI(t),
+		tokens: mocks.NewAuthTokensCache(t),
+	}
+
+	return NewAuth(m.users, m.oauth, m.tokens), m
+}
+
+func TestAuthUseCase_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	user := &entity.User{ID: "user", Email: "user@example.com"}
+	require.NoError(t, user.AccessToken.GenerateFrom([]byte("token")))
+
+	t.Run("Authenticate and cache token", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newAuthUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.tokens.On("Get", ctx, "token").
+			Once().
+			Return(nil, false, nil)
+		m.oauth.On("GetUserInfo", ctx, mock.Anything).
+			Once().
+			Return(&entity.APIUser{Email: user.Email}, nil)
+		m.users.On("GetByEmail", ctx, user.Email).
+			Once().
+			Return(user, nil)
+		m.tokens.On("Set", ctx, "token", &entity.CachedToken{Email: user.Email, Stored: user.AccessToken}).
+			Once().
+			Return(nil)
+
+		got, err := uc.Authenticate(ctx, "token")
+		require.NoError(t, err)
+		require.Equal(t, user, got)
+	})
+
+	t.Run("Authenticate cached token", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newAuthUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.tokens.On("Get", ctx, "cached").
+			Once().
+			Return(&entity.CachedToken{Email: user.Email, Stored: user.AccessToken}, true, nil)
+		m.users.On("GetByEmail", ctx, user.Email).
+			Once().
+			Return(user, nil)
+
+		// The cached token is not compared with the stored one again.
+		got, err := uc.Authenticate(ctx, "cached")
+		require.NoError(t, err)
+		require.Equal(t, user, got)
+	})
+
+	t.Run("Get error on cached token after stored token changed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newAuthUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.tokens.On("Get", ctx, "old").
+			Once().
+			Return(&entity.CachedToken{Email: user.Email, Stored: entity.AccessToken("old")}, true, nil)
+		m.users.On("GetByEmail", ctx, user.Email).
+			Once().
+			Return(user, nil)
+		m.tokens.On("Delete", ctx, "old").
+			Once().
+			Return(nil)
+
+		_, err := uc.Authenticate(ctx, "old")
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("Get error on token of other user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newAuthUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.tokens.On("Get", ctx, "other").
+			Once().
+			Return(nil, false, nil)
+		m.oauth.On("GetUserInfo", ctx, mock.Anything).
+			Once().
+			Return(&entity.APIUser{Email: user.Email}, nil)
+		m.users.On("GetByEmail", ctx, user.Email).
+			Once().
+			Return(user, nil)
+
+		_, err := uc.Authenticate(ctx, "other")
+		require.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
@@ -44,7 +44,7 @@ func (bs *PastesBlobStorage) Create(ctx context.Context, p *entity.Paste) error
 	return nil
 }
 
-// Delete deletes a file from obj storage.
+// Delete deletes a file and all its revisions from obj storage.
 func (bs *PastesBlobStorage) Delete(ctx context.Context, userID, id string) error {
 	if userID == "" {
 		userID = public
@@ -54,38 +54,142 @@ func (bs *PastesBlobStorage) Delete(ctx context.Context, userID, id string) erro
 		return fmt.Errorf("PastesBlobStorage.Delete: %w", err)
 	}
 
+	if err := bs.m.DeleteObjects(ctx, userID, id+"/"); err != nil {
+		return fmt.Errorf("PastesBlobStorage.Delete: %w", err)
+	}
+
 	return nil
 }
 
 // Get returns a file from obj storage.
 func (bs *PastesBlobStorage) Get(ctx context.Context, userID, id string) (entity.File, error) {
+	data, err := bs.get(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("PastesBlobStorage.Get: %w", err)
+	}
+
+	return data, nil
+}
+
+// CreateRevision uploads the paste file as revision p.Revision.
+//
+// The revision is stored next to the paste under {hash}/{revision} object name.
+// Files of a multi-file paste, except the first one which is the paste text,
+// are stored under {hash}/{revision}/files/{name} object names.
+func (bs *PastesBlobStorage) CreateRevision(ctx context.Context, p *entity.Paste) error {
+	bucket := public
+	if p.UserID.Valid {
+		bucket = p.UserID.String
+	}
+
+	err := bs.m.UploadObject(ctx, bucket, revisionObject(p.Hash, p.Revision), p.File.Size(), bytes.NewReader(p.File))
+	if err != nil {
+		return fmt.Errorf("PastesBlobStorage.CreateRevision: %w", err)
+	}
+
+	for i := 1; i < len(p.Files); i++ {
+		f := p.Files[i]
+
+		object := fileObject(revisionObject(p.Hash, p.Revision), f.Name)
+
+		if err := bs.m.UploadObject(ctx, bucket, object, f.File.Size(), bytes.NewReader(f.File)); err != nil {
+			return fmt.Errorf("PastesBlobStorage.CreateRevision: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetRevision returns a file of the paste revision from obj storage.
+func (bs *PastesBlobStorage) GetRevision(ctx context.Context, userID, id string, revision int) (entity.File, error) {
+	data, err := bs.get(ctx, userID, revisionObject(id, revision))
+	if err != nil {
+		return nil, fmt.Errorf("PastesBlobStorage.GetRevision: %w", err)
+	}
+
+	return data, nil
+}
+
+// GetRevisionFile returns a file of the multi-file paste revision from obj storage.
+func (bs *PastesBlobStorage) GetRevisionFile(ctx context.Context, userID, id string, revision int, name string) (entity.File, error) {
+	data, err := bs.get(ctx, userID, fileObject(revisionObject(id, revision), name))
+	if err != nil {
+		return nil, fmt.Errorf("PastesBlobStorage.GetRevisionFile: %w", err)
+	}
+
+	return data, nil
+}
+
+func (bs *PastesBlobStorage) get(ctx context.Context, userID, object string) (entity.File, error) {
 	if userID == "" {
 		userID = public
 	}
 
-	obj, err := bs.m.GetObject(ctx, userID, id)
+	obj, err := bs.m.GetObject(ctx, userID, object)
 	if err != nil {
-		return nil, fmt.Errorf("PastesBlobStorage.Get: %w", err)
+		return nil, err
 	}
 
 	objInfo, err := obj.Stat()
 	if err != nil {
-		return nil, fmt.Errorf("PastesBlobStorage.Get: %w", err)
+		return nil, err
 	}
 
 	data := make(entity.File, objInfo.Size)
 
-	_, err = obj.Read(data)
+	_, err = io.ReadFull(obj, data)
 	if err != nil && !errors.Is(err, io.EOF) {
-		return nil, fmt.Errorf("PastesBlobStorage.Get: %w", err)
+		return nil, err
+	}
+
+	return data, nil
+}
+
+func revisionObject(id string, revision int) string {
+	return fmt.Sprintf("%s/%d", id, revision)
+}
+
+// CreateFile uploads a file of the multi-file paste.
+//
+// The file is stored next to the paste under {hash}/files/{name} object name.
+func (bs *PastesBlobStorage) CreateFile(ctx context.Context, p *entity.Paste, f *entity.PasteFile) error {
+	bucket := public
+	if p.UserID.Valid {
+		bucket = p.UserID.String
+	}
+
+	err := bs.m.UploadObject(ctx, bucket, fileObject(p.Hash, f.Name), f.File.Size(), bytes.NewReader(f.File))
+	if err != nil {
+		return fmt.Errorf("PastesBlobStorage.CreateFile: %w", err)
+	}
+
+	return nil
+}
+
+// GetFile returns a file of the multi-file paste from obj storage.
+func (bs *PastesBlobStorage) GetFile(ctx context.Context, userID, id, name string) (entity.File, error) {
+	data, err := bs.get(ctx, userID, fileObject(id, name))
+	if err != nil {
+		return nil, fmt.Errorf("PastesBlobStorage.GetFile: %w", err)
 	}
 
 	return data, nil
 }
 
-// Update updates a file in obj storage.
+func fileObject(id, name string) string {
+	return id + "/files/" + name
+}
+
+// Update rewrites a file in obj storage.
+//
+// The bucket is resolved the same way as in Create.
 func (bs *PastesBlobStorage) Update(ctx context.Context, p *entity.Paste) error {
-	err := bs.m.UploadObject(ctx, p.UserID.String, p.Hash, p.File.Size(), bytes.NewReader(p.File))
+	bucket := public
+	if p.UserID.Valid {
+		bucket = p.UserID.String
+	}
+
+	err := bs.m.UploadObject(ctx, bucket, p.Hash, p.File.Size(), bytes.NewReader(p.File))
 	if err != nil {
 		return fmt.Errorf("PastesBlobStorage.Update: %w", err)
 	}
@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	rds "github.com/romankravchuk/pastebin/pkg/redis"
+)
+
+const (
+	feedPrefix = "feed:"
+	// feedRecent is a key of the feed of recent pastes of all users.
+	feedRecent = feedPrefix + "recent"
+	// feedTTL bounds how long expired pastes stay in cached feeds,
+	// as feeds are invalidated only by changes of pastes.
+	feedTTL = 5 * time.Minute
+)
+
+var _ usecase.PastesFeedsCache = &PastesFeedsCache{}
+
+// PastesFeedsCache keeps feeds of recent public pastes, the feed of all users
+// is kept under the empty user id.
+type PastesFeedsCache struct {
+	rd *rds.Redis
+}
+
+func NewPastesFeedsCache(rd *rds.Redis) *PastesFeedsCache {
+	return &PastesFeedsCache{rd: rd}
+}
+
+// Get returns the feed of the user.
+func (c *PastesFeedsCache) Get(ctx context.Context, userID string) (*entity.Feed, bool, error) {
+	raw, err := c.rd.Client.Get(ctx, feedKey(userID)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("PastesFeedsCache.Redis.Client: %w", err)
+	}
+
+	feed := new(entity.Feed)
+	if err := json.Unmarshal(raw, feed); err != nil {
+		return nil, false, fmt.Errorf("PastesFeedsCache.Unmarshal: %w", err)
+	}
+
+	return feed, true, nil
+}
+
+// Set stores the feed of the user for feedTTL.
+func (c *PastesFeedsCache) Set(ctx context.Context, userID string, feed *entity.Feed) error {
+	raw, err := json.Marshal(feed)
+	if err != nil {
+		return fmt.Errorf("PastesFeedsCache.Marshal: %w", err)
+	}
+
+	if err := c.rd.Client.Set(ctx, feedKey(userID), raw, feedTTL).Err(); err != nil {
+		return fmt.Errorf("PastesFeedsCache.Redis.Client: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the feed of the user.
+func (c *PastesFeedsCache) Delete(ctx context.Context, userID string) error {
+	if err := c.rd.Client.Del(ctx, feedKey(userID)).Err(); err != nil {
+		return fmt.Errorf("PastesFeedsCache.Redis.Client: %w", err)
+	}
+
+	return nil
+}
+
+func feedKey(userID string) string {
+	if userID == "" {
+		return feedRecent
+	}
+
+	return feedPrefix + "user:" + userID
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	rds "github.com/romankravchuk/pastebin/pkg/redis"
+)
+
+const (
+	lockPrefix  = "lock:"
+	tokenLength = 16
+)
+
+// releaseLock deletes the lock only if it is held by the token.
+var releaseLock = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+var _ usecase.Locker = &Locker{}
+
+// Locker is a distributed lock shared by all instances of the service.
+type Locker struct {
+	rd    *rds.Redis
+	token string
+}
+
+func NewLocker(rd *rds.Redis) (*Locker, error) {
+	b := make([]byte, tokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("Locker.Token: %w", err)
+	}
+
+	return &Locker{rd: rd, token: hex.EncodeToString(b)}, nil
+}
+
+// Acquire takes the lock for ttl. Returns false if the lock is held by another instance.
+func (l *Locker) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := l.rd.Client.SetNX(ctx, lockPrefix+key, l.token, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("Locker.Redis.Client: %w", err)
+	}
+
+	return ok, nil
+}
+
+// Release frees the lock if it is held by this instance.
+func (l *Locker) Release(ctx context.Context, key string) error {
+	if err := releaseLock.Run(ctx, l.rd.Client, []string{lockPrefix + key}, l.token).Err(); err != nil {
+		return fmt.Errorf("Locker.Redis.Client: %w", err)
+	}
+
+	return nil
+}
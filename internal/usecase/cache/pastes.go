@@ -35,9 +35,13 @@ func (c *PastesCache) Create(ctx context.Context, paste *entity.Paste) error {
 	return nil
 }
 
-// Delete implements usecase.PastesCache.
-func (*PastesCache) Delete(context.Context, string) error {
-	panic("unimplemented")
+// Delete removes paste cache from redis.
+func (c *PastesCache) Delete(ctx context.Context, hash string) error {
+	if err := c.rd.Client.Del(ctx, hash).Err(); err != nil {
+		return fmt.Errorf("PastesCache.Redis.Client: %w", err)
+	}
+
+	return nil
 }
 
 // Get returns paste from redis.
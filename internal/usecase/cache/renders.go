@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	rds "github.com/romankravchuk/pastebin/pkg/redis"
+)
+
+const (
+	renderPrefix = "render:"
+	renderTTL    = 24 * time.Hour
+)
+
+var _ usecase.PastesRendersCache = &PastesRendersCache{}
+
+// PastesRendersCache keeps rendered pastes in a redis hash per paste,
+// so all renders of a paste are invalidated at once.
+type PastesRendersCache struct {
+	rd *rds.Redis
+}
+
+func NewPastesRendersCache(rd *rds.Redis) *PastesRendersCache {
+	return &PastesRendersCache{rd: rd}
+}
+
+// Get returns the paste rendered with options identified by key.
+func (c *PastesRendersCache) Get(ctx context.Context, hash, key string) ([]byte, bool, error) {
+	raw, err := c.rd.Client.HGet(ctx, renderPrefix+hash, key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, false, nil
+		}
+
+		return nil, false, fmt.Errorf("PastesRendersCache.Redis.Client: %w", err)
+	}
+
+	return raw, true, nil
+}
+
+// Set stores the rendered paste. Renders of a paste expire together after renderTTL.
+func (c *PastesRendersCache) Set(ctx context.Context, hash, key string, render []byte) error {
+	_, err := c.rd.Client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, renderPrefix+hash, key, render)
+		pipe.Expire(ctx, renderPrefix+hash, renderTTL)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("PastesRendersCache.Redis.Client: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes all renders of the paste.
+func (c *PastesRendersCache) Delete(ctx context.Context, hash string) error {
+	if err := c.rd.Client.Del(ctx, renderPrefix+hash).Err(); err != nil {
+		return fmt.Errorf("PastesRendersCache.Redis.Client: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	rds "github.com/romankravchuk/pastebin/pkg/redis"
+)
+
+const (
+	starsPrefix = "stars:"
+	// starsDirty is a set of paste hashes with counters not reconciled with database.
+	starsDirty = "stars:dirty"
+	starsTTL   = 24 * time.Hour
+)
+
+// incrStars initializes the counter from database value if it is missing and
+// adds the delta to it, the counter never goes below zero.
+var incrStars = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'NX')
+local n = redis.call('INCRBY', KEYS[1], ARGV[2])
+if n < 0 then
+	n = 0
+	redis.call('SET', KEYS[1], 0)
+end
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('SADD', KEYS[2], ARGV[3])
+return n
+`)
+
+var _ usecase.PasteStarsCounter = &PasteStarsCounter{}
+
+// PasteStarsCounter keeps numbers of paste stars between reconciliations.
+//
+// Stars of users stored in database are the source of truth, they make starring
+// idempotent. The counter only serves the counts and marks pastes to reconcile,
+// reconciliation recounts stars in database and overwrites the counter.
+type PasteStarsCounter struct {
+	rd *rds.Redis
+}
+
+func NewPasteStarsCounter(rd *rds.Redis) *PasteStarsCounter {
+	return &PasteStarsCounter{rd: rd}
+}
+
+// Incr adds the delta to the paste stars counter in redis and returns a new value.
+// The counter starts from stars stored in database.
+func (c *PasteStarsCounter) Incr(ctx context.Context, hash string, stars, delta int) (int, error) {
+	keys := []string{starsPrefix + hash, starsDirty}
+
+	n, err := incrStars.Run(ctx, c.rd.Client, keys, stars, delta, hash, int(starsTTL.Seconds())).Int()
+	if err != nil {
+		return 0, fmt.Errorf("PasteStarsCounter.Redis.Client: %w", err)
+	}
+
+	return n, nil
+}
+
+// Get returns the paste stars counter, false if it is not in redis.
+func (c *PasteStarsCounter) Get(ctx context.Context, hash string) (int, bool, error) {
+	n, err := c.rd.Client.Get(ctx, starsPrefix+hash).Int()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return 0, false, nil
+		}
+
+		return 0, false, fmt.Errorf("PasteStarsCounter.Redis.Client: %w", err)
+	}
+
+	return n, true, nil
+}
+
+// Pending pops at most limit hashes of pastes with counters not reconciled with database.
+func (c *PasteStarsCounter) Pending(ctx context.Context, limit int) ([]string, error) {
+	hashes, err := c.rd.Client.SPopN(ctx, starsDirty, int64(limit)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("PasteStarsCounter.Redis.Client: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// Set overwrites paste stars counters with values reconciled with database.
+func (c *PasteStarsCounter) Set(ctx context.Context, stars map[string]int) error {
+	if len(stars) == 0 {
+		return nil
+	}
+
+	_, err := c.rd.Client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		for hash, n := range stars {
+			p.Set(ctx, starsPrefix+hash, n, starsTTL)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("PasteStarsCounter.Redis.Client: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the paste stars counter from redis.
+func (c *PasteStarsCounter) Delete(ctx context.Context, hash string) error {
+	_, err := c.rd.Client.Pipelined(ctx, func(p redis.Pipeliner) error {
+		p.Del(ctx, starsPrefix+hash)
+		p.SRem(ctx, starsDirty, hash)
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("PasteStarsCounter.Redis.Client: %w", err)
+	}
+
+	return nil
+}
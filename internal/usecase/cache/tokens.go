@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	rds "github.com/romankravchuk/pastebin/pkg/redis"
+)
+
+const (
+	tokenPrefix = "token:"
+	// tokenTTL bounds how long a token revoked by the oauth provider is still accepted.
+	tokenTTL = 5 * time.Minute
+)
+
+var _ usecase.AuthTokensCache = &AuthTokensCache{}
+
+// AuthTokensCache keeps emails of users of access tokens checked by the oauth provider.
+// Tokens are kept as SHA-256 digests.
+type AuthTokensCache struct {
+	rd *rds.Redis
+}
+
+func NewAuthTokensCache(rd *rds.Redis) *AuthTokensCache {
+	return &AuthTokensCache{rd: rd}
+}
+
+// Get returns the email of the user of the access token and the stored token it matched.
+func (c *AuthTokensCache) Get(ctx context.Context, token string) (*entity.CachedToken, bool, error) {
+	fields, err := c.rd.Client.HGetAll(ctx, tokenKey(token)).Result()
+	if err != nil {
+		return nil, false, fmt.Errorf("AuthTokensCache.Redis.Client: %w", err)
+	}
+
+	if len(fields) == 0 {
+		return nil, false, nil
+	}
+
+	return &entity.CachedToken{Email: fields["email"], Stored: entity.AccessToken(fields["stored"])}, true, nil
+}
+
+// Set keeps the email of the user of the access token and the stored token it matched for tokenTTL.
+func (c *AuthTokensCache) Set(ctx context.Context, token string, cached *entity.CachedToken) error {
+	key := tokenKey(token)
+
+	_, err := c.rd.Client.TxPipelined(ctx, func(p redis.Pipeliner) error {
+		p.HSet(ctx, key, "email", cached.Email, "stored", []byte(cached.Stored))
+		p.Expire(ctx, key, tokenTTL)
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("AuthTokensCache.Redis.Client: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the access token from the cache.
+func (c *AuthTokensCache) Delete(ctx context.Context, token string) error {
+	err := c.rd.Client.Del(ctx, tokenKey(token)).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("AuthTokensCache.Redis.Client: %w", err)
+	}
+
+	return nil
+}
+
+func tokenKey(token string) string {
+	digest := sha256.Sum256([]byte(token))
+
+	return tokenPrefix + hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	rds "github.com/romankravchuk/pastebin/pkg/redis"
+)
+
+const (
+	viewsPrefix = "views:"
+	// viewsDirty is a set of paste hashes with counters not flushed to database.
+	viewsDirty = "views:dirty"
+	viewsTTL   = 24 * time.Hour
+)
+
+// incrViews initializes the counter from database value if it is missing and
+// increments it unless the limit is reached. Returns -1 if the limit is reached.
+var incrViews = redis.NewScript(`
+redis.call('SET', KEYS[1], ARGV[1], 'NX')
+local max = tonumber(ARGV[2])
+local n = tonumber(redis.call('GET', KEYS[1]))
+if max > 0 and n >= max then
+	return -1
+end
+n = redis.call('INCR', KEYS[1])
+redis.call('EXPIRE', KEYS[1], ARGV[4])
+redis.call('SADD', KEYS[2], ARGV[3])
+return n
+`)
+
+// flushedViews removes pastes from the dirty set unless their counters changed
+// since the flushed values were read.
+var flushedViews = redis.NewScript(`
+for i, hash in ipairs(ARGV) do
+	if i % 2 == 1 then
+		local n = redis.call('GET', KEYS[1] .. hash)
+		if not n or n == ARGV[i + 1] then
+			redis.call('SREM', KEYS[2], hash)
+		end
+	end
+end
+return 0
+`)
+
+var _ usecase.PasteViewsCounter = &PasteViewsCounter{}
+
+type PasteViewsCounter struct {
+	rd *rds.Redis
+}
+
+func NewPasteViewsCounter(rd *rds.Redis) *PasteViewsCounter {
+	return &PasteViewsCounter{rd: rd}
+}
+
+// Incr increments the paste views counter in redis and returns a new value.
+//
+// The counter starts from views stored in database. If maxViews is positive and
+// the counter reached it, the counter is not incremented and -1 is returned.
+func (c *PasteViewsCounter) Incr(ctx context.Context, hash string, views, maxViews int) (int, error) {
+	keys := []string{viewsPrefix + hash, viewsDirty}
+
+	n, err := incrViews.Run(ctx, c.rd.Client, keys, views, maxViews, hash, int(viewsTTL.Seconds())).Int()
+	if err != nil {
+		return 0, fmt.Errorf("PasteViewsCounter.Redis.Client: %w", err)
+	}
+
+	return n, nil
+}
+
+// Pending returns views of at most limit pastes with not flushed counters.
+// Pastes are left in the dirty set until Flushed, pastes with expired counters are removed from it.
+func (c *PasteViewsCounter) Pending(ctx context.Context, limit int) (map[string]int, error) {
+	hashes, err := c.rd.Client.SRandMemberN(ctx, viewsDirty, int64(limit)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("PasteViewsCounter.Redis.Client: %w", err)
+	}
+
+	views := make(map[string]int, len(hashes))
+	if len(hashes) == 0 {
+		return views, nil
+	}
+
+	keys := make([]string, 0, len(hashes))
+	for _, hash := range hashes {
+		keys = append(keys, viewsPrefix+hash)
+	}
+
+	values, err := c.rd.Client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("PasteViewsCounter.Redis.Client: %w", err)
+	}
+
+	expired := make([]any, 0)
+
+	for i, v := range values {
+		raw, ok := v.(string)
+		if !ok {
+			expired = append(expired, strings.TrimPrefix(keys[i], viewsPrefix))
+
+			continue
+		}
+
+		var n int
+		if _, err := fmt.Sscan(raw, &n); err != nil {
+			return nil, fmt.Errorf("PasteViewsCounter.Sscan: %w", err)
+		}
+
+		views[strings.TrimPrefix(keys[i], viewsPrefix)] = n
+	}
+
+	if len(expired) > 0 {
+		if err := c.rd.Client.SRem(ctx, viewsDirty, expired...).Err(); err != nil {
+			return nil, fmt.Errorf("PasteViewsCounter.Redis.Client: %w", err)
+		}
+	}
+
+	return views, nil
+}
+
+// Flushed removes pastes with views stored in database from the dirty set.
+// Pastes viewed again since Pending stay in the set to be flushed later.
+func (c *PasteViewsCounter) Flushed(ctx context.Context, views map[string]int) error {
+	if len(views) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, 2*len(views))
+	for hash, n := range views {
+		args = append(args, hash, strconv.Itoa(n))
+	}
+
+	err := flushedViews.Run(ctx, c.rd.Client, []string{viewsPrefix, viewsDirty}, args...).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("PasteViewsCounter.Redis.Client: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the paste views counter from redis.
+func (c *PasteViewsCounter) Delete(ctx context.Context, hash string) error {
+	err := c.rd.Client.Del(ctx, viewsPrefix+hash).Err()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("PasteViewsCounter.Redis.Client: %w", err)
+	}
+
+	return nil
+}
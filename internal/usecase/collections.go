@@ -0,0 +1,330 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+// maxCollectionPastes is a max number of pastes in a collection.
+const maxCollectionPastes = 100
+
+var _ Collections = (*CollectionsUseCase)(nil)
+
+type CollectionsUseCase struct {
+	repo   CollectionsRepo
+	pastes PasteAccess
+}
+
+func NewCollections(r CollectionsRepo, a PasteAccess) *CollectionsUseCase {
+	return &CollectionsUseCase{
+		repo:   r,
+		pastes: a,
+	}
+}
+
+// Create creates a new collection of the user from context with the pastes in order.
+//
+// Only authenticated users can create collections, otherwise returns ErrUnauthorized.
+// Collections are unlisted unless other visibility is set. The user must be able to
+// read every paste, otherwise returns ErrPasteNotFound, or ErrPasteExpired for expired pastes.
+// Returns the collection with metadata of its pastes.
+func (uc *CollectionsUseCase) Create(ctx context.Context, c *entity.Collection) error {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return ErrUnauthorized
+	}
+
+	c.UserID = userID
+
+	if c.Visibility == "" {
+		c.Visibility = entity.VisibilityUnlisted
+	}
+
+	if len(c.Pastes) > maxCollectionPastes {
+		return ErrCollectionFull
+	}
+
+	hashes := make([]string, 0, len(c.Pastes))
+
+	for _, p := range c.Pastes {
+		if err := uc.readable(ctx, p.Hash); err != nil {
+			return fmt.Errorf("CollectionsUseCase.Create: %w", err)
+		}
+
+		hashes = append(hashes, p.Hash)
+	}
+
+	if err := uc.repo.Create(ctx, c); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Create: %w", err)
+	}
+
+	if err := uc.repo.AddPastes(ctx, c.Hash, hashes); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Create: %w", err)
+	}
+
+	pastes, err := uc.repo.ListPastes(ctx, c.Hash, userID)
+	if err != nil {
+		return fmt.Errorf("CollectionsUseCase.Create: %w", err)
+	}
+
+	c.Pastes = pastes
+
+	return nil
+}
+
+// Get returns a collection with metadata of its pastes in order, paste texts are not fetched.
+//
+// Private collections are readable only by their owners and users granted access,
+// for others returns ErrCollectionNotFound. Expired pastes and private pastes
+// the user can not read are left out.
+func (uc *CollectionsUseCase) Get(ctx context.Context, hash string) (*entity.Collection, error) {
+	c, err := uc.repo.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+
+		return nil, fmt.Errorf("CollectionsUseCase.Get: %w", err)
+	}
+
+	userID, _ := ctx.Value(entity.UserIDKey).(string)
+
+	if err := uc.access(ctx, c, userID); err != nil {
+		return nil, fmt.Errorf("CollectionsUseCase.Get: %w", err)
+	}
+
+	if c.Pastes, err = uc.repo.ListPastes(ctx, c.Hash, userID); err != nil {
+		return nil, fmt.Errorf("CollectionsUseCase.Get: %w", err)
+	}
+
+	return c, nil
+}
+
+// Update renames a collection or changes its visibility, empty fields are left unchanged.
+// Only the owner can update the collection, otherwise returns ErrNotCollectionOwner.
+// Returns the collection with metadata of its pastes.
+func (uc *CollectionsUseCase) Update(ctx context.Context, c *entity.Collection) error {
+	stored, err := uc.own(ctx, c.Hash)
+	if err != nil {
+		return fmt.Errorf("CollectionsUseCase.Update: %w", err)
+	}
+
+	if c.Title != "" {
+		stored.Title = c.Title
+	}
+
+	if c.Visibility != "" {
+		stored.Visibility = c.Visibility
+	}
+
+	if err := uc.repo.Update(ctx, stored); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Update: %w", err)
+	}
+
+	if stored.Pastes, err = uc.repo.ListPastes(ctx, stored.Hash, stored.UserID); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Update: %w", err)
+	}
+
+	*c = *stored
+
+	return nil
+}
+
+// Delete deletes a collection, its pastes are kept.
+// Only the owner can delete the collection, otherwise returns ErrNotCollectionOwner.
+func (uc *CollectionsUseCase) Delete(ctx context.Context, hash string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Delete: %w", err)
+	}
+
+	if err := uc.repo.Delete(ctx, hash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Delete: %w", err)
+	}
+
+	return nil
+}
+
+// AddPaste appends the paste to the end of the collection.
+//
+// Only the owner can change the collection, otherwise returns ErrNotCollectionOwner.
+// The owner must be able to read the paste as in Create. If the paste is already
+// in the collection returns ErrCollectionPasteExists, if the collection has
+// maxCollectionPastes pastes returns ErrCollectionFull.
+func (uc *CollectionsUseCase) AddPaste(ctx context.Context, hash, pasteHash string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.AddPaste: %w", err)
+	}
+
+	hashes, err := uc.repo.PasteHashes(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("CollectionsUseCase.AddPaste: %w", err)
+	}
+
+	for _, h := range hashes {
+		if h == pasteHash {
+			return ErrCollectionPasteExists
+		}
+	}
+
+	if len(hashes) >= maxCollectionPastes {
+		return ErrCollectionFull
+	}
+
+	if err := uc.readable(ctx, pasteHash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.AddPaste: %w", err)
+	}
+
+	if err := uc.repo.AddPastes(ctx, hash, []string{pasteHash}); err != nil {
+		return fmt.Errorf("CollectionsUseCase.AddPaste: %w", err)
+	}
+
+	return nil
+}
+
+// RemovePaste removes the paste from the collection, the paste itself is kept.
+// Only the owner can change the collection, otherwise returns ErrNotCollectionOwner.
+// If the paste is not in the collection returns ErrPasteNotFound.
+func (uc *CollectionsUseCase) RemovePaste(ctx context.Context, hash, pasteHash string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.RemovePaste: %w", err)
+	}
+
+	if err := uc.repo.RemovePaste(ctx, hash, pasteHash); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrPasteNotFound
+		}
+
+		return fmt.Errorf("CollectionsUseCase.RemovePaste: %w", err)
+	}
+
+	return nil
+}
+
+// Reorder sets the order of pastes of the collection.
+// Only the owner can change the collection, otherwise returns ErrNotCollectionOwner.
+// Pastes must list every paste of the collection exactly once, otherwise returns ErrInvalidOrder.
+func (uc *CollectionsUseCase) Reorder(ctx context.Context, hash string, pastes []string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Reorder: %w", err)
+	}
+
+	hashes, err := uc.repo.PasteHashes(ctx, hash)
+	if err != nil {
+		return fmt.Errorf("CollectionsUseCase.Reorder: %w", err)
+	}
+
+	if len(pastes) != len(hashes) {
+		return ErrInvalidOrder
+	}
+
+	current := make(map[string]struct{}, len(hashes))
+	for _, h := range hashes {
+		current[h] = struct{}{}
+	}
+
+	for _, p := range pastes {
+		if _, ok := current[p]; !ok {
+			return ErrInvalidOrder
+		}
+
+		delete(current, p)
+	}
+
+	if err := uc.repo.SetOrder(ctx, hash, pastes); err != nil {
+		return fmt.Errorf("CollectionsUseCase.Reorder: %w", err)
+	}
+
+	return nil
+}
+
+// GrantAccess grants the user with the username access to the private collection.
+// Grants of a collection do not grant access to its private pastes.
+//
+// Only the owner can grant access, otherwise returns ErrNotCollectionOwner.
+// If the user does not exist returns ErrUserNotFound.
+func (uc *CollectionsUseCase) GrantAccess(ctx context.Context, hash, username string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.GrantAccess: %w", err)
+	}
+
+	if err := uc.repo.CreateGrant(ctx, hash, username); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+
+		return fmt.Errorf("CollectionsUseCase.GrantAccess: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAccess revokes access of the user with the username to the private collection.
+// Only the owner can revoke access, otherwise returns ErrNotCollectionOwner.
+func (uc *CollectionsUseCase) RevokeAccess(ctx context.Context, hash, username string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("CollectionsUseCase.RevokeAccess: %w", err)
+	}
+
+	if err := uc.repo.DeleteGrant(ctx, hash, username); err != nil {
+		return fmt.Errorf("CollectionsUseCase.RevokeAccess: %w", err)
+	}
+
+	return nil
+}
+
+// own returns a collection if the user from context is its owner,
+// otherwise returns ErrNotCollectionOwner.
+func (uc *CollectionsUseCase) own(ctx context.Context, hash string) (*entity.Collection, error) {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return nil, ErrNotCollectionOwner
+	}
+
+	c, err := uc.repo.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrCollectionNotFound
+		}
+
+		return nil, err
+	}
+
+	if c.UserID != userID {
+		return nil, ErrNotCollectionOwner
+	}
+
+	return c, nil
+}
+
+// access checks that the user can read the collection, empty userID means an anonymous user.
+// Private collections do not exist for users who can not read them.
+func (uc *CollectionsUseCase) access(ctx context.Context, c *entity.Collection, userID string) error {
+	if c.Visibility != entity.VisibilityPrivate || userID != "" && c.UserID == userID {
+		return nil
+	}
+
+	if userID == "" {
+		return ErrCollectionNotFound
+	}
+
+	granted, err := uc.repo.GrantExists(ctx, c.Hash, userID)
+	if err != nil {
+		return err
+	}
+
+	if !granted {
+		return ErrCollectionNotFound
+	}
+
+	return nil
+}
+
+// readable checks that the user from context can read the paste to add it to a collection.
+func (uc *CollectionsUseCase) readable(ctx context.Context, hash string) error {
+	_, err := uc.pastes.Readable(ctx, hash)
+
+	return err
+}
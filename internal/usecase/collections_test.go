@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type collectionsMocks struct {
+	repo   *mocks.CollectionsRepo
+	pastes *mocks.PastesRepo
+	grants *mocks.PasteGrantsRepo
+}
+
+func newCollectionsUseCase(t *testing.T) (*CollectionsUseCase, *collectionsMocks) {
+	t.Helper()
+
+	m := &collectionsMocks{
+		repo:   mocks.NewCollectionsRepo(t),
+		pastes: mocks.NewPastesRepo(t),
+		grants: mocks.NewPasteGrantsRepo(t),
+	}
+
+	return NewCollections(m.repo, NewPasteAccess(m.pastes, m.grants)), m
+}
+
+func TestCollectionsUseCase_Create(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create collection with pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newCollectionsUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+			pastes = []*entity.Paste{{Hash: "first"}, {Hash: "second"}}
+			c      = &entity.Collection{Hash: "test", Title: "runbook", Pastes: pastes}
+		)
+
+		m.pastes.On("Get", ctx, "first").
+			Once().
+			Return(&entity.Paste{Hash: "first", Visibility: entity.VisibilityPublic}, nil)
+		m.pastes.On("Get", ctx, "second").
+			Once().
+			Return(&entity.Paste{Hash: "second", UserID: sql.NullString{String: "owner", Valid: true}, Visibility: entity.VisibilityPrivate}, nil)
+		m.repo.On("Create", ctx, c).
+			Once().
+			Return(nil)
+		m.repo.On("AddPastes", ctx, "test", []string{"first", "second"}).
+			Once().
+			Return(nil)
+		m.repo.On("ListPastes", ctx, "test", "owner").
+			Once().
+			Return(pastes, nil)
+
+		err := uc.Create(ctx, c)
+		require.NoError(t, err)
+		require.Equal(t, "owner", c.UserID)
+		require.Equal(t, entity.VisibilityUnlisted, c.Visibility)
+		require.Equal(t, pastes, c.Pastes)
+	})
+
+	t.Run("Create error on anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		uc, _ := newCollectionsUseCase(t)
+
+		err := uc.Create(context.Background(), &entity.Collection{Hash: "test", Title: "runbook"})
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Create error on private paste of other user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+			c     = &entity.Collection{Hash: "test", Title: "runbook", Pastes: []*entity.Paste{{Hash: "secret"}}}
+		)
+
+		m.pastes.On("Get", ctx, "secret").
+			Once().
+			Return(&entity.Paste{Hash: "secret", UserID: sql.NullString{String: "other", Valid: true}, Visibility: entity.VisibilityPrivate}, nil)
+		m.grants.On("Exists", ctx, "secret", "owner").
+			Once().
+			Return(false, nil)
+
+		err := uc.Create(ctx, c)
+		require.ErrorIs(t, err, ErrPasteNotFound)
+	})
+
+	t.Run("Create error on expired paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+			c     = &entity.Collection{Hash: "test", Title: "runbook", Pastes: []*entity.Paste{{Hash: "old"}}}
+		)
+
+		m.pastes.On("Get", ctx, "old").
+			Once().
+			Return(&entity.Paste{Hash: "old", ExpiresAt: time.Now().Add(-time.Hour)}, nil)
+
+		err := uc.Create(ctx, c)
+		require.ErrorIs(t, err, ErrPasteExpired)
+	})
+}
+
+func TestCollectionsUseCase_Get(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get collection with readable pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newCollectionsUseCase(t)
+			ctx    = context.Background()
+			c      = &entity.Collection{Hash: "test", UserID: "owner", Visibility: entity.VisibilityUnlisted}
+			pastes = []*entity.Paste{{Hash: "first"}}
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(c, nil)
+		m.repo.On("ListPastes", ctx, "test", "").
+			Once().
+			Return(pastes, nil)
+
+		got, err := uc.Get(ctx, "test")
+		require.NoError(t, err)
+		require.Equal(t, pastes, got.Pastes)
+	})
+
+	t.Run("Get private collection by granted user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "granted")
+			c     = &entity.Collection{Hash: "test", UserID: "owner", Visibility: entity.VisibilityPrivate}
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(c, nil)
+		m.repo.On("GrantExists", ctx, "test", "granted").
+			Once().
+			Return(true, nil)
+		m.repo.On("ListPastes", ctx, "test", "granted").
+			Once().
+			Return([]*entity.Paste{}, nil)
+
+		_, err := uc.Get(ctx, "test")
+		require.NoError(t, err)
+	})
+
+	t.Run("Get error on private collection of anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(&entity.Collection{Hash: "test", UserID: "owner", Visibility: entity.VisibilityPrivate}, nil)
+
+		_, err := uc.Get(ctx, "test")
+		require.ErrorIs(t, err, ErrCollectionNotFound)
+	})
+
+	t.Run("Get error on unknown collection", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.repo.On("Get", ctx, "none").
+			Once().
+			Return(nil, ErrRecordNotFound)
+
+		_, err := uc.Get(ctx, "none")
+		require.ErrorIs(t, err, ErrCollectionNotFound)
+	})
+}
+
+func TestCollectionsUseCase_Pastes(t *testing.T) {
+	t.Parallel()
+
+	owned := func() *entity.Collection {
+		return &entity.Collection{Hash: "test", UserID: "owner", Visibility: entity.VisibilityPublic}
+	}
+
+	t.Run("Add paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(owned(), nil)
+		m.repo.On("PasteHashes", ctx, "test").
+			Once().
+			Return([]string{"first"}, nil)
+		m.pastes.On("Get", ctx, "second").
+			Once().
+			Return(&entity.Paste{Hash: "second", Visibility: entity.VisibilityUnlisted}, nil)
+		m.repo.On("AddPastes", ctx, "test", []string{"second"}).
+			Once().
+			Return(nil)
+
+		err := uc.AddPaste(ctx, "test", "second")
+		require.NoError(t, err)
+	})
+
+	t.Run("Add paste error on paste in collection", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(owned(), nil)
+		m.repo.On("PasteHashes", ctx, "test").
+			Once().
+			Return([]string{"first"}, nil)
+
+		err := uc.AddPaste(ctx, "test", "first")
+		require.ErrorIs(t, err, ErrCollectionPasteExists)
+	})
+
+	t.Run("Add paste error on not owner", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "other")
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(owned(), nil)
+
+		err := uc.AddPaste(ctx, "test", "first")
+		require.ErrorIs(t, err, ErrNotCollectionOwner)
+	})
+
+	t.Run("Remove paste error on paste not in collection", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(owned(), nil)
+		m.repo.On("RemovePaste", ctx, "test", "none").
+			Once().
+			Return(ErrRecordNotFound)
+
+		err := uc.RemovePaste(ctx, "test", "none")
+		require.ErrorIs(t, err, ErrPasteNotFound)
+	})
+
+	t.Run("Reorder pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(owned(), nil)
+		m.repo.On("PasteHashes", ctx, "test").
+			Once().
+			Return([]string{"first", "second"}, nil)
+		m.repo.On("SetOrder", ctx, "test", []string{"second", "first"}).
+			Once().
+			Return(nil)
+
+		err := uc.Reorder(ctx, "test", []string{"second", "first"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Reorder error on missing paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCollectionsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+		)
+
+		m.repo.On("Get", ctx, "test").
+			Once().
+			Return(owned(), nil)
+		m.repo.On("PasteHashes", ctx, "test").
+			Once().
+			Return([]string{"first", "second"}, nil)
+
+		err := uc.Reorder(ctx, "test", []string{"second", "third"})
+		require.ErrorIs(t, err, ErrInvalidOrder)
+	})
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+var _ Comments = (*CommentsUseCase)(nil)
+
+type CommentsUseCase struct {
+	repo   PasteCommentsRepo
+	objs   PastesBlobStorage
+	access PasteAccess
+}
+
+func NewComments(r PasteCommentsRepo, o PastesBlobStorage, a PasteAccess) *CommentsUseCase {
+	return &CommentsUseCase{
+		repo:   r,
+		objs:   o,
+		access: a,
+	}
+}
+
+// ListComments returns all comments of a paste in order of creation, replies follow
+// the comments they reply to. If the paste is private and not readable by the user
+// returns ErrPasteNotFound. If the paste is expired returns ErrPasteExpired.
+// If the password does not match the paste one returns ErrPasteLocked.
+func (uc *CommentsUseCase) ListComments(ctx context.Context, hash, password string) ([]*entity.PasteComment, error) {
+	if _, err := uc.access.Meta(ctx, hash, password); err != nil {
+		return nil, fmt.Errorf("CommentsUseCase.ListComments: %w", err)
+	}
+
+	comments, err := uc.repo.List(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("CommentsUseCase.ListComments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// CreateComment comments on a paste as the user from context.
+//
+// Only authenticated users can comment, otherwise returns ErrUnauthorized.
+// The paste must be readable as in ListComments. A reply takes lines of the comment
+// it replies to and can not set its own, otherwise returns ErrInvalidCommentLines.
+// If the replied comment is not a comment of the paste returns ErrInvalidReply.
+// Lines must be within the paste text, otherwise returns ErrInvalidCommentLines,
+// a range without the last line comments one line. The comment is made on
+// the current revision of the paste. On success c is replaced with the stored comment.
+func (uc *CommentsUseCase) CreateComment(ctx context.Context, password string, c *entity.PasteComment) error {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return ErrUnauthorized
+	}
+
+	paste, err := uc.access.Meta(ctx, c.PasteHash, password)
+	if err != nil {
+		return fmt.Errorf("CommentsUseCase.CreateComment: %w", err)
+	}
+
+	if c.ParentID != 0 {
+		if c.Anchored() {
+			return ErrInvalidCommentLines
+		}
+
+		parent, err := uc.comment(ctx, c.PasteHash, c.ParentID)
+		if err != nil {
+			if errors.Is(err, ErrCommentNotFound) {
+				return ErrInvalidReply
+			}
+
+			return fmt.Errorf("CommentsUseCase.CreateComment: %w", err)
+		}
+
+		c.LineStart, c.LineEnd = parent.LineStart, parent.LineEnd
+	} else if c.Anchored() {
+		if err := uc.checkLines(ctx, paste, c); err != nil {
+			return fmt.Errorf("CommentsUseCase.CreateComment: %w", err)
+		}
+	}
+
+	c.UserID = userID
+	c.Revision = paste.Revision
+
+	if err := uc.repo.Create(ctx, c); err != nil {
+		return fmt.Errorf("CommentsUseCase.CreateComment: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateComment changes the text of a comment. Only the author of the comment
+// can change it, otherwise returns ErrNotCommentAuthor. The paste must be
+// readable as in ListComments. If the comment is not a comment of the paste
+// or is deleted returns ErrCommentNotFound. On success c is replaced with
+// the updated comment.
+func (uc *CommentsUseCase) UpdateComment(ctx context.Context, password string, c *entity.PasteComment) error {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return ErrNotCommentAuthor
+	}
+
+	if _, err := uc.access.Meta(ctx, c.PasteHash, password); err != nil {
+		return fmt.Errorf("CommentsUseCase.UpdateComment: %w", err)
+	}
+
+	comment, err := uc.comment(ctx, c.PasteHash, c.ID)
+	if err != nil {
+		return fmt.Errorf("CommentsUseCase.UpdateComment: %w", err)
+	}
+
+	if comment.UserID != userID {
+		return ErrNotCommentAuthor
+	}
+
+	comment.Text = c.Text
+
+	if err := uc.repo.Update(ctx, comment); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+
+		return fmt.Errorf("CommentsUseCase.UpdateComment: %w", err)
+	}
+
+	*c = *comment
+
+	return nil
+}
+
+// DeleteComment deletes a comment of a paste. The author of the comment and
+// the author of the paste can delete it, otherwise returns ErrNotCommentAuthor.
+// Other errors are the same as in UpdateComment.
+func (uc *CommentsUseCase) DeleteComment(ctx context.Context, hash, password string, id int64) error {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return ErrNotCommentAuthor
+	}
+
+	paste, err := uc.access.Meta(ctx, hash, password)
+	if err != nil {
+		return fmt.Errorf("CommentsUseCase.DeleteComment: %w", err)
+	}
+
+	comment, err := uc.comment(ctx, hash, id)
+	if err != nil {
+		return fmt.Errorf("CommentsUseCase.DeleteComment: %w", err)
+	}
+
+	if comment.UserID != userID && paste.UserID.String != userID {
+		return ErrNotCommentAuthor
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrCommentNotFound
+		}
+
+		return fmt.Errorf("CommentsUseCase.DeleteComment: %w", err)
+	}
+
+	return nil
+}
+
+// comment returns a not deleted comment of the paste, otherwise returns ErrCommentNotFound.
+func (uc *CommentsUseCase) comment(ctx context.Context, hash string, id int64) (*entity.PasteComment, error) {
+	comment, err := uc.repo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrCommentNotFound
+		}
+
+		return nil, err
+	}
+
+	if comment.PasteHash != hash || comment.Deleted {
+		return nil, ErrCommentNotFound
+	}
+
+	return comment, nil
+}
+
+// checkLines checks that lines of the comment are within the paste text.
+// A comment without the last line gets the first one.
+func (uc *CommentsUseCase) checkLines(ctx context.Context, paste *entity.Paste, c *entity.PasteComment) error {
+	if c.LineEnd == 0 {
+		c.LineEnd = c.LineStart
+	}
+
+	if c.LineStart < 1 || c.LineEnd < c.LineStart {
+		return ErrInvalidCommentLines
+	}
+
+	text, err := uc.objs.Get(ctx, paste.UserID.String, paste.Hash)
+	if err != nil {
+		return err
+	}
+
+	if c.LineEnd > lineCount(text) {
+		return ErrInvalidCommentLines
+	}
+
+	return nil
+}
@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type commentsMocks struct {
+	repo   *mocks.PasteCommentsRepo
+	blob   *mocks.PastesBlobStorage
+	pastes *mocks.PastesRepo
+	grants *mocks.PasteGrantsRepo
+}
+
+func newCommentsUseCase(t *testing.T) (*CommentsUseCase, *commentsMocks) {
+	t.Helper()
+
+	m := &commentsMocks{
+		repo:   mocks.NewPasteCommentsRepo(t),
+		blob:   mocks.NewPastesBlobStorage(t),
+		pastes: mocks.NewPastesRepo(t),
+		grants: mocks.NewPasteGrantsRepo(t),
+	}
+
+	return NewComments(m.repo, m.blob, NewPasteAccess(m.pastes, m.grants)), m
+}
+
+func TestCommentsUseCase_Comments(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Comment lines of paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCommentsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{
+				Hash:     "test",
+				Revision: 2,
+				UserID:   sql.NullString{String: "author", Valid: true},
+			}
+			comment = &entity.PasteComment{PasteHash: paste.Hash, LineStart: 3, Text: "typo"}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.blob.On("Get", ctx, "author", paste.Hash).
+			Once().
+			Return(entity.File("one\ntwo\nthree\n"), nil)
+		m.repo.On("Create", ctx, comment).
+			Once().
+			Return(nil)
+
+		err := uc.CreateComment(ctx, "", comment)
+		require.NoError(t, err)
+		require.Equal(t, "user", comment.UserID)
+		require.Equal(t, 2, comment.Revision)
+		require.Equal(t, 3, comment.LineEnd)
+	})
+
+	t.Run("Comment views limited paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newCommentsUseCase(t)
+			ctx     = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste   = &entity.Paste{Hash: "test", MaxViews: 1, BurnAfterRead: true}
+			comment = &entity.PasteComment{PasteHash: paste.Hash, Text: "thanks"}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Create", ctx, comment).
+			Once().
+			Return(nil)
+
+		err := uc.CreateComment(ctx, "", comment)
+		require.NoError(t, err)
+	})
+
+	t.Run("Comment protected paste by author without password", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCommentsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "author")
+			paste = &entity.Paste{
+				Hash:   "test",
+				UserID: sql.NullString{String: "author", Valid: true},
+			}
+			comment = &entity.PasteComment{PasteHash: paste.Hash, Text: "note"}
+		)
+
+		paste.Password.Set("secret")
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Create", ctx, comment).
+			Once().
+			Return(nil)
+
+		err := uc.CreateComment(ctx, "", comment)
+		require.NoError(t, err)
+	})
+
+	t.Run("Comment error on lines out of paste text", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newCommentsUseCase(t)
+			ctx     = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste   = &entity.Paste{Hash: "test"}
+			comment = &entity.PasteComment{PasteHash: paste.Hash, LineStart: 2, LineEnd: 4, Text: "typo"}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("one\ntwo\nthree"), nil)
+
+		err := uc.CreateComment(ctx, "", comment)
+		require.ErrorIs(t, err, ErrInvalidCommentLines)
+	})
+
+	t.Run("Reply takes lines of replied comment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newCommentsUseCase(t)
+			ctx     = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste   = &entity.Paste{Hash: "test", Revision: 1}
+			parent  = &entity.PasteComment{ID: 1, PasteHash: paste.Hash, LineStart: 2, LineEnd: 3}
+			comment = &entity.PasteComment{PasteHash: paste.Hash, ParentID: 1, Text: "agree"}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Get", ctx, int64(1)).
+			Once().
+			Return(parent, nil)
+		m.repo.On("Create", ctx, comment).
+			Once().
+			Return(nil)
+
+		err := uc.CreateComment(ctx, "", comment)
+		require.NoError(t, err)
+		require.Equal(t, 2, comment.LineStart)
+		require.Equal(t, 3, comment.LineEnd)
+	})
+
+	t.Run("Reply error on comment of other paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newCommentsUseCase(t)
+			ctx     = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste   = &entity.Paste{Hash: "test"}
+			comment = &entity.PasteComment{PasteHash: paste.Hash, ParentID: 1, Text: "agree"}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Get", ctx, int64(1)).
+			Once().
+			Return(&entity.PasteComment{ID: 1, PasteHash: "other"}, nil)
+
+		err := uc.CreateComment(ctx, "", comment)
+		require.ErrorIs(t, err, ErrInvalidReply)
+	})
+
+	t.Run("Comment error for anonymous user", func(t *testing.T) {
+		t.Parallel()
+
+		uc, _ := newCommentsUseCase(t)
+
+		err := uc.CreateComment(context.Background(), "", &entity.PasteComment{PasteHash: "test", Text: "typo"})
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("List comments error on locked paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCommentsUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test"}
+		)
+
+		paste.Password.Set("secret")
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		_, err := uc.ListComments(ctx, paste.Hash, "")
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+
+	t.Run("Update error for other user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newCommentsUseCase(t)
+			ctx     = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste   = &entity.Paste{Hash: "test"}
+			comment = &entity.PasteComment{ID: 1, PasteHash: paste.Hash, Text: "edited"}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Get", ctx, int64(1)).
+			Once().
+			Return(&entity.PasteComment{ID: 1, PasteHash: paste.Hash, UserID: "other"}, nil)
+
+		err := uc.UpdateComment(ctx, "", comment)
+		require.ErrorIs(t, err, ErrNotCommentAuthor)
+	})
+
+	t.Run("Paste author deletes comment", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newCommentsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "author")
+			paste = &entity.Paste{Hash: "test", UserID: sql.NullString{String: "author", Valid: true}}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Get", ctx, int64(1)).
+			Once().
+			Return(&entity.PasteComment{ID: 1, PasteHash: paste.Hash, UserID: "other"}, nil)
+		m.repo.On("Delete", ctx, int64(1)).
+			Once().
+			Return(nil)
+
+		err := uc.DeleteComment(ctx, paste.Hash, "", 1)
+		require.NoError(t, err)
+	})
+}
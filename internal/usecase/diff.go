@@ -0,0 +1,214 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+// diffContext is a number of unchanged lines around each hunk.
+const diffContext = 3
+
+// Diff returns a difference between two paste versions.
+//
+// Each source is unlocked with its own password the same way as unlocking a paste.
+// If the password does not match returns ErrPasteLocked.
+func (uc *PastesUseCase) Diff(ctx context.Context, from, to entity.DiffSource) (*entity.Diff, error) {
+	a, err := uc.getDiffSource(ctx, from)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Diff: %w", err)
+	}
+
+	b, err := uc.getDiffSource(ctx, to)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Diff: %w", err)
+	}
+
+	return diffRevisions(a, b), nil
+}
+
+// DiffRevisions returns a difference between two revisions of a paste.
+//
+// Zero to means the current revision, zero from means the revision before to.
+func (uc *PastesUseCase) DiffRevisions(ctx context.Context, hash, password string, from, to int) (*entity.Diff, error) {
+	paste, err := uc.access.Text(ctx, hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.DiffRevisions: %w", err)
+	}
+
+	if to == 0 {
+		to = paste.Revision
+	}
+
+	if from == 0 {
+		from = to - 1
+	}
+
+	if from < 1 || from > paste.Revision || to > paste.Revision {
+		return nil, ErrRevisionNotFound
+	}
+
+	a, err := uc.getRevisionFile(ctx, paste, from)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.DiffRevisions: %w", err)
+	}
+
+	b, err := uc.getRevisionFile(ctx, paste, to)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.DiffRevisions: %w", err)
+	}
+
+	return diffRevisions(a, b), nil
+}
+
+func (uc *PastesUseCase) getDiffSource(ctx context.Context, src entity.DiffSource) (*entity.PasteRevision, error) {
+	paste, err := uc.access.Text(ctx, src.Hash, src.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := src.Revision
+	if revision == 0 {
+		revision = paste.Revision
+	}
+
+	return uc.getRevisionFile(ctx, paste, revision)
+}
+
+// getRevisionFile returns the paste revision with its file.
+func (uc *PastesUseCase) getRevisionFile(ctx context.Context, paste *entity.Paste, revision int) (*entity.PasteRevision, error) {
+	if revision == paste.Revision {
+		file, err := uc.objs.Get(ctx, paste.UserID.String, paste.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		rev := paste.Revisioned()
+		rev.File = file
+
+		return rev, nil
+	}
+
+	rev, err := uc.revs.Get(ctx, paste.Hash, revision)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+
+		return nil, err
+	}
+
+	rev.File, err = uc.objs.GetRevision(ctx, paste.UserID.String, paste.Hash, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	return rev, nil
+}
+
+func diffRevisions(a, b *entity.PasteRevision) *entity.Diff {
+	d := &entity.Diff{
+		From: fmt.Sprintf("%s@%d", a.Hash, a.Revision),
+		To:   fmt.Sprintf("%s@%d", b.Hash, b.Revision),
+	}
+	d.Hunks = diffHunks(splitLines(string(a.File)), splitLines(string(b.File)))
+	d.Unified = unified(d.From, d.To, d.Hunks)
+
+	return d
+}
+
+// diffHunks groups changed lines into hunks with diffContext lines around.
+func diffHunks(a, b []string) []entity.DiffHunk {
+	m := difflib.NewMatcher(a, b)
+	hunks := make([]entity.DiffHunk, 0)
+
+	for _, group := range m.GetGroupedOpCodes(diffContext) {
+		if len(group) == 1 && group[0].Tag == 'e' {
+			continue
+		}
+
+		first, last := group[0], group[len(group)-1]
+		hunk := entity.DiffHunk{
+			OldStart: first.I1 + 1,
+			OldLines: last.I2 - first.I1,
+			NewStart: first.J1 + 1,
+			NewLines: last.J2 - first.J1,
+			Lines:    make([]entity.DiffLine, 0),
+		}
+
+		for _, op := range group {
+			if op.Tag == 'e' {
+				hunk.Lines = appendLines(hunk.Lines, " ", a[op.I1:op.I2])
+
+				continue
+			}
+
+			if op.Tag == 'r' || op.Tag == 'd' {
+				hunk.Lines = appendLines(hunk.Lines, "-", a[op.I1:op.I2])
+			}
+
+			if op.Tag == 'r' || op.Tag == 'i' {
+				hunk.Lines = appendLines(hunk.Lines, "+", b[op.J1:op.J2])
+			}
+		}
+
+		hunks = append(hunks, hunk)
+	}
+
+	return hunks
+}
+
+func appendLines(dst []entity.DiffLine, op string, lines []string) []entity.DiffLine {
+	for _, l := range lines {
+		dst = append(dst, entity.DiffLine{Op: op, Text: l})
+	}
+
+	return dst
+}
+
+// unified formats hunks as unified diff.
+func unified(from, to string, hunks []entity.DiffHunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", from, to)
+
+	for _, h := range hunks {
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", unifiedRange(h.OldStart, h.OldLines), unifiedRange(h.NewStart, h.NewLines))
+
+		for _, l := range h.Lines {
+			sb.WriteString(l.Op)
+			sb.WriteString(l.Text)
+			sb.WriteByte('\n')
+		}
+	}
+
+	return sb.String()
+}
+
+// unifiedRange formats a hunk range. Empty ranges begin at the line before the range.
+func unifiedRange(start, length int) string {
+	switch length {
+	case 1:
+		return fmt.Sprintf("%d", start)
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	default:
+		return fmt.Sprintf("%d,%d", start, length)
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
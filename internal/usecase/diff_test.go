@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffRevisions(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = &entity.PasteRevision{Hash: "test", Revision: 1, File: entity.File("a\nb\nc\n")}
+		b = &entity.PasteRevision{Hash: "test", Revision: 2, File: entity.File("a\nB\nc\nd\n")}
+	)
+
+	diff := diffRevisions(a, b)
+
+	require.Equal(t, "test@1", diff.From)
+	require.Equal(t, "test@2", diff.To)
+	require.Len(t, diff.Hunks, 1)
+	require.Equal(t, entity.DiffHunk{
+		OldStart: 1,
+		OldLines: 3,
+		NewStart: 1,
+		NewLines: 4,
+		Lines: []entity.DiffLine{
+			{Op: " ", Text: "a"},
+			{Op: "-", Text: "b"},
+			{Op: "+", Text: "B"},
+			{Op: " ", Text: "c"},
+			{Op: "+", Text: "d"},
+		},
+	}, diff.Hunks[0])
+	require.Equal(t, "--- test@1\n+++ test@2\n@@ -1,3 +1,4 @@\n a\n-b\n+B\n c\n+d\n", diff.Unified)
+}
+
+func TestDiffRevisions_Equal(t *testing.T) {
+	t.Parallel()
+
+	var (
+		a = &entity.PasteRevision{Hash: "test", Revision: 1, File: entity.File("a\n")}
+		b = &entity.PasteRevision{Hash: "test", Revision: 2, File: entity.File("a\n")}
+	)
+
+	diff := diffRevisions(a, b)
+
+	require.Empty(t, diff.Hunks)
+	require.Empty(t, diff.Unified)
+}
+
+func TestPastesUseCase_Diff(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Diff two pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			a     = &entity.Paste{Hash: "a", Revision: 1}
+			b     = &entity.Paste{Hash: "b", Revision: 3}
+		)
+
+		m.repo.On("Get", ctx, a.Hash).
+			Once().
+			Return(a, nil)
+		m.repo.On("Get", ctx, b.Hash).
+			Once().
+			Return(b, nil)
+		m.blob.On("Get", ctx, "", a.Hash).
+			Once().
+			Return(entity.File("one\n"), nil)
+		m.blob.On("Get", ctx, "", b.Hash).
+			Once().
+			Return(entity.File("two\n"), nil)
+
+		diff, err := uc.Diff(ctx, entity.DiffSource{Hash: a.Hash}, entity.DiffSource{Hash: b.Hash})
+		require.NoError(t, err)
+		require.Equal(t, "a@1", diff.From)
+		require.Equal(t, "b@3", diff.To)
+		require.Len(t, diff.Hunks, 1)
+	})
+
+	t.Run("Get error on wrong password", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			a     = &entity.Paste{Hash: "a", Revision: 1}
+		)
+
+		a.Password.Set("secret")
+
+		m.repo.On("Get", ctx, a.Hash).
+			Once().
+			Return(a, nil)
+
+		_, err := uc.Diff(ctx, entity.DiffSource{Hash: a.Hash, Password: "wrong"}, entity.DiffSource{Hash: "b"})
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+
+	t.Run("Get error on views limited paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			a     = &entity.Paste{Hash: "a", Revision: 1, MaxViews: 1}
+		)
+
+		m.repo.On("Get", ctx, a.Hash).
+			Once().
+			Return(a, nil)
+
+		_, err := uc.Diff(ctx, entity.DiffSource{Hash: a.Hash}, entity.DiffSource{Hash: "b"})
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+}
+
+func TestPastesUseCase_DiffRevisions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Diff current with previous", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Revision: 2}
+		)
+
+		paste.Password.Set("secret")
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.revs.On("Get", ctx, paste.Hash, 1).
+			Once().
+			Return(&entity.PasteRevision{Hash: paste.Hash, Revision: 1}, nil)
+		m.blob.On("GetRevision", ctx, "", paste.Hash, 1).
+			Once().
+			Return(entity.File("old\n"), nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("new\n"), nil)
+
+		diff, err := uc.DiffRevisions(ctx, paste.Hash, "secret", 0, 0)
+		require.NoError(t, err)
+		require.Equal(t, "test@1", diff.From)
+		require.Equal(t, "test@2", diff.To)
+	})
+
+	t.Run("Get error on unknown revision", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Revision: 1}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		_, err := uc.DiffRevisions(ctx, paste.Hash, "", 0, 0)
+		require.ErrorIs(t, err, ErrRevisionNotFound)
+	})
+}
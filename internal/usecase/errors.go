@@ -6,4 +6,36 @@ var (
 	ErrPasteNotFound  = errors.New("the paste not found")
 	ErrRecordNotFound = errors.New("the record not found")
 	ErrNotPasteAuthor = errors.New("the user is not paste authro")
+	ErrInvalidToken   = errors.New("the access token is invalid")
+	ErrUnauthorized   = errors.New("the user is not authorized")
+	ErrPasteLocked    = errors.New("the paste is locked with password")
+	ErrPasteGone      = errors.New("the paste views limit is reached")
+	ErrPasteExpired   = errors.New("the paste is expired")
+
+	ErrInvalidExpiration = errors.New("the paste expiration is not allowed")
+	ErrUnknownTheme      = errors.New("the highlight theme is unknown")
+	ErrNotConvertible    = errors.New("the paste can not be converted to the format")
+	ErrNotQueryable      = errors.New("the paste format can not be queried")
+	ErrQueryLimit        = errors.New("the query exceeds the limits")
+
+	ErrSchemaNotFound      = errors.New("the schema paste not found")
+	ErrInvalidSchema       = errors.New("the paste is not a valid JSON schema")
+	ErrSchemaNotApplicable = errors.New("the paste format can not be validated against a schema")
+
+	ErrRevisionNotFound = errors.New("the paste revision not found")
+	ErrFileNotFound     = errors.New("the paste file not found")
+	ErrUserNotFound     = errors.New("the user not found")
+
+	ErrInvalidTags = errors.New("the paste tags are invalid")
+
+	ErrCollectionNotFound    = errors.New("the collection not found")
+	ErrNotCollectionOwner    = errors.New("the user is not collection owner")
+	ErrCollectionPasteExists = errors.New("the paste is already in the collection")
+	ErrCollectionFull        = errors.New("the collection has too many pastes")
+	ErrInvalidOrder          = errors.New("the order must list every paste of the collection once")
+
+	ErrCommentNotFound     = errors.New("the comment not found")
+	ErrNotCommentAuthor    = errors.New("the user is not comment author")
+	ErrInvalidCommentLines = errors.New("the commented lines are invalid")
+	ErrInvalidReply        = errors.New("the replied comment not found")
 )
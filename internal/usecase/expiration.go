@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+// ExpirationPolicy limits lifetimes of pastes.
+type ExpirationPolicy struct {
+	// Min and Max bound a requested lifetime of a paste.
+	Min, Max time.Duration
+	// Default is a lifetime of a paste created without expiration.
+	Default time.Duration
+	// AllowNever allows authenticated users to create pastes that never expire.
+	AllowNever bool
+}
+
+// expiresAt returns an expiration date for the requested expiration,
+// zero date means the paste never expires.
+//
+// Returns ErrUnauthorized if an anonymous user requests a never expiring paste
+// and ErrInvalidExpiration if the expiration is not allowed by the policy.
+func (p ExpirationPolicy) expiresAt(e entity.Expiration, authenticated bool) (time.Time, error) {
+	now := time.Now()
+
+	switch {
+	case e.Never:
+		if !authenticated {
+			return time.Time{}, ErrUnauthorized
+		}
+
+		if !p.AllowNever {
+			return time.Time{}, ErrInvalidExpiration
+		}
+
+		return time.Time{}, nil
+	case !e.At.IsZero():
+		if !p.allows(e.At.Sub(now)) {
+			return time.Time{}, ErrInvalidExpiration
+		}
+
+		return e.At, nil
+	case e.Duration != 0:
+		if !p.allows(e.Duration) {
+			return time.Time{}, ErrInvalidExpiration
+		}
+
+		return now.Add(e.Duration), nil
+	default:
+		return now.Add(p.Default), nil
+	}
+}
+
+func (p ExpirationPolicy) allows(d time.Duration) bool {
+	return d >= p.Min && d <= p.Max
+}
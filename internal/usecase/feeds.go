@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+// feedSize is a number of pastes in feeds.
+const feedSize = 50
+
+var _ Feeds = (*FeedsUseCase)(nil)
+
+type FeedsUseCase struct {
+	cache  PastesFeedsCache
+	pastes PastesRepo
+	users  UsersRepo
+}
+
+func NewFeeds(c PastesFeedsCache, p PastesRepo, us UsersRepo) *FeedsUseCase {
+	return &FeedsUseCase{
+		cache:  c,
+		pastes: p,
+		users:  us,
+	}
+}
+
+// Recent returns the feed of recent public pastes of all users.
+//
+// Feeds are cached until a public paste is created, changed or deleted.
+func (uc *FeedsUseCase) Recent(ctx context.Context) (*entity.Feed, error) {
+	feed, err := uc.feed(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("FeedsUseCase.Recent: %w", err)
+	}
+
+	return feed, nil
+}
+
+// UserFeed returns the feed of recent public pastes of the user with the username.
+// If the user does not exist returns ErrUserNotFound. The feed is cached as in Recent.
+func (uc *FeedsUseCase) UserFeed(ctx context.Context, username string) (*entity.Feed, error) {
+	user, err := uc.users.GetByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+
+		return nil, fmt.Errorf("FeedsUseCase.UserFeed: %w", err)
+	}
+
+	feed, err := uc.feed(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("FeedsUseCase.UserFeed: %w", err)
+	}
+
+	return feed, nil
+}
+
+// feed returns the cached feed of the user, or of all users if userID is empty.
+func (uc *FeedsUseCase) feed(ctx context.Context, userID string) (*entity.Feed, error) {
+	feed, ok, err := uc.cache.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return feed, nil
+	}
+
+	entries, err := uc.pastes.ListFeed(ctx, userID, feedSize)
+	if err != nil {
+		return nil, err
+	}
+
+	feed = &entity.Feed{Entries: entries}
+
+	for _, e := range entries {
+		if e.Paste.UpdatedAt.After(feed.Updated) {
+			feed.Updated = e.Paste.UpdatedAt
+		}
+	}
+
+	if err := uc.cache.Set(ctx, userID, feed); err != nil {
+		return nil, err
+	}
+
+	return feed, nil
+}
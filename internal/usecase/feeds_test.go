@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type feedsMocks struct {
+	cache  *mocks.PastesFeedsCache
+	pastes *mocks.PastesRepo
+	users  *mocks.UsersRepo
+}
+
+func newFeedsUseCase(t *testing.T) (*FeedsUseCase, *feedsMocks) {
+	t.Helper()
+
+	m := &feedsMocks{
+		cache:  mocks.NewPastesFeedsCache(t),
+		pastes: mocks.NewPastesRepo(t),
+		users:  mocks.NewUsersRepo(t),
+	}
+
+	return NewFeeds(m.cache, m.pastes, m.users), m
+}
+
+func TestFeedsUseCase_Feeds(t *testing.T) {
+	t.Parallel()
+
+	var (
+		created = time.Date(2023, 10, 29, 20, 38, 41, 0, time.UTC)
+		entries = []*entity.FeedEntry{
+			{Paste: &entity.Paste{Hash: "first", CreatedAt: created, UpdatedAt: created.Add(time.Hour)}, Excerpt: "a"},
+			{Paste: &entity.Paste{Hash: "second", CreatedAt: created, UpdatedAt: created}, Excerpt: "b"},
+		}
+	)
+
+	t.Run("Get cached recent feed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newFeedsUseCase(t)
+			ctx   = context.Background()
+			feed  = &entity.Feed{Entries: entries, Updated: created.Add(time.Hour)}
+		)
+
+		m.cache.On("Get", ctx, "").
+			Once().
+			Return(feed, true, nil)
+
+		got, err := uc.Recent(ctx)
+		require.NoError(t, err)
+		require.Equal(t, feed, got)
+	})
+
+	t.Run("Get recent feed on cache miss", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newFeedsUseCase(t)
+			ctx   = context.Background()
+			feed  = &entity.Feed{Entries: entries, Updated: created.Add(time.Hour)}
+		)
+
+		m.cache.On("Get", ctx, "").
+			Once().
+			Return(nil, false, nil)
+		m.pastes.On("ListFeed", ctx, "", feedSize).
+			Once().
+			Return(entries, nil)
+		m.cache.On("Set", ctx, "", feed).
+			Once().
+			Return(nil)
+
+		got, err := uc.Recent(ctx)
+		require.NoError(t, err)
+		require.Equal(t, feed, got)
+	})
+
+	t.Run("Get user feed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newFeedsUseCase(t)
+			ctx   = context.Background()
+			feed  = &entity.Feed{Entries: entries[1:], Updated: created}
+		)
+
+		m.users.On("GetByUsername", ctx, "octocat").
+			Once().
+			Return(&entity.User{ID: "user", Username: "octocat"}, nil)
+		m.cache.On("Get", ctx, "user").
+			Once().
+			Return(nil, false, nil)
+		m.pastes.On("ListFeed", ctx, "user", feedSize).
+			Once().
+			Return(entries[1:], nil)
+		m.cache.On("Set", ctx, "user", feed).
+			Once().
+			Return(nil)
+
+		got, err := uc.UserFeed(ctx, "octocat")
+		require.NoError(t, err)
+		require.Equal(t, feed, got)
+	})
+
+	t.Run("Get user feed error on unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newFeedsUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.users.On("GetByUsername", ctx, "nobody").
+			Once().
+			Return(nil, ErrRecordNotFound)
+
+		_, err := uc.UserFeed(ctx, "nobody")
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+}
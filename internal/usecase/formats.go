@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+var _ Formats = &FormatsUseCase{}
+
+type FormatsUseCase struct {
+	detector FormatDetector
+}
+
+func NewFormats(d FormatDetector) *FormatsUseCase {
+	return &FormatsUseCase{detector: d}
+}
+
+// Detect guesses a format of the text without creating a paste.
+func (uc *FormatsUseCase) Detect(_ context.Context, text entity.File) entity.FormatDetection {
+	return uc.detector.Detect(text)
+}
@@ -0,0 +1,896 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// indent is an indentation of pretty printed texts.
+	indent = "  "
+	// xmlRoot is a name of the root element of converted texts that have no single root.
+	xmlRoot = "root"
+	// xmlItem is a name of elements of nested sequences.
+	xmlItem = "item"
+	// xmlAttrPrefix and xmlText are keys of attributes and text of XML elements.
+	xmlAttrPrefix = "@"
+	xmlText       = "#text"
+	// maxAliasNodes is a max number of nodes expanded from aliases of a YAML text,
+	// it stops texts that expand a few aliases into a huge value.
+	maxAliasNodes = 100_000
+)
+
+var _ usecase.FormatConverter = &Converter{}
+
+// Converter converts texts between structured formats.
+//
+// Texts are converted through YAML nodes, so key order is kept by all formats
+// and comments are kept when YAML is converted to YAML. XML elements are
+// mapped to keys, attributes to keys with @ prefix and texts to #text keys.
+type Converter struct {
+	v *Validator
+}
+
+func NewConverter() *Converter {
+	return &Converter{v: NewValidator()}
+}
+
+// Convert converts the text of the format to opts.To.
+//
+// Returns *entity.FormatError if the text does not match its format and
+// usecase.ErrNotConvertible if either format is not structured or the
+// text can not be represented in the target format.
+// Only the first document of a multi-document YAML is converted.
+func (c *Converter) Convert(format string, text entity.File, opts entity.ConvertOptions) (entity.File, error) {
+	to := opts.To
+	if to == "" {
+		to = format
+	}
+
+	doc, err := c.parse(format, text)
+	if err != nil {
+		return nil, err
+	}
+
+	root := doc.Content[0]
+
+	switch {
+	case opts.Minify:
+		stripComments(root)
+	case format == "yaml":
+		blockStyle(root)
+	default:
+		resetStyle(root)
+	}
+
+	var b bytes.Buffer
+
+	switch to {
+	case "json":
+		err = writeJSON(&b, root, opts.Minify)
+	case "yaml":
+		err = writeYAML(&b, doc, opts.Minify)
+	case "toml":
+		err = writeTOML(&b, root)
+	case "xml":
+		err = writeXML(&b, root, opts.Minify)
+	default:
+		err = fmt.Errorf("%w: %s is not a structured format", usecase.ErrNotConvertible, to)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return b.Bytes(), nil
+}
+
+// parse returns a document node of the text.
+func (c *Converter) parse(format string, text []byte) (*yaml.Node, error) {
+	var (
+		root *yaml.Node
+		err  error
+	)
+
+	switch format {
+	case "json", "yaml":
+		var doc yaml.Node
+
+		if err = yaml.Unmarshal(text, &doc); err == nil && len(doc.Content) > 0 {
+			if err := checkAliases(format, &doc); err != nil {
+				return nil, err
+			}
+
+			return &doc, nil
+		}
+	case "toml":
+		root, err = parseTOML(text)
+	case "xml":
+		root, err = parseXML(text)
+	default:
+		return nil, fmt.Errorf("%w: %s is not a structured format", usecase.ErrNotConvertible, format)
+	}
+
+	if err == nil && root != nil {
+		return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+	}
+
+	if verr := c.v.Validate(format, text); verr != nil {
+		return nil, verr
+	}
+
+	return nil, fmt.Errorf("%w: the %s text is empty", usecase.ErrNotConvertible, format)
+}
+
+// parseTOML returns a mapping node of the text with keys in the order of the text.
+func parseTOML(text []byte) (*yaml.Node, error) {
+	var v map[string]any
+
+	md, err := toml.Decode(string(text), &v)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err := root.Encode(v); err != nil {
+		return nil, err
+	}
+
+	order := make(map[string]int, len(md.Keys()))
+
+	for i, k := range md.Keys() {
+		if _, ok := order[k.String()]; !ok {
+			order[k.String()] = i
+		}
+	}
+
+	sortKeys(&root, "", order)
+
+	return &root, nil
+}
+
+// sortKeys sorts keys of mappings by their order in a TOML text,
+// path is a dotted path of the node.
+func sortKeys(n *yaml.Node, path string, order map[string]int) {
+	switch n.Kind {
+	case yaml.MappingNode:
+		pairs := make([][2]*yaml.Node, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			pairs = append(pairs, [2]*yaml.Node{n.Content[i], n.Content[i+1]})
+		}
+
+		key := func(k *yaml.Node) string {
+			return strings.TrimPrefix(path+"."+toml.Key{k.Value}.String(), ".")
+		}
+
+		sort.SliceStable(pairs, func(i, j int) bool {
+			return order[key(pairs[i][0])] < order[key(pairs[j][0])]
+		})
+
+		n.Content = n.Content[:0]
+		for _, p := range pairs {
+			sortKeys(p[1], key(p[0]), order)
+			n.Content = append(n.Content, p[0], p[1])
+		}
+	case yaml.SequenceNode:
+		for _, item := range n.Content {
+			sortKeys(item, path, order)
+		}
+	}
+}
+
+// xmlElement is an element of a parsed XML text.
+type xmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*xmlElement
+	text     strings.Builder
+}
+
+// parseXML returns a mapping node of the root element.
+func parseXML(text []byte) (*yaml.Node, error) {
+	var (
+		dec   = xml.NewDecoder(bytes.NewReader(text))
+		stack []*xmlElement
+		root  *xmlElement
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			e := &xmlElement{name: t.Name.Local, attrs: t.Attr}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			} else if root == nil {
+				root = e
+			} else {
+				return nil, errors.New("multiple root elements")
+			}
+
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("missing root element")
+	}
+
+	return mapping(scalar(root.name), elementNode(root)), nil
+}
+
+// elementNode returns a scalar node of an element with a text only,
+// otherwise a mapping of its attributes, children and text.
+// Children with the same name are grouped into a sequence.
+func elementNode(e *xmlElement) *yaml.Node {
+	text := strings.TrimSpace(e.text.String())
+
+	if len(e.attrs) == 0 && len(e.children) == 0 {
+		return scalar(text)
+	}
+
+	n := mapping()
+
+	for _, a := range e.attrs {
+		if a.Name.Space == "xmlns" || a.Name.Local == "xmlns" {
+			continue
+		}
+
+		n.Content = append(n.Content, scalar(xmlAttrPrefix+a.Name.Local), scalar(a.Value))
+	}
+
+	// index is a position of a child value in the mapping content.
+	index := make(map[string]int)
+
+	for _, child := range e.children {
+		value := elementNode(child)
+
+		i, ok := index[child.name]
+		switch {
+		case !ok:
+			index[child.name] = len(n.Content) + 1
+			n.Content = append(n.Content, scalar(child.name), value)
+		case n.Content[i].Kind == yaml.SequenceNode:
+			n.Content[i].Content = append(n.Content[i].Content, value)
+		default:
+			n.Content[i] = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{n.Content[i], value}}
+		}
+	}
+
+	if text != "" {
+		n.Content = append(n.Content, scalar(xmlText), scalar(text))
+	}
+
+	return n
+}
+
+func scalar(v string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}
+}
+
+func mapping(content ...*yaml.Node) *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: content}
+}
+
+// stripComments removes comments of the node and its children.
+func stripComments(n *yaml.Node) {
+	n.HeadComment, n.LineComment, n.FootComment = "", "", ""
+
+	for _, c := range n.Content {
+		stripComments(c)
+	}
+}
+
+// blockStyle writes collections of the node in block style, keeping scalar styles.
+func blockStyle(n *yaml.Node) {
+	n.Style &^= yaml.FlowStyle
+
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// resetStyle removes styles of the node, so the YAML encoder picks them.
+func resetStyle(n *yaml.Node) {
+	n.Style = 0
+
+	for _, c := range n.Content {
+		resetStyle(c)
+	}
+}
+
+// checkAliases checks that aliases of the document can be expanded: an alias
+// must not refer to a node containing it, and all aliases together must not expand
+// into more than maxAliasNodes nodes. Nodes of checked documents can be walked
+// with resolved aliases without limits.
+func checkAliases(format string, doc *yaml.Node) error {
+	ac := &aliasCheck{format: format, expanding: make(map[*yaml.Node]bool)}
+
+	return ac.walk(doc, nil)
+}
+
+// aliasCheck keeps the state of an alias check.
+type aliasCheck struct {
+	format string
+	// expanding are nodes of aliases being expanded.
+	expanding map[*yaml.Node]bool
+	expanded  int
+}
+
+// walk walks the node, outer is the outermost alias being expanded.
+func (ac *aliasCheck) walk(n, outer *yaml.Node) error {
+	if n.Kind == yaml.AliasNode {
+		if ac.expanding[n.Alias] {
+			return &entity.FormatError{
+				Format: ac.format,
+				Line:   n.Line,
+				Column: n.Column,
+				Msg:    fmt.Sprintf("the alias *%s refers to a value containing it", n.Value),
+			}
+		}
+
+		if outer == nil {
+			outer = n
+		}
+
+		ac.expanding[n.Alias] = true
+		err := ac.walk(n.Alias, outer)
+		delete(ac.expanding, n.Alias)
+
+		return err
+	}
+
+	if outer != nil {
+		if ac.expanded++; ac.expanded > maxAliasNodes {
+			return &entity.FormatError{
+				Format: ac.format,
+				Line:   outer.Line,
+				Column: outer.Column,
+				Msg:    fmt.Sprintf("aliases expand into more than %d values", maxAliasNodes),
+			}
+		}
+	}
+
+	for _, c := range n.Content {
+		if err := ac.walk(c, outer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolve returns the node with aliases resolved.
+func resolve(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+
+	return n
+}
+
+// pairs returns key and value pairs of a mapping node.
+// Keys must be scalars, as in JSON, TOML and XML.
+func pairs(n *yaml.Node) ([][2]*yaml.Node, error) {
+	ps := make([][2]*yaml.Node, 0, len(n.Content)/2)
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := resolve(n.Content[i]), resolve(n.Content[i+1])
+		if k.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%w: keys must be scalars", usecase.ErrNotConvertible)
+		}
+
+		ps = append(ps, [2]*yaml.Node{k, v})
+	}
+
+	return ps, nil
+}
+
+// scalarValue returns a Go value of the scalar node.
+func scalarValue(n *yaml.Node) (any, error) {
+	var v any
+	if err := n.Decode(&v); err != nil {
+		return nil, fmt.Errorf("%w: %w", usecase.ErrNotConvertible, err)
+	}
+
+	return v, nil
+}
+
+func writeYAML(b *bytes.Buffer, doc *yaml.Node, minify bool) error {
+	if minify {
+		doc.Content[0].Style |= yaml.FlowStyle
+	}
+
+	enc := yaml.NewEncoder(b)
+	enc.SetIndent(len(indent))
+
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("%w: %w", usecase.ErrNotConvertible, err)
+	}
+
+	return enc.Close()
+}
+
+func writeJSON(b *bytes.Buffer, n *yaml.Node, minify bool) error {
+	var compact bytes.Buffer
+
+	if err := writeJSONValue(&compact, n); err != nil {
+		return err
+	}
+
+	if minify {
+		b.Write(compact.Bytes())
+
+		return nil
+	}
+
+	if err := json.Indent(b, compact.Bytes(), "", indent); err != nil {
+		return err
+	}
+
+	b.WriteByte('\n')
+
+	return nil
+}
+
+func writeJSONValue(b *bytes.Buffer, n *yaml.Node) error {
+	n = resolve(n)
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		ps, err := pairs(n)
+		if err != nil {
+			return err
+		}
+
+		b.WriteByte('{')
+
+		for i, p := range ps {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			writeJSONString(b, p[0].Value)
+			b.WriteByte(':')
+
+			if err := writeJSONValue(b, p[1]); err != nil {
+				return err
+			}
+		}
+
+		b.WriteByte('}')
+	case yaml.SequenceNode:
+		b.WriteByte('[')
+
+		for i, item := range n.Content {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			if err := writeJSONValue(b, item); err != nil {
+				return err
+			}
+		}
+
+		b.WriteByte(']')
+	default:
+		// Numbers are written as is to keep their precision and notation.
+		if tag := n.ShortTag(); (tag == "!!int" || tag == "!!float") && jsonNumberRe.MatchString(n.Value) {
+			b.WriteString(n.Value)
+
+			return nil
+		}
+
+		v, err := scalarValue(n)
+		if err != nil {
+			return err
+		}
+
+		if s, ok := v.(string); ok {
+			writeJSONString(b, s)
+
+			return nil
+		}
+
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("%w: %w", usecase.ErrNotConvertible, err)
+		}
+
+		b.Write(raw)
+	}
+
+	return nil
+}
+
+var jsonNumberRe = regexp.MustCompile(`^-?(0|[1-9]\d*)(\.\d+)?([eE][+-]?\d+)?$`)
+
+// writeJSONString writes a quoted string without escaping of HTML characters.
+func writeJSONString(b *bytes.Buffer, s string) {
+	enc := json.NewEncoder(b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	// Encode always ends the value with a new line.
+	b.Truncate(b.Len() - 1)
+}
+
+var tomlBareKeyRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// writeTOML writes a mapping node as a TOML document. Scalars and arrays of a table
+// are written before its subtables, mappings in arrays are written as inline tables.
+func writeTOML(b *bytes.Buffer, n *yaml.Node) error {
+	n = resolve(n)
+	if n.Kind != yaml.MappingNode {
+		return fmt.Errorf("%w: the toml document must be a table", usecase.ErrNotConvertible)
+	}
+
+	return writeTOMLTable(b, n, nil)
+}
+
+func writeTOMLTable(b *bytes.Buffer, n *yaml.Node, path []string) error {
+	ps, err := pairs(n)
+	if err != nil {
+		return err
+	}
+
+	var tables, arrays [][2]*yaml.Node
+
+	for _, p := range ps {
+		switch {
+		case p[1].Kind == yaml.MappingNode:
+			tables = append(tables, p)
+		case isArrayOfTables(p[1]):
+			arrays = append(arrays, p)
+		default:
+			b.WriteString(tomlKey(p[0].Value))
+			b.WriteString(" = ")
+
+			if err := writeTOMLValue(b, p[1]); err != nil {
+				return err
+			}
+
+			b.WriteByte('\n')
+		}
+	}
+
+	for _, p := range tables {
+		sub := append(path[:len(path):len(path)], tomlKey(p[0].Value))
+
+		writeTOMLHeader(b, "[", strings.Join(sub, "."), "]")
+
+		if err := writeTOMLTable(b, p[1], sub); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range arrays {
+		sub := append(path[:len(path):len(path)], tomlKey(p[0].Value))
+
+		for _, item := range p[1].Content {
+			writeTOMLHeader(b, "[[", strings.Join(sub, "."), "]]")
+
+			if err := writeTOMLTable(b, resolve(item), sub); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeTOMLHeader writes a table header separated from the previous lines.
+func writeTOMLHeader(b *bytes.Buffer, open, key, closing string) {
+	if b.Len() > 0 {
+		b.WriteByte('\n')
+	}
+
+	b.WriteString(open + key + closing + "\n")
+}
+
+// isArrayOfTables reports whether the node is a not empty sequence of mappings.
+func isArrayOfTables(n *yaml.Node) bool {
+	if n.Kind != yaml.SequenceNode || len(n.Content) == 0 {
+		return false
+	}
+
+	for _, item := range n.Content {
+		if resolve(item).Kind != yaml.MappingNode {
+			return false
+		}
+	}
+
+	return true
+}
+
+func writeTOMLValue(b *bytes.Buffer, n *yaml.Node) error {
+	n = resolve(n)
+
+	switch n.Kind {
+	case yaml.MappingNode:
+		ps, err := pairs(n)
+		if err != nil {
+			return err
+		}
+
+		b.WriteByte('{')
+
+		for i, p := range ps {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+
+			b.WriteString(" " + tomlKey(p[0].Value) + " = ")
+
+			if err := writeTOMLValue(b, p[1]); err != nil {
+				return err
+			}
+		}
+
+		if len(ps) > 0 {
+			b.WriteByte(' ')
+		}
+
+		b.WriteByte('}')
+	case yaml.SequenceNode:
+		b.WriteByte('[')
+
+		for i, item := range n.Content {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+
+			if err := writeTOMLValue(b, item); err != nil {
+				return err
+			}
+		}
+
+		b.WriteByte(']')
+	default:
+		v, err := scalarValue(n)
+		if err != nil {
+			return err
+		}
+
+		return writeTOMLScalar(b, v)
+	}
+
+	return nil
+}
+
+func writeTOMLScalar(b *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		return fmt.Errorf("%w: toml has no null values", usecase.ErrNotConvertible)
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case int:
+		b.WriteString(strconv.Itoa(v))
+	case int64:
+		b.WriteString(strconv.FormatInt(v, 10))
+	case uint64:
+		b.WriteString(strconv.FormatUint(v, 10))
+	case float64:
+		b.WriteString(tomlFloat(v))
+	case time.Time:
+		b.WriteString(v.Format(time.RFC3339Nano))
+	case string:
+		writeJSONString(b, v)
+	default:
+		writeJSONString(b, fmt.Sprint(v))
+	}
+
+	return nil
+}
+
+// tomlFloat formats a float, TOML floats always have a fraction or an exponent.
+func tomlFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "nan"
+	case math.IsInf(f, 1):
+		return "inf"
+	case math.IsInf(f, -1):
+		return "-inf"
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+
+	return s
+}
+
+// tomlKey returns a bare key or a quoted one if the key has other characters.
+func tomlKey(k string) string {
+	if tomlBareKeyRe.MatchString(k) {
+		return k
+	}
+
+	var b bytes.Buffer
+	writeJSONString(&b, k)
+
+	return b.String()
+}
+
+var xmlNameRe = regexp.MustCompile(`^[A-Za-z_][\w.-]*$`)
+
+// writeXML writes the node as a XML document. A mapping with a single key is
+// the root element, other nodes are wrapped into the root element.
+func writeXML(b *bytes.Buffer, n *yaml.Node, minify bool) error {
+	n = resolve(n)
+
+	w := &xmlWriter{b: b, pretty: !minify}
+
+	b.WriteString(xml.Header)
+
+	if n.Kind == yaml.MappingNode && len(n.Content) == 2 && resolve(n.Content[1]).Kind != yaml.SequenceNode {
+		if err := w.element(resolve(n.Content[0]).Value, n.Content[1], 0); err != nil {
+			return err
+		}
+	} else if err := w.element(xmlRoot, n, 0); err != nil {
+		return err
+	}
+
+	if w.pretty {
+		b.WriteByte('\n')
+	}
+
+	return nil
+}
+
+type xmlWriter struct {
+	b       *bytes.Buffer
+	pretty  bool
+	started bool
+}
+
+// indent starts a new line of the element at the depth in pretty printed documents.
+func (w *xmlWriter) indent(depth int) {
+	if !w.pretty {
+		return
+	}
+
+	if w.started {
+		w.b.WriteByte('\n')
+	}
+
+	w.started = true
+
+	w.b.WriteString(strings.Repeat(indent, depth))
+}
+
+func (w *xmlWriter) element(name string, n *yaml.Node, depth int) error {
+	n = resolve(n)
+
+	if !xmlNameRe.MatchString(name) {
+		return fmt.Errorf("%w: %q is not a valid xml name", usecase.ErrNotConvertible, name)
+	}
+
+	// A sequence is written as repeated elements with the same name.
+	if n.Kind == yaml.SequenceNode {
+		for _, item := range n.Content {
+			item = resolve(item)
+			if item.Kind == yaml.SequenceNode {
+				item = &yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{scalar(xmlItem), item}}
+			}
+
+			if err := w.element(name, item, depth); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	w.indent(depth)
+	w.b.WriteString("<" + name)
+
+	if n.Kind == yaml.ScalarNode {
+		v, err := scalarValue(n)
+		if err != nil {
+			return err
+		}
+
+		if v == nil {
+			w.b.WriteString("/>")
+
+			return nil
+		}
+
+		w.b.WriteByte('>')
+		w.text(n.Value)
+		w.b.WriteString("</" + name + ">")
+
+		return nil
+	}
+
+	ps, err := pairs(n)
+	if err != nil {
+		return err
+	}
+
+	var (
+		children [][2]*yaml.Node
+		text     *yaml.Node
+	)
+
+	for _, p := range ps {
+		switch k := p[0].Value; {
+		case k == xmlText:
+			text = p[1]
+		case strings.HasPrefix(k, xmlAttrPrefix) && p[1].Kind == yaml.ScalarNode:
+			attr := strings.TrimPrefix(k, xmlAttrPrefix)
+			if !xmlNameRe.MatchString(attr) {
+				return fmt.Errorf("%w: %q is not a valid xml name", usecase.ErrNotConvertible, attr)
+			}
+
+			w.b.WriteString(" " + attr + `="`)
+			w.text(p[1].Value)
+			w.b.WriteByte('"')
+		default:
+			children = append(children, p)
+		}
+	}
+
+	if len(children) == 0 && text == nil {
+		w.b.WriteString("/>")
+
+		return nil
+	}
+
+	w.b.WriteByte('>')
+
+	if text != nil {
+		w.text(text.Value)
+	}
+
+	for _, p := range children {
+		if err := w.element(p[0].Value, p[1], depth+1); err != nil {
+			return err
+		}
+	}
+
+	if len(children) > 0 {
+		w.indent(depth)
+	}
+
+	w.b.WriteString("</" + name + ">")
+
+	return nil
+}
+
+func (w *xmlWriter) text(s string) {
+	_ = xml.EscapeText(w.b, []byte(s))
+}
@@ -0,0 +1,129 @@
+package formats
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/stretchr/testify/require"
+)
+
+// laughs returns a YAML text of levels of aliases, each level repeats the previous one ten times.
+func laughs(levels int) entity.File {
+	var b strings.Builder
+
+	b.WriteString("l0: &l0 [lol, lol, lol, lol, lol, lol, lol, lol, lol, lol]\n")
+
+	for i := 1; i <= levels; i++ {
+		prev := fmt.Sprintf("*l%d", i-1)
+		fmt.Fprintf(&b, "l%d: &l%d [%s]\n", i, i, strings.Repeat(prev+", ", 9)+prev)
+	}
+
+	return entity.File(b.String())
+}
+
+func TestConverter_Convert(t *testing.T) {
+	t.Parallel()
+
+	const object = `{"b": 1, "a": [true, null, "x"]}`
+
+	tests := []struct {
+		name   string
+		format string
+		text   string
+		opts   entity.ConvertOptions
+		want   string
+		err    error
+	}{
+		{name: "json to yaml", format: "json", text: object, opts: entity.ConvertOptions{To: "yaml"}, want: "b: 1\na:\n  - true\n  - null\n  - x\n"},
+		{name: "json to minified json", format: "json", text: object, opts: entity.ConvertOptions{To: "json", Minify: true}, want: `{"b":1,"a":[true,null,"x"]}`},
+		{name: "json to xml", format: "json", text: object, opts: entity.ConvertOptions{To: "xml", Minify: true}, want: `<?xml version="1.0" encoding="UTF-8"?>` + "\n<root><b>1</b><a>true</a><a/><a>x</a></root>"},
+		{name: "yaml to json", format: "yaml", text: "b: 1\na: {c: 2.5}\n", opts: entity.ConvertOptions{To: "json"}, want: "{\n  \"b\": 1,\n  \"a\": {\n    \"c\": 2.5\n  }\n}\n"},
+		{name: "yaml to yaml keeps comments", format: "yaml", text: "b: 1 # c\na: x\n", opts: entity.ConvertOptions{To: "yaml"}, want: "b: 1 # c\na: x\n"},
+		{name: "yaml to toml", format: "yaml", text: "b: 1\na: {c: x}\n", opts: entity.ConvertOptions{To: "toml"}, want: "b = 1\n\n[a]\nc = \"x\"\n"},
+		{name: "toml to json", format: "toml", text: "b = 1\n[a]\nc = 2024-01-02T03:04:05Z\n", opts: entity.ConvertOptions{To: "json", Minify: true}, want: `{"b":1,"a":{"c":"2024-01-02T03:04:05Z"}}`},
+		{name: "xml to json", format: "xml", text: `<root id="1"><a>x</a><a>y</a><b/></root>`, opts: entity.ConvertOptions{To: "json", Minify: true}, want: `{"root":{"@id":"1","a":["x","y"],"b":""}}`},
+		{name: "null to toml", format: "json", text: object, opts: entity.ConvertOptions{To: "toml"}, err: usecase.ErrNotConvertible},
+		{name: "array to toml", format: "json", text: `[1, 2]`, opts: entity.ConvertOptions{To: "toml"}, err: usecase.ErrNotConvertible},
+		{name: "invalid xml name", format: "json", text: `{"a b": 1}`, opts: entity.ConvertOptions{To: "xml"}, err: usecase.ErrNotConvertible},
+		{name: "plaintext", format: "plaintext", text: "a", opts: entity.ConvertOptions{To: "json"}, err: usecase.ErrNotConvertible},
+		{name: "malformed json", format: "json", text: `{"a": `, opts: entity.ConvertOptions{To: "yaml"}, err: &entity.FormatError{}},
+		{name: "malformed yaml", format: "yaml", text: "a: [1\n", opts: entity.ConvertOptions{To: "json"}, err: &entity.FormatError{}},
+		{name: "malformed toml", format: "toml", text: "a = \n", opts: entity.ConvertOptions{To: "json"}, err: &entity.FormatError{}},
+		{name: "malformed xml", format: "xml", text: "<a><b></a>", opts: entity.ConvertOptions{To: "json"}, err: &entity.FormatError{}},
+		{name: "empty json", format: "json", text: "", opts: entity.ConvertOptions{To: "yaml"}, err: &entity.FormatError{}},
+		{name: "deep json", format: "json", text: strings.Repeat("[", 20000) + strings.Repeat("]", 20000), opts: entity.ConvertOptions{To: "yaml"}, err: &entity.FormatError{}},
+	}
+
+	c := NewConverter()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := c.Convert(tt.format, entity.File(tt.text), tt.opts)
+
+			var fe *entity.FormatError
+
+			switch {
+			case tt.err == nil:
+				require.NoError(t, err)
+				require.Equal(t, tt.want, string(out))
+			case errors.As(tt.err, &fe):
+				require.ErrorAs(t, err, &fe)
+				require.Equal(t, tt.format, fe.Format)
+			default:
+				require.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestConverter_Aliases(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text entity.File
+		to   string
+		want string
+		line int
+	}{
+		{name: "expand alias to json", text: entity.File("a: &a [1, 2]\nb: *a\n"), to: "json", want: `{"a":[1,2],"b":[1,2]}`},
+		{name: "expand alias to toml", text: entity.File("a: &a {x: 1}\nb: *a\n"), to: "toml", want: "[a]\nx = 1\n\n[b]\nx = 1\n"},
+		{name: "cyclic alias to json", text: entity.File("a: &a [1, *a]\n"), to: "json", line: 1},
+		{name: "cyclic alias to toml", text: entity.File("a: &a {b: *a}\n"), to: "toml", line: 1},
+		{name: "cyclic alias to xml", text: entity.File("a: &a\n  b: *a\n"), to: "xml", line: 2},
+		{name: "cyclic alias to yaml", text: entity.File("a: &a [1, *a]\n"), to: "yaml", line: 1},
+		{name: "nested cyclic alias", text: entity.File("a: &a\n  - b: &b\n      c: [*a]\n"), to: "json", line: 3},
+		{name: "aliases expand too much", text: laughs(8), to: "json", line: 5},
+	}
+
+	c := NewConverter()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			out, err := c.Convert("yaml", tt.text, entity.ConvertOptions{To: tt.to, Minify: tt.to == "json"})
+			if tt.line == 0 {
+				require.NoError(t, err)
+				require.Equal(t, tt.want, string(out))
+
+				return
+			}
+
+			var fe *entity.FormatError
+			require.ErrorAs(t, err, &fe)
+			require.Equal(t, "yaml", fe.Format)
+			require.Equal(t, tt.line, fe.Line)
+		})
+	}
+}
@@ -0,0 +1,290 @@
+// Package formats implements detection, validation, conversion and querying of paste formats.
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+
+	"github.com/BurntSushi/toml"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// sampleSize is a max size of a text prefix checked by language heuristics.
+	sampleSize = 64 << 10
+	// minConfidence is a min score of a language or YAML, texts with lower scores are plain texts.
+	minConfidence = 0.3
+	// plaintextConfidence is a confidence of texts that match nothing.
+	plaintextConfidence = 0.5
+)
+
+// Confidences of structured formats. A successful parse is a strong signal,
+// but YAML accepts almost any text with colons, so its confidence is lowered by
+// lines that do not look like YAML.
+const (
+	jsonConfidence = 1
+	xmlConfidence  = 0.95
+	htmlConfidence = 0.9
+	tomlConfidence = 0.9
+	yamlConfidence = 0.8
+)
+
+var _ usecase.FormatDetector = &Detector{}
+
+// Detector guesses formats of texts.
+type Detector struct{}
+
+func NewDetector() *Detector {
+	return &Detector{}
+}
+
+// Detect guesses a format of the text.
+//
+// Structured formats are detected by parsing the text: JSON, XML and TOML are
+// checked first, then heuristics for common programming languages compete with YAML,
+// as YAML parses most of source codes. Texts that match nothing are plaintext.
+func (d *Detector) Detect(text entity.File) entity.FormatDetection {
+	text = bytes.TrimSpace(text)
+	if len(text) == 0 {
+		return entity.FormatDetection{Format: entity.FormatPlaintext, Confidence: plaintextConfidence}
+	}
+
+	switch {
+	case isJSON(text):
+		return entity.FormatDetection{Format: "json", Confidence: jsonConfidence}
+	case isHTML(text):
+		return entity.FormatDetection{Format: "html", Confidence: htmlConfidence}
+	case isXML(text):
+		return entity.FormatDetection{Format: "xml", Confidence: xmlConfidence}
+	case isTOML(text):
+		return entity.FormatDetection{Format: "toml", Confidence: tomlConfidence}
+	}
+
+	best := detectLanguage(text)
+
+	if score := yamlScore(text); score > best.Confidence {
+		best = entity.FormatDetection{Format: "yaml", Confidence: score}
+	}
+
+	if best.Confidence >= minConfidence {
+		return best
+	}
+
+	return entity.FormatDetection{Format: entity.FormatPlaintext, Confidence: plaintextConfidence}
+}
+
+// isJSON reports whether the text is a JSON object or array,
+// bare JSON values are mostly plain texts.
+func isJSON(text []byte) bool {
+	return (text[0] == '{' || text[0] == '[') && json.Valid(text)
+}
+
+var htmlRe = regexp.MustCompile(`(?i)^(<!--.*?-->\s*)*<(!doctype html|html)[\s>]`)
+
+// isHTML reports whether the text is a HTML document, HTML is rarely a well-formed XML.
+func isHTML(text []byte) bool {
+	return htmlRe.Match(text)
+}
+
+// isXML reports whether the text is a well-formed XML document.
+func isXML(text []byte) bool {
+	if text[0] != '<' {
+		return false
+	}
+
+	var (
+		dec  = xml.NewDecoder(bytes.NewReader(text))
+		root bool
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return root
+		}
+
+		if err != nil {
+			return false
+		}
+
+		if _, ok := tok.(xml.StartElement); ok {
+			root = true
+		}
+	}
+}
+
+// isTOML reports whether the text is a TOML document with at least one key.
+func isTOML(text []byte) bool {
+	var v map[string]any
+
+	md, err := toml.Decode(string(text), &v)
+	if err != nil {
+		return false
+	}
+
+	return len(md.Keys()) > 0
+}
+
+var yamlLineRe = regexp.MustCompile(`^\s*(#.*|---|\.\.\.|-( .*)?|(- )*([\w.\-/]+|"[^"]*"|'[^']*'):(\s.*)?)$`)
+
+// yamlScore returns a confidence of a YAML mapping or sequence, the confidence
+// is lowered by lines that do not look like keys, items or comments.
+// Any text without special characters is a YAML string, so scalars score 0.
+func yamlScore(text []byte) float64 {
+	var v any
+	if err := yaml.Unmarshal(text, &v); err != nil {
+		return 0
+	}
+
+	switch v.(type) {
+	case map[string]any, []any:
+	default:
+		return 0
+	}
+
+	var total, matched int
+
+	for _, line := range bytes.Split(text, []byte("\n")) {
+		line = bytes.TrimRight(line, " \t\r")
+		if len(line) == 0 {
+			continue
+		}
+
+		total++
+
+		if yamlLineRe.Match(line) {
+			matched++
+		}
+	}
+
+	return yamlConfidence * float64(matched) / float64(total)
+}
+
+// rule is a pattern of a programming language with its weight.
+type rule struct {
+	re     *regexp.Regexp
+	weight float64
+}
+
+// language is a programming language recognized by its rules.
+type language struct {
+	format string
+	rules  []rule
+}
+
+func newRule(pattern string, weight float64) rule {
+	return rule{re: regexp.MustCompile(pattern), weight: weight}
+}
+
+// jsRules are rules shared by JavaScript and TypeScript.
+var jsRules = []rule{
+	newRule(`\b(const|let|var) \w+ =`, 0.3),
+	newRule(`\bfunction\s*\w*\s*\(`, 0.3),
+	newRule(`\) => `, 0.2),
+	newRule(`\bconsole\.log\(`, 0.4),
+	newRule(`\brequire\(['"]`, 0.4),
+	newRule(`(?m)^(import .+ from ['"]|export (default|const|function|class) )`, 0.4),
+}
+
+// languages are checked in order, the first one wins among languages with the same score.
+// TypeScript extends JavaScript rules, so it wins only with its own rules matched.
+var languages = []language{
+	{"php", []rule{newRule(`^<\?php`, 1)}},
+	{"shell", []rule{
+		newRule(`^#!\s*/(usr/)?bin/(env )?(ba|z|k)?sh\b`, 1),
+		newRule(`(?m)^\s*(echo|export|sudo|apt-get|apt|yum|chmod|mkdir|cd|curl) `, 0.2),
+		newRule(`(?m)^\s*(if \[\[? .+ \]\]?; then|fi|done|esac)$`, 0.4),
+		newRule(`\$\{?\w+\}?`, 0.1),
+	}},
+	{"dockerfile", []rule{
+		newRule(`(?m)^FROM \S+`, 0.5),
+		newRule(`(?m)^(RUN|CMD|COPY|ADD|ENTRYPOINT|WORKDIR|EXPOSE|ENV|ARG) `, 0.3),
+	}},
+	{"go", []rule{
+		newRule(`(?m)^package \w+$`, 0.5),
+		newRule(`(?m)^import (\(|")`, 0.2),
+		newRule(`(?m)^func (\(\w+ \*?\w+\) )?\w+\(`, 0.4),
+		newRule(`\w+ := `, 0.2),
+		newRule(`\b(fmt|errors|strings)\.\w+\(`, 0.2),
+		newRule(`\bif err != nil \{`, 0.4),
+	}},
+	{"rust", []rule{
+		newRule(`(?m)^\s*(pub )?fn \w+(<.*>)?\(`, 0.4),
+		newRule(`\blet mut \w+`, 0.4),
+		newRule(`(?m)^use \w+(::\w+)+`, 0.4),
+		newRule(`\b(println|vec|format)!\(`, 0.4),
+		newRule(`\bimpl\b.* \{`, 0.3),
+	}},
+	{"java", []rule{
+		newRule(`(?m)^import java\.`, 0.5),
+		newRule(`(?m)^\s*(public|private|protected)( static)?( final)? (class|interface|enum) \w+`, 0.4),
+		newRule(`\bSystem\.out\.print(ln)?\(`, 0.4),
+		newRule(`\bpublic static void main\(String`, 0.5),
+	}},
+	{"c", []rule{
+		newRule(`(?m)^#include\s*[<"]`, 0.5),
+		newRule(`(?m)^#(define|ifndef|ifdef|endif)\b`, 0.3),
+		newRule(`\bint main\(`, 0.4),
+		newRule(`\b(printf|malloc|free)\(`, 0.2),
+	}},
+	{"javascript", jsRules},
+	{"typescript", append([]rule{
+		newRule(`(?m)^\s*(export )?(interface|type) \w+(<.*>)? (=|\{)`, 0.4),
+		newRule(`\w+\??: (string|number|boolean|any|void)\b`, 0.4),
+	}, jsRules...)},
+	{"python", []rule{
+		newRule(`(?m)^\s*def \w+\(.*\)( -> .+)?:$`, 0.5),
+		newRule(`(?m)^\s*class \w+(\(.*\))?:$`, 0.4),
+		newRule(`(?m)^(from [\w.]+ )?import \w+`, 0.2),
+		newRule(`\bself\.\w+`, 0.2),
+		newRule(`\bif __name__ == ['"]__main__['"]:`, 0.5),
+		newRule(`(?m)^\s*(elif .+|else|try|except( \w+)?( as \w+)?):$`, 0.3),
+	}},
+	{"sql", []rule{
+		newRule(`(?is)\bselect\b.+\bfrom\b`, 0.5),
+		newRule(`(?i)\b(insert into|create table|alter table|drop table|delete from)\b`, 0.6),
+		newRule(`(?i)\bupdate \w+ set\b`, 0.5),
+		newRule(`(?i)\b(where|join|group by|order by)\b`, 0.1),
+	}},
+	{"markdown", []rule{
+		newRule(`(?m)^#{1,6} \S`, 0.3),
+		newRule("(?m)^```", 0.3),
+		newRule(`\[[^\]]+\]\([^)\s]+\)`, 0.3),
+		newRule(`(?m)^\s*([-*]|\d+\.) \S`, 0.1),
+		newRule(`\*\*[^*]+\*\*`, 0.1),
+	}},
+}
+
+// detectLanguage returns a programming language with the highest score,
+// a score is a sum of weights of matched rules capped by 1.
+func detectLanguage(text []byte) entity.FormatDetection {
+	if len(text) > sampleSize {
+		text = text[:sampleSize]
+	}
+
+	var best entity.FormatDetection
+
+	for _, lang := range languages {
+		var score float64
+
+		for _, rl := range lang.rules {
+			if rl.re.Match(text) {
+				score += rl.weight
+			}
+		}
+
+		score = min(score, 1)
+
+		if score > best.Confidence {
+			best = entity.FormatDetection{Format: lang.format, Confidence: score}
+		}
+	}
+
+	return best
+}
@@ -0,0 +1,60 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetector_Detect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{name: "empty", text: "", want: entity.FormatPlaintext},
+		{name: "spaces", text: " \n\t\n", want: entity.FormatPlaintext},
+		{name: "json object", text: `{"name": "web", "replicas": 2}`, want: "json"},
+		{name: "json array", text: "\n[1, 2, 3]\n", want: "json"},
+		{name: "bare json value", text: `42`, want: entity.FormatPlaintext},
+		{name: "broken json", text: `{"name": "web",}`, want: entity.FormatPlaintext},
+		{name: "xml", text: `<?xml version="1.0"?><config><name>web</name></config>`, want: "xml"},
+		{name: "xml without root", text: `<?xml version="1.0"?>`, want: entity.FormatPlaintext},
+		{name: "html", text: "<!DOCTYPE html>\n<html><body><p>Hi<br></body></html>", want: "html"},
+		{name: "toml", text: "title = \"config\"\n\n[server]\nport = 8080\n", want: "toml"},
+		{name: "yaml", text: "name: web\nreplicas: 2\nports:\n  - 80\n  - 443\n", want: "yaml"},
+		{name: "yaml with comments", text: "# config\nname: web # the name\n---\nname: api\n", want: "yaml"},
+		{name: "go", text: "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n", want: "go"},
+		{name: "python", text: "import os\n\n\ndef main():\n    print(os.getcwd())\n\n\nif __name__ == '__main__':\n    main()\n", want: "python"},
+		{name: "shell", text: "#!/bin/bash\necho \"$HOME\"\n", want: "shell"},
+		{name: "php", text: "<?php\necho 'hi';\n", want: "php"},
+		{name: "dockerfile", text: "FROM golang:1.21\nWORKDIR /app\nRUN go build ./...\n", want: "dockerfile"},
+		{name: "sql", text: "SELECT id, name FROM users WHERE id = 1;", want: "sql"},
+		{name: "typescript", text: "interface User {\n  name: string;\n}\nconst user: User = { name: 'a' };\n", want: "typescript"},
+		{name: "markdown", text: "# Title\n\nSee [docs](https://example.com).\n\n```go\nfmt.Println()\n```\n", want: "markdown"},
+		{name: "prose", text: "Just some words without any structure at all.", want: entity.FormatPlaintext},
+		{name: "prose with a colon", text: "Note: this is a sentence.\nAnd another one, with no keys here.\nAnd a third.", want: entity.FormatPlaintext},
+		{name: "binary", text: "\x00\x01\x02\xff\xfe", want: entity.FormatPlaintext},
+		{name: "deep json", text: strings.Repeat("[", 20000) + strings.Repeat("]", 20000), want: entity.FormatPlaintext},
+		{name: "long go", text: "package main\n\nfunc main() {}\n" + strings.Repeat("// comment\n", sampleSize/10), want: "go"},
+	}
+
+	d := NewDetector()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := d.Detect(entity.File(tt.text))
+			require.Equal(t, tt.want, got.Format)
+			require.Greater(t, got.Confidence, 0.0)
+			require.LessOrEqual(t, got.Confidence, 1.0)
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package formats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+var _ usecase.FormatQuerier = &Querier{}
+
+// Querier evaluates path expressions against texts of structured formats.
+//
+// Expressions are a subset of jq: paths such as .spec.containers[0].image,
+// iteration with .[], slices, recursion with .., pipes, commas, comparisons,
+// and, or, not, select, map, keys, length and type. Expressions starting
+// with $ are JSONPath, such as $..containers[?(@.name == 'app')].image.
+//
+// Queries are limited by the evaluation time and the size of the results,
+// so hostile expressions can not tie up the server.
+type Querier struct {
+	c *Converter
+	// timeout is a max time of a query evaluation.
+	timeout time.Duration
+	// maxOutput is a max size of the results in bytes.
+	maxOutput int
+}
+
+func NewQuerier(timeout time.Duration, maxOutput int) *Querier {
+	return &Querier{c: NewConverter(), timeout: timeout, maxOutput: maxOutput}
+}
+
+// Query evaluates the expression against the text of the format and returns
+// matched values as JSON in the order of the text.
+//
+// Returns *entity.QueryError if the expression is invalid, *entity.FormatError
+// if the text does not match its format, usecase.ErrNotQueryable if the format
+// is not structured and usecase.ErrQueryLimit if the query exceeds the limits.
+func (q *Querier) Query(ctx context.Context, format string, text entity.File, expr string) ([]json.RawMessage, error) {
+	switch format {
+	case "json", "yaml", "toml", "xml":
+	default:
+		return nil, fmt.Errorf("%w: %s is not a structured format", usecase.ErrNotQueryable, format)
+	}
+
+	tree, path, err := parseQuery(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := q.c.parse(format, text)
+	if err != nil {
+		// An empty text has no values to match.
+		if errors.Is(err, usecase.ErrNotConvertible) {
+			return []json.RawMessage{}, nil
+		}
+
+		return nil, err
+	}
+
+	qctx, cancel := context.WithTimeout(ctx, q.timeout)
+	defer cancel()
+
+	var (
+		root = resolve(doc.Content[0])
+		e    = &evaluator{ctx: qctx, root: root, path: path}
+	)
+
+	values, err := tree.eval(e, root)
+	if err != nil {
+		return nil, q.limitError(ctx, qctx, err)
+	}
+
+	results := make([]json.RawMessage, 0, len(values))
+	size := 0
+
+	for _, v := range values {
+		if size += jsonSize(v, q.maxOutput-size+1); size > q.maxOutput {
+			return nil, fmt.Errorf("%w: the results are larger than %d bytes", usecase.ErrQueryLimit, q.maxOutput)
+		}
+
+		var b bytes.Buffer
+
+		if err := writeJSONValue(&b, v); err != nil {
+			return nil, fmt.Errorf("%w: %w", usecase.ErrNotQueryable, err)
+		}
+
+		results = append(results, b.Bytes())
+	}
+
+	return results, nil
+}
+
+// limitError returns usecase.ErrQueryLimit if the query is stopped by its timeout,
+// errors of the request context are returned as is.
+func (q *Querier) limitError(ctx, qctx context.Context, err error) error {
+	if ctx.Err() == nil && errors.Is(err, qctx.Err()) {
+		return fmt.Errorf("%w: the query takes longer than %s", usecase.ErrQueryLimit, q.timeout)
+	}
+
+	return err
+}
+
+// jsonSize returns an estimated size of the node written as JSON.
+// It stops once the size exceeds the limit, as aliases may expand
+// a small YAML text into a huge value.
+func jsonSize(n *yaml.Node, limit int) int {
+	var (
+		size  int
+		stack = []*yaml.Node{n}
+	)
+
+	for len(stack) > 0 && size <= limit {
+		n := resolve(stack[len(stack)-1])
+		stack = stack[:len(stack)-1]
+
+		// Quotes, separators and brackets are counted as two bytes per node.
+		size += len(n.Value) + 2
+		stack = append(stack, n.Content...)
+	}
+
+	return size
+}
@@ -0,0 +1,629 @@
+package formats
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+// maxQuerySteps is a max number of values produced while a query is evaluated,
+// it bounds queries that multiply values, such as nested recursions and commas.
+const maxQuerySteps = 1 << 20
+
+// query is a node of a query tree, it maps an input value to output values.
+type query interface {
+	eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error)
+}
+
+// evaluator keeps the state of a query evaluation.
+type evaluator struct {
+	ctx  context.Context
+	root *yaml.Node
+	// path is true for JSONPath queries.
+	path  bool
+	steps int
+}
+
+// step counts produced values and stops the evaluation on the limits.
+func (e *evaluator) step(n int) error {
+	e.steps += n
+	if e.steps > maxQuerySteps {
+		return fmt.Errorf("%w: the query produces more than %d values", usecase.ErrQueryLimit, maxQuerySteps)
+	}
+
+	return e.ctx.Err()
+}
+
+// typeError returns an error of the value of unexpected type in jq queries.
+// JSONPath queries skip such values.
+func (e *evaluator) typeError(pos int, format string, args ...any) ([]*yaml.Node, error) {
+	if e.path {
+		return nil, nil
+	}
+
+	return nil, &entity.QueryError{Column: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+type identityQuery struct{}
+
+func (identityQuery) eval(_ *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	return []*yaml.Node{in}, nil
+}
+
+type rootQuery struct{}
+
+func (rootQuery) eval(e *evaluator, _ *yaml.Node) ([]*yaml.Node, error) {
+	return []*yaml.Node{e.root}, nil
+}
+
+type literalQuery struct {
+	value *yaml.Node
+}
+
+func (q literalQuery) eval(_ *evaluator, _ *yaml.Node) ([]*yaml.Node, error) {
+	return []*yaml.Node{q.value}, nil
+}
+
+type fieldQuery struct {
+	name string
+	pos  int
+}
+
+func (q fieldQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	switch {
+	case in.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(in.Content); i += 2 {
+			if k := resolve(in.Content[i]); k.Kind == yaml.ScalarNode && k.Value == q.name {
+				return []*yaml.Node{resolve(in.Content[i+1])}, nil
+			}
+		}
+	case isNull(in):
+	default:
+		return e.typeError(q.pos, "cannot index %s with %q", typeName(in), q.name)
+	}
+
+	if e.path {
+		return nil, nil
+	}
+
+	return []*yaml.Node{nullNode()}, nil
+}
+
+type indexQuery struct {
+	index int
+	pos   int
+}
+
+func (q indexQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	switch {
+	case in.Kind == yaml.SequenceNode:
+		i := q.index
+		if i < 0 {
+			i += len(in.Content)
+		}
+
+		if i >= 0 && i < len(in.Content) {
+			return []*yaml.Node{resolve(in.Content[i])}, nil
+		}
+	case isNull(in):
+	default:
+		return e.typeError(q.pos, "cannot index %s with number", typeName(in))
+	}
+
+	if e.path {
+		return nil, nil
+	}
+
+	return []*yaml.Node{nullNode()}, nil
+}
+
+type sliceQuery struct {
+	from, to *int
+	pos      int
+}
+
+func (q sliceQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	switch {
+	case in.Kind == yaml.SequenceNode:
+	case isNull(in) && !e.path:
+		return []*yaml.Node{nullNode()}, nil
+	default:
+		return e.typeError(q.pos, "cannot slice %s", typeName(in))
+	}
+
+	var (
+		n        = len(in.Content)
+		from, to = 0, n
+	)
+
+	if q.from != nil {
+		from = *q.from
+	}
+
+	if q.to != nil {
+		to = *q.to
+	}
+
+	bound := func(i int) int {
+		if i < 0 {
+			i += n
+		}
+
+		return min(max(i, 0), n)
+	}
+
+	from, to = bound(from), bound(to)
+	if to < from {
+		to = from
+	}
+
+	out := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: in.Content[from:to]}
+
+	if e.path {
+		// JSONPath slices select items instead of making a new array.
+		return iterateQuery{}.eval(e, out)
+	}
+
+	return []*yaml.Node{out}, nil
+}
+
+type iterateQuery struct {
+	pos int
+}
+
+func (q iterateQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	var out []*yaml.Node
+
+	switch in.Kind {
+	case yaml.MappingNode:
+		for i := 1; i < len(in.Content); i += 2 {
+			out = append(out, resolve(in.Content[i]))
+		}
+	case yaml.SequenceNode:
+		for _, item := range in.Content {
+			out = append(out, resolve(item))
+		}
+	default:
+		return e.typeError(q.pos, "cannot iterate over %s", typeName(in))
+	}
+
+	return out, e.step(len(out))
+}
+
+// recurseQuery returns the input and all of its descendants.
+type recurseQuery struct{}
+
+func (q recurseQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	var (
+		out   []*yaml.Node
+		stack = []*yaml.Node{in}
+	)
+
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		out = append(out, n)
+
+		if err := e.step(1); err != nil {
+			return nil, err
+		}
+
+		switch n.Kind {
+		case yaml.MappingNode:
+			// Children are pushed in reverse to be visited in the document order.
+			for i := len(n.Content) - 1; i > 0; i -= 2 {
+				stack = append(stack, resolve(n.Content[i]))
+			}
+		case yaml.SequenceNode:
+			for i := len(n.Content) - 1; i >= 0; i-- {
+				stack = append(stack, resolve(n.Content[i]))
+			}
+		}
+	}
+
+	return out, nil
+}
+
+type pipeQuery struct {
+	left, right query
+}
+
+func (q pipeQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	left, err := q.left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*yaml.Node
+
+	for _, n := range left {
+		right, err := q.right.eval(e, n)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := e.step(len(right)); err != nil {
+			return nil, err
+		}
+
+		out = append(out, right...)
+	}
+
+	return out, nil
+}
+
+type commaQuery struct {
+	left, right query
+}
+
+func (q commaQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	left, err := q.left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := q.right.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), e.step(len(left) + len(right))
+}
+
+// tryQuery suppresses errors of value types.
+type tryQuery struct {
+	q query
+}
+
+func (q tryQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	out, err := q.q.eval(e, in)
+
+	var qe *entity.QueryError
+	if errors.As(err, &qe) {
+		return nil, nil
+	}
+
+	return out, err
+}
+
+// collectQuery collects outputs of the query into an array.
+type collectQuery struct {
+	q query
+}
+
+func (q collectQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	out := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+	if q.q != nil {
+		items, err := q.q.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Content = items
+	}
+
+	return []*yaml.Node{out}, nil
+}
+
+type selectQuery struct {
+	cond query
+}
+
+func (q selectQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	conds, err := q.cond.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*yaml.Node
+
+	for _, c := range conds {
+		if truthy(c) {
+			out = append(out, in)
+		}
+	}
+
+	return out, nil
+}
+
+type notQuery struct{}
+
+func (notQuery) eval(_ *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	return []*yaml.Node{boolNode(!truthy(in))}, nil
+}
+
+type logicQuery struct {
+	and         bool
+	left, right query
+}
+
+func (q logicQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	left, err := q.left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []*yaml.Node
+
+	for _, l := range left {
+		// The right side is evaluated only if the left one does not decide the result.
+		if truthy(l) != q.and {
+			out = append(out, boolNode(!q.and))
+
+			continue
+		}
+
+		right, err := q.right.eval(e, in)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range right {
+			out = append(out, boolNode(truthy(r)))
+		}
+	}
+
+	return out, e.step(len(out))
+}
+
+type compareQuery struct {
+	op          string
+	left, right query
+}
+
+func (q compareQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	left, err := q.left.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	right, err := q.right.eval(e, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := e.step(len(left) * len(right)); err != nil {
+		return nil, err
+	}
+
+	out := make([]*yaml.Node, 0, len(left)*len(right))
+
+	for _, r := range right {
+		for _, l := range left {
+			c, err := compare(l, r, e.step)
+			if err != nil {
+				return nil, err
+			}
+
+			var ok bool
+
+			switch q.op {
+			case "==":
+				ok = c == 0
+			case "!=":
+				ok = c != 0
+			case "<":
+				ok = c < 0
+			case "<=":
+				ok = c <= 0
+			case ">":
+				ok = c > 0
+			case ">=":
+				ok = c >= 0
+			}
+
+			out = append(out, boolNode(ok))
+		}
+	}
+
+	return out, nil
+}
+
+type builtinQuery struct {
+	name string
+	pos  int
+}
+
+func (q builtinQuery) eval(e *evaluator, in *yaml.Node) ([]*yaml.Node, error) {
+	switch q.name {
+	case "type":
+		return []*yaml.Node{{Kind: yaml.ScalarNode, Tag: "!!str", Value: typeName(in)}}, nil
+	case "length":
+		switch in.Kind {
+		case yaml.MappingNode:
+			return []*yaml.Node{intNode(len(in.Content) / 2)}, nil
+		case yaml.SequenceNode:
+			return []*yaml.Node{intNode(len(in.Content))}, nil
+		}
+
+		switch typeName(in) {
+		case "null":
+			return []*yaml.Node{intNode(0)}, nil
+		case "string":
+			return []*yaml.Node{intNode(utf8.RuneCountInString(in.Value))}, nil
+		case "number":
+			f, _ := number(in)
+
+			return []*yaml.Node{numberNode(strconv.FormatFloat(math.Abs(f), 'f', -1, 64))}, nil
+		}
+	case "keys":
+		out := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+		switch in.Kind {
+		case yaml.MappingNode:
+			keys := make([]string, 0, len(in.Content)/2)
+			for i := 0; i < len(in.Content); i += 2 {
+				keys = append(keys, resolve(in.Content[i]).Value)
+			}
+
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				out.Content = append(out.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k})
+			}
+
+			return []*yaml.Node{out}, nil
+		case yaml.SequenceNode:
+			for i := range in.Content {
+				out.Content = append(out.Content, intNode(i))
+			}
+
+			return []*yaml.Node{out}, nil
+		}
+	}
+
+	return e.typeError(q.pos, "%s has no %s", typeName(in), q.name)
+}
+
+// typeName returns a JSON type name of the node.
+func typeName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+
+	switch n.ShortTag() {
+	case "!!null":
+		return "null"
+	case "!!bool":
+		return "boolean"
+	case "!!int", "!!float":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
+func isNull(n *yaml.Node) bool {
+	return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null"
+}
+
+// truthy reports whether the node is neither false nor null.
+func truthy(n *yaml.Node) bool {
+	switch typeName(n) {
+	case "null":
+		return false
+	case "boolean":
+		var b bool
+
+		return n.Decode(&b) == nil && b
+	default:
+		return true
+	}
+}
+
+func number(n *yaml.Node) (float64, bool) {
+	var f float64
+	if err := n.Decode(&f); err != nil {
+		return 0, false
+	}
+
+	return f, true
+}
+
+// typeOrder is an order of JSON types in comparisons, as in jq.
+var typeOrder = map[string]int{"null": 0, "boolean": 1, "number": 2, "string": 3, "array": 4, "object": 5}
+
+// compare compares values as jq does: values of different types are ordered by type,
+// arrays are compared item by item and objects by sorted keys and then by values.
+// Every compared value is counted by step, which stops the comparison on its error.
+func compare(a, b *yaml.Node, step func(int) error) (int, error) {
+	if err := step(1); err != nil {
+		return 0, err
+	}
+
+	a, b = resolve(a), resolve(b)
+
+	ta, tb := typeName(a), typeName(b)
+	if ta != tb {
+		return typeOrder[ta] - typeOrder[tb], nil
+	}
+
+	switch ta {
+	case "null":
+		return 0, nil
+	case "boolean":
+		return compareBools(truthy(a), truthy(b)), nil
+	case "number":
+		fa, _ := number(a)
+		fb, _ := number(b)
+
+		return cmp.Compare(fa, fb), nil
+	case "string":
+		return cmp.Compare(a.Value, b.Value), nil
+	case "array":
+		for i := 0; i < len(a.Content) && i < len(b.Content); i++ {
+			if c, err := compare(a.Content[i], b.Content[i], step); c != 0 || err != nil {
+				return c, err
+			}
+		}
+
+		return len(a.Content) - len(b.Content), nil
+	default:
+		ka, kb := sortedPairs(a), sortedPairs(b)
+
+		for i := 0; i < len(ka) && i < len(kb); i++ {
+			if c := cmp.Compare(ka[i][0].Value, kb[i][0].Value); c != 0 {
+				return c, nil
+			}
+		}
+
+		if len(ka) != len(kb) {
+			return len(ka) - len(kb), nil
+		}
+
+		for i := range ka {
+			if c, err := compare(ka[i][1], kb[i][1], step); c != 0 || err != nil {
+				return c, err
+			}
+		}
+
+		return 0, nil
+	}
+}
+
+func sortedPairs(n *yaml.Node) [][2]*yaml.Node {
+	ps := make([][2]*yaml.Node, 0, len(n.Content)/2)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		ps = append(ps, [2]*yaml.Node{resolve(n.Content[i]), resolve(n.Content[i+1])})
+	}
+
+	sort.Slice(ps, func(i, j int) bool { return ps[i][0].Value < ps[j][0].Value })
+
+	return ps
+}
+
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case b:
+		return -1
+	default:
+		return 1
+	}
+}
+
+func nullNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+func boolNode(v bool) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}
+}
+
+func intNode(v int) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!int", Value: strconv.Itoa(v)}
+}
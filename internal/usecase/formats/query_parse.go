@@ -0,0 +1,737 @@
+package formats
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// maxExprLen is a max length of a query expression in bytes.
+	maxExprLen = 1024
+	// maxExprDepth is a max nesting of parentheses and brackets of a query expression.
+	maxExprDepth = 32
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokDot
+	tokRecurse
+	tokLBracket
+	tokRBracket
+	tokLParen
+	tokRParen
+	tokPipe
+	tokComma
+	tokColon
+	tokQuestion
+	tokStar
+	tokRoot
+	tokCurrent
+	tokNot
+	tokAnd
+	tokOr
+	tokCmp
+	tokIdent
+	tokString
+	tokNumber
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// pos is a 1-based column of the token in the expression.
+	pos int
+}
+
+// lex splits the expression into tokens.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+
+	for i := 0; i < len(expr); {
+		var (
+			c   = expr[i]
+			pos = utf8.RuneCountInString(expr[:i]) + 1
+		)
+
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+
+			continue
+		case strings.HasPrefix(expr[i:], ".."):
+			tokens = append(tokens, token{kind: tokRecurse, text: "..", pos: pos})
+			i += 2
+
+			continue
+		case strings.HasPrefix(expr[i:], "&&"):
+			tokens = append(tokens, token{kind: tokAnd, text: "&&", pos: pos})
+			i += 2
+
+			continue
+		case strings.HasPrefix(expr[i:], "||"):
+			tokens = append(tokens, token{kind: tokOr, text: "||", pos: pos})
+			i += 2
+
+			continue
+		}
+
+		if op := cmpOperator(expr[i:]); op != "" {
+			tokens = append(tokens, token{kind: tokCmp, text: op, pos: pos})
+			i += len(op)
+
+			continue
+		}
+
+		if kind, ok := punctuation[c]; ok {
+			tokens = append(tokens, token{kind: kind, text: string(c), pos: pos})
+			i++
+
+			continue
+		}
+
+		switch {
+		case c == '"' || c == '\'':
+			s, n, err := lexString(expr[i:])
+			if err != nil {
+				return nil, &entity.QueryError{Column: pos, Msg: err.Error()}
+			}
+
+			tokens = append(tokens, token{kind: tokString, text: s, pos: pos})
+			i += n
+		case c == '-' || isDigit(c):
+			n := lexNumber(expr[i:])
+			if n == 0 {
+				return nil, &entity.QueryError{Column: pos, Msg: fmt.Sprintf("unexpected character %q", c)}
+			}
+
+			tokens = append(tokens, token{kind: tokNumber, text: expr[i : i+n], pos: pos})
+			i += n
+		default:
+			n := lexIdent(expr[i:])
+			if n == 0 {
+				r, _ := utf8.DecodeRuneInString(expr[i:])
+
+				return nil, &entity.QueryError{Column: pos, Msg: fmt.Sprintf("unexpected character %q", r)}
+			}
+
+			tokens = append(tokens, token{kind: tokIdent, text: expr[i : i+n], pos: pos})
+			i += n
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: utf8.RuneCountInString(expr) + 1}), nil
+}
+
+var punctuation = map[byte]tokenKind{
+	'.': tokDot,
+	'[': tokLBracket,
+	']': tokRBracket,
+	'(': tokLParen,
+	')': tokRParen,
+	'|': tokPipe,
+	',': tokComma,
+	':': tokColon,
+	'?': tokQuestion,
+	'*': tokStar,
+	'$': tokRoot,
+	'@': tokCurrent,
+	'!': tokNot,
+}
+
+func cmpOperator(s string) string {
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+
+	return ""
+}
+
+// lexString returns the unquoted string and the length of the quoted one.
+// Double quoted strings have JSON escapes, single quoted ones are JSONPath strings
+// where only the quote and the backslash are escaped.
+func lexString(s string) (string, int, error) {
+	quote := s[0]
+
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case quote:
+			if quote == '"' {
+				v, err := strconv.Unquote(s[:i+1])
+				if err != nil {
+					return "", 0, fmt.Errorf("invalid string %s", s[:i+1])
+				}
+
+				return v, i + 1, nil
+			}
+
+			r := strings.NewReplacer(`\'`, `'`, `\\`, `\`)
+
+			return r.Replace(s[1:i]), i + 1, nil
+		}
+	}
+
+	return "", 0, fmt.Errorf("unterminated string")
+}
+
+func lexNumber(s string) int {
+	i := 0
+	if s[0] == '-' {
+		i++
+	}
+
+	start := i
+
+	for i < len(s) && (isDigit(s[i]) || s[i] == '.' && i+1 < len(s) && isDigit(s[i+1])) {
+		i++
+	}
+
+	if i == start {
+		return 0
+	}
+
+	return i
+}
+
+// lexIdent returns the length of the identifier, identifiers may contain dashes
+// after the first character, as keys of manifests often do.
+func lexIdent(s string) int {
+	n := 0
+
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !(r == '_' || unicode.IsLetter(r) || n > 0 && (unicode.IsDigit(r) || r == '-')) {
+			break
+		}
+
+		n += size
+	}
+
+	return n
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// parser parses query expressions of a jq subset and JSONPath into a query tree.
+//
+// The grammar of both languages is shared, so JSONPath filters may use jq
+// operators and the other way around:
+//
+//	pipe    = comma { "|" comma }
+//	comma   = or { "," or }
+//	or      = and { ("or" | "||") and }
+//	and     = unary { ("and" | "&&") unary }
+//	unary   = "!" unary | compare
+//	compare = postfix [ ("==" | "!=" | "<" | "<=" | ">" | ">=") postfix ]
+//	postfix = primary { suffix }
+//	primary = "." [ key ] | ".." | "$" | "@" | literal | "(" pipe ")" | "[" [ pipe ] "]" | function
+//	suffix  = "." ( key | "*" ) | ".." ( key | "*" | bracket ) | bracket | "?"
+//	bracket = "[" ( "]" | "*" "]" | "?" "(" pipe ")" "]" | subscript { "," subscript } "]" )
+//
+// Expressions starting with $ are JSONPath, missing keys and values of other
+// types yield nothing there, while jq yields null for missing keys.
+type parser struct {
+	tokens []token
+	i      int
+	depth  int
+}
+
+// parseQuery returns a query tree of the expression and whether it is a JSONPath.
+func parseQuery(expr string) (query, bool, error) {
+	if len(expr) > maxExprLen {
+		return nil, false, &entity.QueryError{Msg: fmt.Sprintf("the expression is longer than %d bytes", maxExprLen)}
+	}
+
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, false, err
+	}
+
+	p := &parser{tokens: tokens}
+
+	q, err := p.pipe()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, false, p.unexpected(tok)
+	}
+
+	return q, tokens[0].kind == tokRoot, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.i]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.i]
+	if tok.kind != tokEOF {
+		p.i++
+	}
+
+	return tok
+}
+
+func (p *parser) accept(kind tokenKind) bool {
+	if p.peek().kind == kind {
+		p.i++
+
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) acceptIdent(names ...string) bool {
+	tok := p.peek()
+	if tok.kind != tokIdent {
+		return false
+	}
+
+	for _, name := range names {
+		if tok.text == name {
+			p.i++
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *parser) expect(kind tokenKind, what string) error {
+	if tok := p.next(); tok.kind != kind {
+		return &entity.QueryError{Column: tok.pos, Msg: fmt.Sprintf("expected %s", what)}
+	}
+
+	return nil
+}
+
+func (p *parser) unexpected(tok token) error {
+	if tok.kind == tokEOF {
+		return &entity.QueryError{Column: tok.pos, Msg: "unexpected end of expression"}
+	}
+
+	return &entity.QueryError{Column: tok.pos, Msg: fmt.Sprintf("unexpected %q", tok.text)}
+}
+
+// nest guards recursion of nested expressions.
+func (p *parser) nest() error {
+	p.depth++
+	if p.depth > maxExprDepth {
+		return &entity.QueryError{Column: p.peek().pos, Msg: "the expression is nested too deeply"}
+	}
+
+	return nil
+}
+
+func (p *parser) pipe() (query, error) {
+	left, err := p.comma()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokPipe) {
+		right, err := p.comma()
+		if err != nil {
+			return nil, err
+		}
+
+		left = pipeQuery{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) comma() (query, error) {
+	left, err := p.or()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokComma) {
+		right, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+
+		left = commaQuery{left, right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) or() (query, error) {
+	left, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokOr) || p.acceptIdent("or") {
+		right, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+
+		left = logicQuery{and: false, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) and() (query, error) {
+	left, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokAnd) || p.acceptIdent("and") {
+		right, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = logicQuery{and: true, left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) unary() (query, error) {
+	if p.accept(tokNot) {
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+
+		q, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+
+		p.depth--
+
+		return pipeQuery{q, notQuery{}}, nil
+	}
+
+	return p.compare()
+}
+
+func (p *parser) compare() (query, error) {
+	left, err := p.postfix()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind == tokCmp {
+		p.next()
+
+		right, err := p.postfix()
+		if err != nil {
+			return nil, err
+		}
+
+		return compareQuery{op: tok.text, left: left, right: right}, nil
+	}
+
+	return left, nil
+}
+
+func (p *parser) postfix() (query, error) {
+	q, err := p.primary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := p.peek()
+
+		switch tok.kind {
+		case tokDot:
+			p.next()
+
+			if p.peek().kind == tokLBracket {
+				continue
+			}
+
+			step, err := p.key(tok)
+			if err != nil {
+				return nil, err
+			}
+
+			q = pipeQuery{q, step}
+		case tokRecurse:
+			p.next()
+
+			var step query
+
+			if p.peek().kind == tokLBracket {
+				p.next()
+
+				step, err = p.bracket()
+			} else {
+				step, err = p.key(tok)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			q = pipeQuery{pipeQuery{q, recurseQuery{}}, step}
+		case tokLBracket:
+			p.next()
+
+			step, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+
+			q = pipeQuery{q, step}
+		case tokQuestion:
+			p.next()
+
+			q = tryQuery{q}
+		default:
+			return q, nil
+		}
+	}
+}
+
+// key parses a key or a wildcard after a dot.
+func (p *parser) key(dot token) (query, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokIdent, tokString:
+		return fieldQuery{name: tok.text, pos: tok.pos}, nil
+	case tokStar:
+		return iterateQuery{pos: tok.pos}, nil
+	default:
+		return nil, &entity.QueryError{Column: dot.pos, Msg: "expected a key after " + dot.text}
+	}
+}
+
+func (p *parser) primary() (query, error) {
+	tok := p.next()
+
+	switch tok.kind {
+	case tokDot:
+		switch p.peek().kind {
+		case tokIdent, tokString, tokStar:
+			return p.key(tok)
+		}
+
+		return identityQuery{}, nil
+	case tokRecurse:
+		return recurseQuery{}, nil
+	case tokRoot:
+		return rootQuery{}, nil
+	case tokCurrent:
+		return identityQuery{}, nil
+	case tokString:
+		return literalQuery{&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: tok.text}}, nil
+	case tokNumber:
+		return literalQuery{numberNode(tok.text)}, nil
+	case tokLParen:
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+
+		q, err := p.pipe()
+		if err != nil {
+			return nil, err
+		}
+
+		p.depth--
+
+		return q, p.expect(tokRParen, `")"`)
+	case tokLBracket:
+		if p.accept(tokRBracket) {
+			return collectQuery{}, nil
+		}
+
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+
+		q, err := p.pipe()
+		if err != nil {
+			return nil, err
+		}
+
+		p.depth--
+
+		return collectQuery{q}, p.expect(tokRBracket, `"]"`)
+	case tokIdent:
+		return p.function(tok)
+	default:
+		return nil, p.unexpected(tok)
+	}
+}
+
+// function parses literals and builtin functions.
+func (p *parser) function(tok token) (query, error) {
+	switch tok.text {
+	case "true", "false":
+		return literalQuery{&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: tok.text}}, nil
+	case "null":
+		return literalQuery{&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}}, nil
+	case "not":
+		return notQuery{}, nil
+	case "length", "keys", "type":
+		return builtinQuery{name: tok.text, pos: tok.pos}, nil
+	case "select", "map":
+		if err := p.expect(tokLParen, `"("`); err != nil {
+			return nil, err
+		}
+
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+
+		arg, err := p.pipe()
+		if err != nil {
+			return nil, err
+		}
+
+		p.depth--
+
+		if err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+
+		if tok.text == "select" {
+			return selectQuery{arg}, nil
+		}
+
+		return collectQuery{pipeQuery{iterateQuery{pos: tok.pos}, arg}}, nil
+	default:
+		return nil, &entity.QueryError{Column: tok.pos, Msg: fmt.Sprintf("unknown function %q", tok.text)}
+	}
+}
+
+// bracket parses a subscript after the opening bracket.
+func (p *parser) bracket() (query, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokRBracket:
+		p.next()
+
+		return iterateQuery{pos: tok.pos}, nil
+	case tokStar:
+		p.next()
+
+		return iterateQuery{pos: tok.pos}, p.expect(tokRBracket, `"]"`)
+	case tokQuestion:
+		p.next()
+
+		if err := p.expect(tokLParen, `"("`); err != nil {
+			return nil, err
+		}
+
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+
+		cond, err := p.pipe()
+		if err != nil {
+			return nil, err
+		}
+
+		p.depth--
+
+		if err := p.expect(tokRParen, `")"`); err != nil {
+			return nil, err
+		}
+
+		return pipeQuery{iterateQuery{pos: tok.pos}, selectQuery{cond}}, p.expect(tokRBracket, `"]"`)
+	}
+
+	q, err := p.subscript()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokComma) {
+		next, err := p.subscript()
+		if err != nil {
+			return nil, err
+		}
+
+		q = commaQuery{q, next}
+	}
+
+	return q, p.expect(tokRBracket, `"]"`)
+}
+
+// subscript parses a key, an index or a slice.
+func (p *parser) subscript() (query, error) {
+	tok := p.peek()
+
+	if tok.kind == tokString {
+		p.next()
+
+		return fieldQuery{name: tok.text, pos: tok.pos}, nil
+	}
+
+	from, err := p.integer()
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.accept(tokColon) {
+		if from == nil {
+			return nil, p.unexpected(p.peek())
+		}
+
+		return indexQuery{index: *from, pos: tok.pos}, nil
+	}
+
+	to, err := p.integer()
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceQuery{from: from, to: to, pos: tok.pos}, nil
+}
+
+// integer parses an optional integer.
+func (p *parser) integer() (*int, error) {
+	tok := p.peek()
+	if tok.kind != tokNumber {
+		return nil, nil
+	}
+
+	p.next()
+
+	n, err := strconv.Atoi(tok.text)
+	if err != nil {
+		return nil, &entity.QueryError{Column: tok.pos, Msg: fmt.Sprintf("invalid index %s", tok.text)}
+	}
+
+	return &n, nil
+}
+
+func numberNode(v string) *yaml.Node {
+	tag := "!!int"
+	if strings.Contains(v, ".") {
+		tag = "!!float"
+	}
+
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v}
+}
@@ -0,0 +1,175 @@
+package formats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestQuerier_Query(t *testing.T) {
+	t.Parallel()
+
+	const text = `{"a": [1, null, {"c": "x"}], "b": {"c": 2}}`
+
+	tests := []struct {
+		name   string
+		format string
+		text   string
+		expr   string
+		want   []string
+		err    error
+	}{
+		{name: "field", format: "json", text: text, expr: ".b.c", want: []string{"2"}},
+		{name: "iterate and select", format: "json", text: text, expr: ".a[] | select(. != null)", want: []string{"1", `{"c":"x"}`}},
+		{name: "keys", format: "json", text: text, expr: ".a | keys", want: []string{"[0,1,2]"}},
+		{name: "jsonpath index", format: "json", text: text, expr: "$.a[0]", want: []string{"1"}},
+		{name: "jsonpath recursive", format: "json", text: text, expr: "$..c", want: []string{`"x"`, "2"}},
+		{name: "yaml", format: "yaml", text: "a:\n  - name: web\n  - name: api\n", expr: ".a[].name", want: []string{`"web"`, `"api"`}},
+		{name: "toml", format: "toml", text: "[server]\nport = 8080\n", expr: ".server.port", want: []string{"8080"}},
+		{name: "xml", format: "xml", text: `<root><a id="1"/></root>`, expr: `.root.a["@id"]`, want: []string{`"1"`}},
+		{name: "missing field", format: "json", text: text, expr: ".x.y", want: []string{"null"}},
+		{name: "invalid expression", format: "json", text: text, expr: ".[", err: &entity.QueryError{}},
+		{name: "type error", format: "json", text: text, expr: ".a.c", err: &entity.QueryError{}},
+		{name: "not queryable", format: "plaintext", text: "a", expr: ".", err: usecase.ErrNotQueryable},
+		{name: "malformed text", format: "json", text: `{"a": `, expr: ".", err: &entity.FormatError{}},
+		{name: "large output", format: "json", text: text, expr: "[.a[], .a[], .a[], .a[], .a[], .a[], .a[], .a[], .a[], .a[], .a[], .a[]]", err: usecase.ErrQueryLimit},
+		{name: "exploding aliases", format: "yaml", text: string(laughs(3)), expr: "[.. | .. | .. | .. | ..] | length", err: usecase.ErrQueryLimit},
+	}
+
+	q := NewQuerier(time.Second, 100)
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			results, err := q.Query(context.Background(), tt.format, entity.File(tt.text), tt.expr)
+
+			var (
+				fe *entity.FormatError
+				qe *entity.QueryError
+			)
+
+			switch {
+			case tt.err == nil:
+				require.NoError(t, err)
+			case errors.As(tt.err, &fe):
+				require.ErrorAs(t, err, &fe)
+
+				return
+			case errors.As(tt.err, &qe):
+				require.ErrorAs(t, err, &qe)
+
+				return
+			default:
+				require.ErrorIs(t, err, tt.err)
+
+				return
+			}
+
+			got := make([]string, 0, len(results))
+			for _, r := range results {
+				got = append(got, string(r))
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuerier_Timeout(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewQuerier(time.Second, 1<<20).Query(ctx, "json", entity.File(`[1, 2, 3]`), ".[]")
+	require.Error(t, err)
+}
+
+func TestQuerier_Compare(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		text    entity.File
+		expr    string
+		want    []string
+		invalid bool
+	}{
+		{name: "equal aliases", text: entity.File("a: &a {x: [1, 2]}\nb: *a\n"), expr: ".a == .b", want: []string{"true"}},
+		{name: "different objects", text: entity.File(`{"a": {"x": 1}, "b": {"x": 2}}`), expr: ".a < .b", want: []string{"true"}},
+		{name: "ordered by type", text: entity.File(`{"a": null, "b": "x"}`), expr: ".a < .b", want: []string{"true"}},
+		{name: "cyclic alias", text: entity.File("a: &a [1, *a]\n"), expr: ".a == .a", invalid: true},
+		{name: "cyclic alias in object", text: entity.File("a: &a {b: *a}\n"), expr: ".a != .a", invalid: true},
+	}
+
+	q := NewQuerier(time.Second, 1<<20)
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			format := "yaml"
+			if tt.text[0] == '{' {
+				format = "json"
+			}
+
+			results, err := q.Query(context.Background(), format, tt.text, tt.expr)
+			if tt.invalid {
+				var fe *entity.FormatError
+				require.ErrorAs(t, err, &fe)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(results))
+			for _, r := range results {
+				got = append(got, string(r))
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCompare_Steps(t *testing.T) {
+	t.Parallel()
+
+	var doc yaml.Node
+	require.NoError(t, yaml.Unmarshal(laughs(3), &doc))
+
+	var (
+		limit = errors.New("limit")
+		steps int
+		step  = func(n int) error {
+			if steps += n; steps > 1000 {
+				return limit
+			}
+
+			return nil
+		}
+		l3 = doc.Content[0].Content[7]
+	)
+
+	_, err := compare(l3, l3, step)
+	require.ErrorIs(t, err, limit)
+
+	steps = 0
+
+	c, err := compare(doc.Content[0].Content[1], doc.Content[0].Content[3], step)
+	require.NoError(t, err)
+	require.Negative(t, c)
+	require.Less(t, steps, 1000)
+}
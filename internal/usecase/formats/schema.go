@@ -0,0 +1,752 @@
+package formats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// maxSchemaDepth is a max nesting of subschemas applied to the same value, it stops recursive references.
+	maxSchemaDepth = 64
+	// maxValueDepth is a max nesting of validated values, deeper values are reported as violations.
+	maxValueDepth = 256
+	// maxSchemaSteps is a max number of subschemas applied and values compared while a text is validated.
+	maxSchemaSteps = 1 << 20
+)
+
+var _ usecase.SchemaValidator = &SchemaValidator{}
+
+// SchemaValidator validates texts of structured formats against JSON schemas.
+//
+// Schemas are json or yaml texts of the draft 2020-12 keywords that check values:
+// type, enum, const, the number, string, array and object keywords, allOf, anyOf,
+// oneOf, not, if, then, else and local $ref with JSON pointers, draft-07 items
+// arrays and additionalItems are supported as well. Annotations and format are ignored.
+type SchemaValidator struct {
+	c *Converter
+}
+
+func NewSchemaValidator() *SchemaValidator {
+	return &SchemaValidator{c: NewConverter()}
+}
+
+// Validate validates the text of the format against the schema and returns
+// violations in the order of the text, no violations mean the text is valid.
+//
+// Returns usecase.ErrSchemaNotApplicable if the text is not json, yaml or toml,
+// *entity.FormatError if the text does not match its format and usecase.ErrInvalidSchema
+// if the schema is not json or yaml, does not parse or has invalid keywords.
+func (v *SchemaValidator) Validate(schemaFormat string, schema entity.File, format string, text entity.File) ([]entity.SchemaViolation, error) {
+	switch format {
+	case "json", "yaml", "toml":
+	default:
+		return nil, fmt.Errorf("%w: %s is not json, yaml or toml", usecase.ErrSchemaNotApplicable, format)
+	}
+
+	switch schemaFormat {
+	case "json", "yaml":
+	default:
+		return nil, fmt.Errorf("%w: the schema format %s is not json or yaml", usecase.ErrInvalidSchema, schemaFormat)
+	}
+
+	sdoc, err := v.c.parse(schemaFormat, schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", usecase.ErrInvalidSchema, err)
+	}
+
+	doc, err := v.c.parse(format, text)
+	if err != nil {
+		var fe *entity.FormatError
+		if errors.As(err, &fe) {
+			return nil, err
+		}
+
+		// An empty text is a null value.
+		doc = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{nullNode()}}
+	}
+
+	sc := &schemaCheck{root: resolve(sdoc.Content[0]), regexps: make(map[string]*regexp.Regexp)}
+
+	if err := sc.validate(sc.root, resolve(doc.Content[0]), "", 0); err != nil {
+		return nil, err
+	}
+
+	return sc.violations, nil
+}
+
+// schemaCheck keeps the state of a text validation.
+type schemaCheck struct {
+	root       *yaml.Node
+	regexps    map[string]*regexp.Regexp
+	steps      int
+	violations []entity.SchemaViolation
+}
+
+func (sc *schemaCheck) violate(ptr, keyword, format string, args ...any) {
+	sc.violations = append(sc.violations, entity.SchemaViolation{
+		Pointer: ptr,
+		Keyword: keyword,
+		Msg:     fmt.Sprintf(format, args...),
+	})
+}
+
+// tooDeep reports the value nested deeper than maxValueDepth once for all subschemas applied to it.
+func (sc *schemaCheck) tooDeep(ptr string) {
+	for _, v := range sc.violations {
+		if v.Pointer == ptr && v.Keyword == "depth" {
+			return
+		}
+	}
+
+	sc.violate(ptr, "depth", "the value is nested deeper than %d levels", maxValueDepth)
+}
+
+func invalidSchema(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", usecase.ErrInvalidSchema, fmt.Sprintf(format, args...))
+}
+
+// matches reports whether the value matches the subschema, violations of the subschema are dropped.
+func (sc *schemaCheck) matches(s, n *yaml.Node, ptr string, depth int) (bool, error) {
+	before := len(sc.violations)
+
+	if err := sc.validate(s, n, ptr, depth); err != nil {
+		return false, err
+	}
+
+	ok := len(sc.violations) == before
+	sc.violations = sc.violations[:before]
+
+	return ok, nil
+}
+
+// step counts applied subschemas and compared values and stops the validation on the limit.
+func (sc *schemaCheck) step(n int) error {
+	sc.steps += n
+	if sc.steps > maxSchemaSteps {
+		return invalidSchema("the validation takes more than %d steps", maxSchemaSteps)
+	}
+
+	return nil
+}
+
+// validate applies the schema to the value at the JSON pointer,
+// depth is a number of subschemas already applied to the same value.
+func (sc *schemaCheck) validate(s, n *yaml.Node, ptr string, depth int) error {
+	if err := sc.step(1); err != nil {
+		return err
+	}
+
+	if depth > maxSchemaDepth {
+		return invalidSchema("the schema is nested deeper than %d subschemas", maxSchemaDepth)
+	}
+
+	if strings.Count(ptr, "/") > maxValueDepth {
+		sc.tooDeep(ptr)
+
+		return nil
+	}
+
+	s = resolve(s)
+
+	// Boolean schemas match everything or nothing.
+	if s.Kind == yaml.ScalarNode && s.ShortTag() == "!!bool" {
+		if !truthy(s) {
+			sc.violate(ptr, "false", "no value is allowed")
+		}
+
+		return nil
+	}
+
+	if s.Kind != yaml.MappingNode {
+		return invalidSchema("a schema must be an object or a boolean, got %s", typeName(s))
+	}
+
+	for _, check := range []func(s, n *yaml.Node, ptr string, depth int) error{
+		sc.ref,
+		sc.typ,
+		sc.enum,
+		sc.numeric,
+		sc.str,
+		sc.array,
+		sc.object,
+		sc.combinators,
+		sc.conditional,
+	} {
+		if err := check(s, n, ptr, depth); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keyword returns the value of the schema keyword or nil.
+func keyword(s *yaml.Node, name string) *yaml.Node {
+	for i := 0; i+1 < len(s.Content); i += 2 {
+		if resolve(s.Content[i]).Value == name {
+			return resolve(s.Content[i+1])
+		}
+	}
+
+	return nil
+}
+
+// ref applies the schema referenced by $ref. Only references to the schema itself are supported.
+func (sc *schemaCheck) ref(s, n *yaml.Node, ptr string, depth int) error {
+	ref := keyword(s, "$ref")
+	if ref == nil {
+		return nil
+	}
+
+	if !strings.HasPrefix(ref.Value, "#") {
+		return invalidSchema("only local references are supported, got %q", ref.Value)
+	}
+
+	target, err := sc.lookup(ref.Value[1:])
+	if err != nil {
+		return err
+	}
+
+	return sc.validate(target, n, ptr, depth+1)
+}
+
+// lookup returns the subschema at the URI fragment of a JSON pointer.
+func (sc *schemaCheck) lookup(fragment string) (*yaml.Node, error) {
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, invalidSchema("invalid reference #%s", fragment)
+	}
+
+	n := sc.root
+	if fragment == "" {
+		return n, nil
+	}
+
+	if !strings.HasPrefix(fragment, "/") {
+		return nil, invalidSchema("only JSON pointer references are supported, got #%s", fragment)
+	}
+
+	for _, token := range strings.Split(fragment[1:], "/") {
+		token = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+
+		switch n.Kind {
+		case yaml.MappingNode:
+			n = keyword(n, token)
+		case yaml.SequenceNode:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n.Content) {
+				return nil, invalidSchema("the reference #%s is not found", fragment)
+			}
+
+			n = resolve(n.Content[i])
+		default:
+			n = nil
+		}
+
+		if n == nil {
+			return nil, invalidSchema("the reference #%s is not found", fragment)
+		}
+	}
+
+	return n, nil
+}
+
+func (sc *schemaCheck) typ(s, n *yaml.Node, ptr string, _ int) error {
+	t := keyword(s, "type")
+	if t == nil {
+		return nil
+	}
+
+	var types []string
+
+	switch t.Kind {
+	case yaml.ScalarNode:
+		types = []string{t.Value}
+	case yaml.SequenceNode:
+		for _, item := range t.Content {
+			types = append(types, resolve(item).Value)
+		}
+	default:
+		return invalidSchema("type must be a string or an array")
+	}
+
+	got := typeName(n)
+
+	for _, want := range types {
+		if want == got || want == "integer" && isInteger(n) {
+			return nil
+		}
+	}
+
+	sc.violate(ptr, "type", "expected %s, got %s", strings.Join(types, " or "), got)
+
+	return nil
+}
+
+func (sc *schemaCheck) enum(s, n *yaml.Node, ptr string, _ int) error {
+	if c := keyword(s, "const"); c != nil {
+		d, err := compare(c, n, sc.step)
+		if err != nil {
+			return err
+		}
+
+		if d != 0 {
+			sc.violate(ptr, "const", "must be equal to the constant")
+		}
+	}
+
+	e := keyword(s, "enum")
+	if e == nil {
+		return nil
+	}
+
+	if e.Kind != yaml.SequenceNode {
+		return invalidSchema("enum must be an array")
+	}
+
+	for _, item := range e.Content {
+		d, err := compare(item, n, sc.step)
+		if err != nil {
+			return err
+		}
+
+		if d == 0 {
+			return nil
+		}
+	}
+
+	sc.violate(ptr, "enum", "must be one of the enum values")
+
+	return nil
+}
+
+func (sc *schemaCheck) numeric(s, n *yaml.Node, ptr string, _ int) error {
+	if typeName(n) != "number" {
+		return nil
+	}
+
+	x, _ := number(n)
+
+	bounds := []struct {
+		keyword string
+		fails   func(x, limit float64) bool
+		msg     string
+	}{
+		{"minimum", func(x, limit float64) bool { return x < limit }, ">="},
+		{"maximum", func(x, limit float64) bool { return x > limit }, "<="},
+		{"exclusiveMinimum", func(x, limit float64) bool { return x <= limit }, ">"},
+		{"exclusiveMaximum", func(x, limit float64) bool { return x >= limit }, "<"},
+	}
+
+	for _, b := range bounds {
+		kw := keyword(s, b.keyword)
+		if kw == nil || typeName(kw) == "boolean" {
+			continue
+		}
+
+		limit, ok := number(kw)
+		if !ok {
+			return invalidSchema("%s must be a number", b.keyword)
+		}
+
+		if b.fails(x, limit) {
+			sc.violate(ptr, b.keyword, "must be %s %s", b.msg, kw.Value)
+		}
+	}
+
+	// Draft 4 exclusive bounds are booleans of minimum and maximum.
+	for _, kw := range [][2]string{{"exclusiveMinimum", "minimum"}, {"exclusiveMaximum", "maximum"}} {
+		if ex := keyword(s, kw[0]); ex != nil && typeName(ex) == "boolean" && truthy(ex) {
+			if limit, ok := numberKeyword(s, kw[1]); ok && x == limit {
+				sc.violate(ptr, kw[0], "must not be equal to %s", keyword(s, kw[1]).Value)
+			}
+		}
+	}
+
+	if m := keyword(s, "multipleOf"); m != nil {
+		d, ok := number(m)
+		if !ok || d <= 0 {
+			return invalidSchema("multipleOf must be a positive number")
+		}
+
+		if q := x / d; math.Abs(q-math.Round(q)) > 1e-9 {
+			sc.violate(ptr, "multipleOf", "must be a multiple of %s", m.Value)
+		}
+	}
+
+	return nil
+}
+
+func (sc *schemaCheck) str(s, n *yaml.Node, ptr string, _ int) error {
+	if typeName(n) != "string" {
+		return nil
+	}
+
+	length := utf8.RuneCountInString(n.Value)
+
+	if limit, ok := numberKeyword(s, "minLength"); ok && float64(length) < limit {
+		sc.violate(ptr, "minLength", "must be at least %v characters long", limit)
+	}
+
+	if limit, ok := numberKeyword(s, "maxLength"); ok && float64(length) > limit {
+		sc.violate(ptr, "maxLength", "must be at most %v characters long", limit)
+	}
+
+	if p := keyword(s, "pattern"); p != nil {
+		re, err := sc.regexp(p.Value)
+		if err != nil {
+			return err
+		}
+
+		if !re.MatchString(n.Value) {
+			sc.violate(ptr, "pattern", "must match the pattern %q", p.Value)
+		}
+	}
+
+	return nil
+}
+
+// duplicate returns indexes i < j of equal items with the least j, j is 0 if all items are distinct.
+// Items are sorted to compare each item with its neighbours only.
+func (sc *schemaCheck) duplicate(items []*yaml.Node) (i, j int, err error) {
+	order := make([]int, len(items))
+	for k := range order {
+		order[k] = k
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		if err != nil {
+			return false
+		}
+
+		var c int
+		c, err = compare(items[order[a]], items[order[b]], sc.step)
+
+		return c < 0
+	})
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for k := 1; k < len(order); k++ {
+		c, err := compare(items[order[k-1]], items[order[k]], sc.step)
+		if err != nil {
+			return 0, 0, err
+		}
+		// The stable sort keeps equal items in the text order.
+		if c == 0 && (j == 0 || order[k] < j) {
+			i, j = order[k-1], order[k]
+		}
+	}
+
+	return i, j, nil
+}
+
+func (sc *schemaCheck) array(s, n *yaml.Node, ptr string, depth int) error {
+	if n.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	count := float64(len(n.Content))
+
+	if limit, ok := numberKeyword(s, "minItems"); ok && count < limit {
+		sc.violate(ptr, "minItems", "must have at least %v items", limit)
+	}
+
+	if limit, ok := numberKeyword(s, "maxItems"); ok && count > limit {
+		sc.violate(ptr, "maxItems", "must have at most %v items", limit)
+	}
+
+	if u := keyword(s, "uniqueItems"); u != nil && truthy(u) {
+		i, j, err := sc.duplicate(n.Content)
+		if err != nil {
+			return err
+		}
+
+		if j > 0 {
+			sc.violate(ptr, "uniqueItems", "items %d and %d are equal", i, j)
+		}
+	}
+
+	// Items before the rest are checked by prefixItems or by the draft-07 items array.
+	var (
+		prefix = keyword(s, "prefixItems")
+		rest   = keyword(s, "items")
+		restKw = "items"
+	)
+
+	if rest != nil && rest.Kind == yaml.SequenceNode {
+		prefix, rest, restKw = rest, keyword(s, "additionalItems"), "additionalItems"
+	}
+
+	start := 0
+
+	if prefix != nil {
+		if prefix.Kind != yaml.SequenceNode {
+			return invalidSchema("prefixItems must be an array")
+		}
+
+		for i := 0; i < len(prefix.Content) && i < len(n.Content); i++ {
+			if err := sc.validate(prefix.Content[i], resolve(n.Content[i]), pointer(ptr, strconv.Itoa(i)), 0); err != nil {
+				return err
+			}
+		}
+
+		start = len(prefix.Content)
+	}
+
+	if rest != nil && typeName(rest) == "boolean" && !truthy(rest) && start < len(n.Content) {
+		sc.violate(pointer(ptr, strconv.Itoa(start)), restKw, "must have at most %d items", start)
+	} else if rest != nil {
+		for i := start; i < len(n.Content); i++ {
+			if err := sc.validate(rest, resolve(n.Content[i]), pointer(ptr, strconv.Itoa(i)), 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c := keyword(s, "contains"); c != nil {
+		for i, item := range n.Content {
+			ok, err := sc.matches(c, resolve(item), pointer(ptr, strconv.Itoa(i)), 0)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				return nil
+			}
+		}
+
+		sc.violate(ptr, "contains", "must contain an item matching the contains schema")
+	}
+
+	return nil
+}
+
+func (sc *schemaCheck) object(s, n *yaml.Node, ptr string, depth int) error {
+	if n.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	count := float64(len(n.Content) / 2)
+
+	if limit, ok := numberKeyword(s, "minProperties"); ok && count < limit {
+		sc.violate(ptr, "minProperties", "must have at least %v properties", limit)
+	}
+
+	if limit, ok := numberKeyword(s, "maxProperties"); ok && count > limit {
+		sc.violate(ptr, "maxProperties", "must have at most %v properties", limit)
+	}
+
+	if req := keyword(s, "required"); req != nil {
+		if req.Kind != yaml.SequenceNode {
+			return invalidSchema("required must be an array")
+		}
+
+		for _, name := range req.Content {
+			if keyword(n, resolve(name).Value) == nil {
+				sc.violate(ptr, "required", "missing property %q", resolve(name).Value)
+			}
+		}
+	}
+
+	var (
+		props      = keyword(s, "properties")
+		patterns   = keyword(s, "patternProperties")
+		additional = keyword(s, "additionalProperties")
+		names      = keyword(s, "propertyNames")
+	)
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		var (
+			key     = resolve(n.Content[i]).Value
+			value   = resolve(n.Content[i+1])
+			at      = pointer(ptr, key)
+			matched bool
+		)
+
+		if names != nil {
+			k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+			if err := sc.validate(names, k, at, 0); err != nil {
+				return err
+			}
+		}
+
+		if props != nil {
+			if ps := keyword(props, key); ps != nil {
+				matched = true
+
+				if err := sc.validate(ps, value, at, 0); err != nil {
+					return err
+				}
+			}
+		}
+
+		if patterns != nil {
+			for j := 0; j+1 < len(patterns.Content); j += 2 {
+				re, err := sc.regexp(resolve(patterns.Content[j]).Value)
+				if err != nil {
+					return err
+				}
+
+				if !re.MatchString(key) {
+					continue
+				}
+
+				matched = true
+
+				if err := sc.validate(patterns.Content[j+1], value, at, 0); err != nil {
+					return err
+				}
+			}
+		}
+
+		if additional != nil && !matched {
+			if typeName(additional) == "boolean" && !truthy(additional) {
+				sc.violate(at, "additionalProperties", "property %q is not allowed", key)
+
+				continue
+			}
+
+			if err := sc.validate(additional, value, at, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (sc *schemaCheck) combinators(s, n *yaml.Node, ptr string, depth int) error {
+	if all := keyword(s, "allOf"); all != nil {
+		if all.Kind != yaml.SequenceNode {
+			return invalidSchema("allOf must be an array")
+		}
+
+		for _, sub := range all.Content {
+			if err := sc.validate(sub, n, ptr, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, kw := range []string{"anyOf", "oneOf"} {
+		subs := keyword(s, kw)
+		if subs == nil {
+			continue
+		}
+
+		if subs.Kind != yaml.SequenceNode {
+			return invalidSchema("%s must be an array", kw)
+		}
+
+		matched := 0
+
+		for _, sub := range subs.Content {
+			ok, err := sc.matches(sub, n, ptr, depth+1)
+			if err != nil {
+				return err
+			}
+
+			if ok {
+				matched++
+			}
+		}
+
+		switch {
+		case matched == 0:
+			sc.violate(ptr, kw, "must match at least one of the %s schemas", kw)
+		case kw == "oneOf" && matched > 1:
+			sc.violate(ptr, kw, "must match exactly one of the oneOf schemas, matches %d", matched)
+		}
+	}
+
+	if not := keyword(s, "not"); not != nil {
+		ok, err := sc.matches(not, n, ptr, depth+1)
+		if err != nil {
+			return err
+		}
+
+		if ok {
+			sc.violate(ptr, "not", "must not match the not schema")
+		}
+	}
+
+	return nil
+}
+
+func (sc *schemaCheck) conditional(s, n *yaml.Node, ptr string, depth int) error {
+	cond := keyword(s, "if")
+	if cond == nil {
+		return nil
+	}
+
+	ok, err := sc.matches(cond, n, ptr, depth+1)
+	if err != nil {
+		return err
+	}
+
+	branch := keyword(s, "else")
+	if ok {
+		branch = keyword(s, "then")
+	}
+
+	if branch == nil {
+		return nil
+	}
+
+	return sc.validate(branch, n, ptr, depth+1)
+}
+
+// regexp returns a compiled pattern of the schema. Patterns are ECMA 262 regular
+// expressions, most of them are valid RE2 ones.
+func (sc *schemaCheck) regexp(pattern string) (*regexp.Regexp, error) {
+	if re, ok := sc.regexps[pattern]; ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, invalidSchema("invalid pattern %q", pattern)
+	}
+
+	sc.regexps[pattern] = re
+
+	return re, nil
+}
+
+func numberKeyword(s *yaml.Node, name string) (float64, bool) {
+	kw := keyword(s, name)
+	if kw == nil {
+		return 0, false
+	}
+
+	return number(kw)
+}
+
+func isInteger(n *yaml.Node) bool {
+	if typeName(n) != "number" {
+		return false
+	}
+
+	f, ok := number(n)
+
+	return ok && f == math.Trunc(f)
+}
+
+// pointer returns the JSON pointer of the key under the parent pointer.
+func pointer(parent, key string) string {
+	return parent + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
@@ -0,0 +1,186 @@
+package formats
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/stretchr/testify/require"
+)
+
+// nested returns a JSON text of arrays nested depth times.
+func nested(depth int) entity.File {
+	return entity.File(strings.Repeat("[", depth) + strings.Repeat("]", depth))
+}
+
+func TestSchemaValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		schema string
+		text   entity.File
+		// want are keywords and pointers of the violations.
+		want []string
+	}{
+		{name: "type", schema: `{"type": "object"}`, text: entity.File(`{"a": 1}`)},
+		{name: "wrong type", schema: `{"type": ["string", "null"]}`, text: entity.File(`1`), want: []string{"type "}},
+		{name: "integer", schema: `{"type": "integer"}`, text: entity.File(`2.0`)},
+		{name: "not integer", schema: `{"type": "integer"}`, text: entity.File(`2.5`), want: []string{"type "}},
+		{name: "false schema", schema: `{"properties": {"a": false}}`, text: entity.File(`{"a": 1}`), want: []string{"false /a"}},
+		{name: "enum", schema: `{"enum": [1, {"a": [1, 2]}]}`, text: entity.File(`{"a": [1, 2]}`)},
+		{name: "not in enum", schema: `{"enum": [1, {"a": [1, 2]}]}`, text: entity.File(`{"a": [2, 1]}`), want: []string{"enum "}},
+		{name: "const", schema: `{"const": {"a": 1, "b": 2}}`, text: entity.File(`{"b": 2, "a": 1}`)},
+		{name: "not const", schema: `{"const": "x"}`, text: entity.File(`"y"`), want: []string{"const "}},
+		{name: "numeric bounds", schema: `{"minimum": 1, "exclusiveMaximum": 3, "multipleOf": 0.5}`, text: entity.File(`2.5`)},
+		{
+			name:   "out of numeric bounds",
+			schema: `{"properties": {"a": {"minimum": 1}, "b": {"exclusiveMaximum": 3}, "c": {"multipleOf": 2}}}`,
+			text:   entity.File(`{"a": 0, "b": 3, "c": 3}`),
+			want:   []string{"minimum /a", "exclusiveMaximum /b", "multipleOf /c"},
+		},
+		{name: "string", schema: `{"minLength": 2, "maxLength": 3, "pattern": "^a"}`, text: entity.File(`"абв"`), want: []string{"pattern "}},
+		{name: "short string", schema: `{"minLength": 2}`, text: entity.File(`"я"`), want: []string{"minLength "}},
+		{name: "items", schema: `{"items": {"type": "number"}, "maxItems": 2}`, text: entity.File(`[1, "a", 3]`), want: []string{"maxItems ", "type /1"}},
+		{name: "prefix items", schema: `{"prefixItems": [{"type": "string"}], "items": false}`, text: entity.File(`["a", 1]`), want: []string{"items /1"}},
+		{name: "contains", schema: `{"contains": {"const": 2}}`, text: entity.File(`[1, 3]`), want: []string{"contains "}},
+		{name: "unique items", schema: `{"uniqueItems": true}`, text: entity.File(`[1, {"a": 1}, 2, {"a": 1}, 1]`), want: []string{"uniqueItems "}},
+		{name: "distinct items", schema: `{"uniqueItems": true}`, text: entity.File(`[1, "1", [1], {"a": 1}]`)},
+		{
+			name:   "object",
+			schema: `{"required": ["a", "b"], "properties": {"a": {"type": "string"}}, "additionalProperties": false}`,
+			text:   entity.File(`{"a": 1, "c": 2}`),
+			want:   []string{"required ", "type /a", "additionalProperties /c"},
+		},
+		{
+			name:   "pattern properties",
+			schema: `{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": {"type": "number"}}`,
+			text:   entity.File(`{"x-a": "a", "b": 1, "x-c": 1}`),
+			want:   []string{"type /x-c"},
+		},
+		{name: "escaped pointer", schema: `{"additionalProperties": false}`, text: entity.File(`{"a/b~c": 1}`), want: []string{"additionalProperties /a~1b~0c"}},
+		{name: "all of", schema: `{"allOf": [{"type": "number"}, {"minimum": 5}]}`, text: entity.File(`3`), want: []string{"minimum "}},
+		{name: "any of", schema: `{"anyOf": [{"type": "string"}, {"minimum": 5}]}`, text: entity.File(`3`), want: []string{"anyOf "}},
+		{name: "one of", schema: `{"oneOf": [{"type": "number"}, {"minimum": 1}]}`, text: entity.File(`3`), want: []string{"oneOf "}},
+		{name: "not", schema: `{"not": {"type": "null"}}`, text: entity.File(`null`), want: []string{"not "}},
+		{
+			name:   "if then else",
+			schema: `{"if": {"properties": {"kind": {"const": "a"}}}, "then": {"required": ["a"]}, "else": {"required": ["b"]}}`,
+			text:   entity.File(`{"kind": "b", "a": 1}`),
+			want:   []string{"required "},
+		},
+		{
+			name:   "recursive reference",
+			schema: `{"$defs": {"node": {"type": "array", "items": {"$ref": "#/$defs/node"}}}, "$ref": "#/$defs/node"}`,
+			text:   entity.File(`[[], [[1]]]`),
+			want:   []string{"type /1/0/0"},
+		},
+		{name: "deep value", schema: `{"items": {"$ref": "#"}}`, text: nested(maxValueDepth + 10), want: []string{"depth " + strings.Repeat("/0", maxValueDepth+1)}},
+		{name: "deep value of many subschemas", schema: `{"allOf": [{"items": {"$ref": "#"}}, {"items": true}]}`, text: nested(maxValueDepth + 2), want: []string{"depth " + strings.Repeat("/0", maxValueDepth+1)}},
+		{name: "deep value without schema", schema: `{"type": "array"}`, text: nested(maxValueDepth + 10)},
+		{name: "yaml text", schema: `{"properties": {"a": {"type": "integer"}}}`, text: entity.File("a: 1.5\n"), want: []string{"type /a"}},
+	}
+
+	v := NewSchemaValidator()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			format := "json"
+			if strings.HasPrefix(tt.name, "yaml") {
+				format = "yaml"
+			}
+
+			violations, err := v.Validate("json", entity.File(tt.schema), format, tt.text)
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(violations))
+			for _, v := range violations {
+				got = append(got, v.Keyword+" "+v.Pointer)
+			}
+
+			if len(tt.want) == 0 {
+				require.Empty(t, got)
+
+				return
+			}
+
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchemaValidator_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		schema string
+		format string
+		text   entity.File
+		err    error
+	}{
+		{name: "not applicable", schema: `{}`, format: "xml", text: entity.File("<a/>"), err: usecase.ErrSchemaNotApplicable},
+		{name: "schema is not an object", schema: `[1]`, format: "json", text: entity.File(`1`), err: usecase.ErrInvalidSchema},
+		{name: "schema does not parse", schema: `{"type": `, format: "json", text: entity.File(`1`), err: usecase.ErrInvalidSchema},
+		{name: "invalid keyword", schema: `{"required": "a"}`, format: "json", text: entity.File(`{}`), err: usecase.ErrInvalidSchema},
+		{name: "remote reference", schema: `{"$ref": "http://example.com/schema"}`, format: "json", text: entity.File(`1`), err: usecase.ErrInvalidSchema},
+		{name: "reference loop", schema: `{"$ref": "#"}`, format: "json", text: entity.File(`1`), err: usecase.ErrInvalidSchema},
+		{name: "branching reference loop", schema: `{"anyOf": [{"$ref": "#"}, {"$ref": "#"}]}`, format: "json", text: entity.File(`1`), err: usecase.ErrInvalidSchema},
+		{name: "cyclic schema alias", schema: "a: &a {items: *a}\n", format: "json", text: entity.File(`1`), err: usecase.ErrInvalidSchema},
+		{name: "cyclic text alias", schema: `{"const": 1}`, format: "yaml", text: entity.File("a: &a [1, *a]\n"), err: &entity.FormatError{}},
+		{name: "text does not parse", schema: `{}`, format: "json", text: entity.File(`[1, 2`), err: &entity.FormatError{}},
+	}
+
+	v := NewSchemaValidator()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schemaFormat := "json"
+			if !strings.HasPrefix(tt.schema, "{") && !strings.HasPrefix(tt.schema, "[") {
+				schemaFormat = "yaml"
+			}
+
+			_, err := v.Validate(schemaFormat, entity.File(tt.schema), tt.format, tt.text)
+
+			var fe *entity.FormatError
+			if errors.As(tt.err, &fe) {
+				require.ErrorAs(t, err, &fe)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestSchemaValidator_UniqueItems(t *testing.T) {
+	t.Parallel()
+
+	items := make([]string, 0, 5000)
+	for i := 0; i < cap(items); i++ {
+		items = append(items, fmt.Sprintf(`{"id": %d, "tags": ["a", "b"]}`, i))
+	}
+
+	v := NewSchemaValidator()
+
+	violations, err := v.Validate("json", entity.File(`{"uniqueItems": true}`), "json", entity.File("["+strings.Join(items, ",")+"]"))
+	require.NoError(t, err)
+	require.Empty(t, violations)
+
+	violations, err = v.Validate("json", entity.File(`{"uniqueItems": true}`), "json", entity.File("["+strings.Join(items, ",")+","+items[7]+"]"))
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	require.Equal(t, fmt.Sprintf("items 7 and %d are equal", len(items)), violations[0].Msg)
+}
@@ -0,0 +1,184 @@
+package formats
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"gopkg.in/yaml.v3"
+)
+
+var _ usecase.FormatValidator = &Validator{}
+
+// Validator checks that texts of structured formats can be parsed.
+type Validator struct{}
+
+func NewValidator() *Validator {
+	return &Validator{}
+}
+
+// Validate parses the text of json, yaml, toml or xml format and returns
+// *entity.FormatError with the error position if the text is invalid.
+// Texts of other formats are always valid.
+func (v *Validator) Validate(format string, text entity.File) error {
+	var err *entity.FormatError
+
+	switch format {
+	case "json":
+		err = validateJSON(text)
+	case "yaml":
+		err = validateYAML(text)
+	case "toml":
+		err = validateTOML(text)
+	case "xml":
+		err = validateXML(text)
+	}
+
+	if err != nil {
+		err.Format = format
+
+		return err
+	}
+
+	return nil
+}
+
+func validateJSON(text []byte) *entity.FormatError {
+	var v any
+
+	err := json.Unmarshal(text, &v)
+	if err == nil {
+		return nil
+	}
+
+	// The offset of a syntax error is right after the invalid character.
+	var se *json.SyntaxError
+	if errors.As(err, &se) {
+		line, col := position(text, int(se.Offset)-1)
+
+		return &entity.FormatError{Line: line, Column: col, Msg: se.Error()}
+	}
+
+	return &entity.FormatError{Msg: err.Error()}
+}
+
+var yamlLineErrRe = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// validateYAML parses all documents of the text. YAML parser reports only lines of errors.
+func validateYAML(text []byte) *entity.FormatError {
+	dec := yaml.NewDecoder(bytes.NewReader(text))
+
+	for {
+		var v any
+
+		err := dec.Decode(&v)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err == nil {
+			continue
+		}
+
+		if m := yamlLineErrRe.FindStringSubmatch(err.Error()); m != nil {
+			line, _ := strconv.Atoi(m[1])
+
+			return &entity.FormatError{Line: line, Msg: m[2]}
+		}
+
+		return &entity.FormatError{Msg: err.Error()}
+	}
+}
+
+var tomlErrPrefixRe = regexp.MustCompile(`^toml: line \d+( \(last key ".*"\))?: `)
+
+func validateTOML(text []byte) *entity.FormatError {
+	var v map[string]any
+
+	_, err := toml.Decode(string(text), &v)
+	if err == nil {
+		return nil
+	}
+
+	var pe toml.ParseError
+	if errors.As(err, &pe) {
+		line, col := position(text, pe.Position.Start)
+
+		msg := pe.Message
+		if msg == "" {
+			msg = tomlErrPrefixRe.ReplaceAllString(pe.Error(), "")
+		}
+
+		return &entity.FormatError{Line: line, Column: col, Msg: msg}
+	}
+
+	return &entity.FormatError{Msg: err.Error()}
+}
+
+// validateXML parses the text as a XML document with a single root element.
+func validateXML(text []byte) *entity.FormatError {
+	var (
+		dec   = xml.NewDecoder(bytes.NewReader(text))
+		depth int
+		roots int
+	)
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			line, col := dec.InputPos()
+
+			var se *xml.SyntaxError
+			if errors.As(err, &se) {
+				return &entity.FormatError{Line: line, Column: col, Msg: se.Msg}
+			}
+
+			return &entity.FormatError{Line: line, Column: col, Msg: err.Error()}
+		}
+
+		switch tok.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				roots++
+			}
+
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+
+		if roots > 1 {
+			line, col := dec.InputPos()
+
+			return &entity.FormatError{Line: line, Column: col, Msg: "multiple root elements"}
+		}
+	}
+
+	if roots == 0 {
+		return &entity.FormatError{Msg: "missing root element"}
+	}
+
+	return nil
+}
+
+// position returns 1-based line and column of the byte offset in the text.
+func position(text []byte, offset int) (line, col int) {
+	offset = min(max(offset, 0), len(text))
+	before := text[:offset]
+
+	line = bytes.Count(before, []byte("\n")) + 1
+	col = offset - bytes.LastIndexByte(before, '\n')
+
+	return line, col
+}
@@ -0,0 +1,101 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format string
+		text   string
+		// line and column of the error, zero line means the text is valid
+		// and negative lines are not checked, YAML reports lines of enclosing blocks.
+		line, column int
+	}{
+		{name: "json", format: "json", text: `{"a": [1, 2, {"b": null}]}`},
+		{name: "json scalar", format: "json", text: `"text"`},
+		{name: "json trailing comma", format: "json", text: "{\n  \"a\": 1,\n}", line: 3, column: 1},
+		{name: "json unterminated", format: "json", text: `{"a": [1, 2`, line: 1},
+		{name: "json garbage after value", format: "json", text: "{}\n}", line: 2, column: 1},
+		{name: "empty json", format: "json", text: "", line: 1, column: 1},
+		{name: "deep json", format: "json", text: strings.Repeat("[", 20000) + strings.Repeat("]", 20000), line: 1},
+		{name: "yaml", format: "yaml", text: "a: 1\nb:\n  - c\n"},
+		{name: "yaml documents", format: "yaml", text: "a: 1\n---\nb: 2\n"},
+		{name: "empty yaml", format: "yaml", text: ""},
+		{name: "yaml bad indentation", format: "yaml", text: "a:\n  b: 1\n c: 2\n", line: -1},
+		{name: "yaml invalid second document", format: "yaml", text: "a: 1\n---\nb: [1\n", line: -1},
+		{name: "yaml unknown alias", format: "yaml", text: "a: *b\n", line: -1},
+		{name: "yaml cyclic alias", format: "yaml", text: "a: &a [1, *a]\n", line: -1},
+		{name: "yaml excessive aliases", format: "yaml", text: string(laughs(8)), line: -1},
+		{name: "toml", format: "toml", text: "a = 1\n\n[b]\nc = \"d\"\n"},
+		{name: "empty toml", format: "toml", text: ""},
+		{name: "toml duplicate key", format: "toml", text: "a = 1\na = 2\n", line: 2},
+		{name: "toml unterminated string", format: "toml", text: "a = \"b\n", line: 1},
+		{name: "xml", format: "xml", text: `<?xml version="1.0"?><a x="1"><b/>text</a>`},
+		{name: "xml unclosed", format: "xml", text: "<a>\n<b>\n</a>", line: 3},
+		{name: "xml multiple roots", format: "xml", text: "<a/>\n<b/>", line: 2},
+		{name: "xml without root", format: "xml", text: "<!-- comment -->", line: -1},
+		{name: "xml undefined entity", format: "xml", text: "<a>&lol;</a>", line: 1},
+		{name: "xml doctype entities", format: "xml", text: `<!DOCTYPE a [<!ENTITY lol "lol">]><a>&lol;</a>`, line: 1},
+		{name: "plaintext", format: "plaintext", text: "{not json"},
+		{name: "go", format: "go", text: "func {"},
+	}
+
+	v := NewValidator()
+
+	for _, tt := range tests {
+		tt := tt
+
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := v.Validate(tt.format, entity.File(tt.text))
+			if tt.line == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+
+			var fe *entity.FormatError
+			require.ErrorAs(t, err, &fe)
+			require.Equal(t, tt.format, fe.Format)
+			require.NotEmpty(t, fe.Msg)
+
+			if tt.line > 0 {
+				require.Equal(t, tt.line, fe.Line)
+			}
+
+			if tt.column > 0 {
+				require.Equal(t, tt.column, fe.Column)
+			}
+		})
+	}
+}
+
+func TestPosition(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		offset       int
+		line, column int
+	}{
+		{offset: -1, line: 1, column: 1},
+		{offset: 0, line: 1, column: 1},
+		{offset: 2, line: 1, column: 3},
+		{offset: 3, line: 2, column: 1},
+		{offset: 100, line: 3, column: 2},
+	}
+
+	for _, tt := range tests {
+		line, column := position([]byte("ab\ncd\ne"), tt.offset)
+		require.Equal(t, tt.line, line, "offset %d", tt.offset)
+		require.Equal(t, tt.column, column, "offset %d", tt.offset)
+	}
+}
@@ -0,0 +1,108 @@
+package highlight
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+)
+
+const (
+	defaultTheme = "github"
+	// linePrefix is a prefix of line anchors, so the line 10 is linked as #L10.
+	linePrefix = "L"
+	// lineNumberFormat is a dimmed right aligned line number of terminal outputs.
+	lineNumberFormat = "\x1b[2m%*d\x1b[0m  "
+)
+
+var _ usecase.PastesHighlighter = &Highlighter{}
+
+// Highlighter renders pastes with syntax highlighting.
+type Highlighter struct{}
+
+func NewHighlighter() *Highlighter {
+	return &Highlighter{}
+}
+
+// HasTheme reports whether the color theme exists.
+func (h *Highlighter) HasTheme(name string) bool {
+	_, ok := styles.Registry[name]
+
+	return ok
+}
+
+// Highlight renders the paste text to a standalone HTML document with linkable line numbers
+// or to a text with ANSI escape codes for terminals.
+//
+// The lexer is chosen by the paste format, unknown formats are rendered as plain text.
+func (h *Highlighter) Highlight(p *entity.Paste, opts entity.HighlightOptions) ([]byte, error) {
+	lexer := lexers.Get(p.Format)
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+
+	theme := opts.Theme
+	if theme == "" {
+		theme = defaultTheme
+	}
+
+	it, err := chroma.Coalesce(lexer).Tokenise(nil, string(p.File))
+	if err != nil {
+		return nil, fmt.Errorf("Highlighter.Tokenise: %w", err)
+	}
+
+	buf := new(bytes.Buffer)
+
+	switch opts.Output {
+	case entity.HighlightANSI256, entity.HighlightTrueColor:
+		err = formatTerminal(buf, opts, styles.Get(theme), it)
+	default:
+		err = html.New(
+			html.Standalone(true),
+			html.WithLineNumbers(true),
+			html.LineNumbersInTable(true),
+			html.WithLinkableLineNumbers(true, linePrefix),
+			html.HighlightLines(opts.Lines),
+		).Format(buf, styles.Get(theme), it)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Highlighter.Format: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// formatTerminal writes tokens with ANSI escape codes line by line,
+// so each line can be prefixed with its number.
+func formatTerminal(w io.Writer, opts entity.HighlightOptions, style *chroma.Style, it chroma.Iterator) error {
+	formatter := formatters.TTY256
+	if opts.Output == entity.HighlightTrueColor {
+		formatter = formatters.TTY16m
+	}
+
+	lines := chroma.SplitTokensIntoLines(it.Tokens())
+	width := len(strconv.Itoa(len(lines)))
+
+	for i, line := range lines {
+		if opts.LineNumbers {
+			if _, err := fmt.Fprintf(w, lineNumberFormat, width, i+1); err != nil {
+				return err
+			}
+		}
+
+		if err := formatter.Format(w, style, chroma.Literator(line...)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/romankravchuk/pastebin/internal/entity"
 	"golang.org/x/oauth2"
@@ -13,6 +15,62 @@ type Pastes interface {
 	Get(context.Context, string) (*entity.Paste, error)
 	Delete(context.Context, string) error
 	Update(context.Context, *entity.Paste) error
+	Unlock(ctx context.Context, hash, password string) (*entity.Paste, error)
+	GetRevisions(ctx context.Context, hash, password string) ([]*entity.PasteRevision, error)
+	GetRevision(ctx context.Context, hash, password string, revision int) (*entity.PasteRevision, error)
+	Diff(ctx context.Context, from, to entity.DiffSource) (*entity.Diff, error)
+	DiffRevisions(ctx context.Context, hash, password string, from, to int) (*entity.Diff, error)
+	Fork(ctx context.Context, fork *entity.Paste, password string) error
+	GetForks(ctx context.Context, hash string) ([]*entity.Paste, error)
+	Extend(ctx context.Context, hash string, e entity.Expiration) (*entity.Paste, error)
+	Highlight(ctx context.Context, hash, password string, opts entity.HighlightOptions) ([]byte, error)
+	Convert(ctx context.Context, conv *entity.Paste, password string, opts entity.ConvertOptions, save bool) error
+	Query(ctx context.Context, hash, password, expr string) ([]json.RawMessage, error)
+	ValidateSchema(ctx context.Context, hash, password, schema string) ([]entity.SchemaViolation, error)
+	ListUserPastes(ctx context.Context, q entity.PasteListQuery) (*entity.PastesPage, error)
+	GrantAccess(ctx context.Context, hash, username string) error
+	RevokeAccess(ctx context.Context, hash, username string) error
+	GetGrants(ctx context.Context, hash string) ([]string, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Comments --output ./mocks --outpkg mocks
+type Comments interface {
+	ListComments(ctx context.Context, hash, password string) ([]*entity.PasteComment, error)
+	CreateComment(ctx context.Context, password string, c *entity.PasteComment) error
+	UpdateComment(ctx context.Context, password string, c *entity.PasteComment) error
+	DeleteComment(ctx context.Context, hash, password string, id int64) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Stars --output ./mocks --outpkg mocks
+type Stars interface {
+	Star(ctx context.Context, hash string) (int, error)
+	Unstar(ctx context.Context, hash string) (int, error)
+	ListStarred(ctx context.Context, q entity.StarListQuery) (*entity.StarsPage, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Tags --output ./mocks --outpkg mocks
+type Tags interface {
+	ListTagged(ctx context.Context, q entity.TagQuery) (*entity.PastesPage, error)
+	Tags(ctx context.Context) ([]*entity.TagCount, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Feeds --output ./mocks --outpkg mocks
+type Feeds interface {
+	Recent(ctx context.Context) (*entity.Feed, error)
+	UserFeed(ctx context.Context, username string) (*entity.Feed, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Search --output ./mocks --outpkg mocks
+type Search interface {
+	Search(ctx context.Context, q entity.SearchQuery) (*entity.SearchPage, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteAccess --output ./mocks --outpkg mocks
+type PasteAccess interface {
+	Check(ctx context.Context, paste *entity.Paste) error
+	Readable(ctx context.Context, hash string) (*entity.Paste, error)
+	Meta(ctx context.Context, hash, password string) (*entity.Paste, error)
+	Text(ctx context.Context, hash, password string) (*entity.Paste, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PastesRepo --output ./mocks --outpkg mocks
@@ -20,7 +78,15 @@ type PastesRepo interface {
 	Create(context.Context, *entity.Paste) error
 	Get(context.Context, string) (*entity.Paste, error)
 	Delete(context.Context, string) error
-	Update(context.Context, *entity.Paste) error
+	Update(ctx context.Context, hash string, update func(p *entity.Paste) error) error
+	ListForks(ctx context.Context, hash string) ([]*entity.Paste, error)
+	ListByUser(ctx context.Context, q entity.PasteListQuery) ([]*entity.Paste, error)
+	ListFeed(ctx context.Context, userID string, limit int) ([]*entity.FeedEntry, error)
+	ListByTags(ctx context.Context, q entity.TagQuery) ([]*entity.Paste, error)
+	Burn(ctx context.Context, hash string) error
+	SetViews(ctx context.Context, views map[string]int) error
+	DeleteExpired(ctx context.Context, limit int) ([]*entity.Paste, error)
+	SetExpiration(ctx context.Context, hash string, expiresAt time.Time) error
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PastesBlobStorage --output ./mocks --outpkg mocks
@@ -29,6 +95,44 @@ type PastesBlobStorage interface {
 	Get(ctx context.Context, userID, hash string) (entity.File, error)
 	Delete(ctx context.Context, userID, hash string) error
 	Update(ctx context.Context, p *entity.Paste) error
+	CreateRevision(ctx context.Context, p *entity.Paste) error
+	GetRevision(ctx context.Context, userID, hash string, revision int) (entity.File, error)
+	GetRevisionFile(ctx context.Context, userID, hash string, revision int, name string) (entity.File, error)
+	CreateFile(ctx context.Context, p *entity.Paste, f *entity.PasteFile) error
+	GetFile(ctx context.Context, userID, hash, name string) (entity.File, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteRevisionsRepo --output ./mocks --outpkg mocks
+type PasteRevisionsRepo interface {
+	Get(ctx context.Context, hash string, revision int) (*entity.PasteRevision, error)
+	List(ctx context.Context, hash string) ([]*entity.PasteRevision, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteFilesRepo --output ./mocks --outpkg mocks
+type PasteFilesRepo interface {
+	Create(ctx context.Context, files []*entity.PasteFile) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteGrantsRepo --output ./mocks --outpkg mocks
+type PasteGrantsRepo interface {
+	Create(ctx context.Context, hash, username string) error
+	Delete(ctx context.Context, hash, username string) error
+	List(ctx context.Context, hash string) ([]string, error)
+	Exists(ctx context.Context, hash, userID string) (bool, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteTagsRepo --output ./mocks --outpkg mocks
+type PasteTagsRepo interface {
+	Set(ctx context.Context, hash string, tags []string) error
+	Counts(ctx context.Context, userID string) ([]*entity.TagCount, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteSearchRepo --output ./mocks --outpkg mocks
+type PasteSearchRepo interface {
+	Index(ctx context.Context, hash, title, content string) error
+	Delete(ctx context.Context, hash string) error
+	Search(ctx context.Context, q entity.SearchQuery) ([]*entity.SearchResult, error)
+	Missing(ctx context.Context, after string, limit int) ([]string, error)
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PastesCache --output ./mocks --outpkg mocks
@@ -38,6 +142,125 @@ type PastesCache interface {
 	Delete(context.Context, string) error
 }
 
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PastesRendersCache --output ./mocks --outpkg mocks
+type PastesRendersCache interface {
+	Get(ctx context.Context, hash, key string) ([]byte, bool, error)
+	Set(ctx context.Context, hash, key string, render []byte) error
+	Delete(ctx context.Context, hash string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PastesFeedsCache --output ./mocks --outpkg mocks
+type PastesFeedsCache interface {
+	Get(ctx context.Context, userID string) (*entity.Feed, bool, error)
+	Set(ctx context.Context, userID string, feed *entity.Feed) error
+	Delete(ctx context.Context, userID string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PastesHighlighter --output ./mocks --outpkg mocks
+type PastesHighlighter interface {
+	HasTheme(name string) bool
+	Highlight(p *entity.Paste, opts entity.HighlightOptions) ([]byte, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Collections --output ./mocks --outpkg mocks
+type Collections interface {
+	Create(ctx context.Context, c *entity.Collection) error
+	Get(ctx context.Context, hash string) (*entity.Collection, error)
+	Update(ctx context.Context, c *entity.Collection) error
+	Delete(ctx context.Context, hash string) error
+	AddPaste(ctx context.Context, hash, pasteHash string) error
+	RemovePaste(ctx context.Context, hash, pasteHash string) error
+	Reorder(ctx context.Context, hash string, pastes []string) error
+	GrantAccess(ctx context.Context, hash, username string) error
+	RevokeAccess(ctx context.Context, hash, username string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name CollectionsRepo --output ./mocks --outpkg mocks
+type CollectionsRepo interface {
+	Create(ctx context.Context, c *entity.Collection) error
+	Get(ctx context.Context, hash string) (*entity.Collection, error)
+	Update(ctx context.Context, c *entity.Collection) error
+	Delete(ctx context.Context, hash string) error
+	ListPastes(ctx context.Context, hash, userID string) ([]*entity.Paste, error)
+	PasteHashes(ctx context.Context, hash string) ([]string, error)
+	AddPastes(ctx context.Context, hash string, pastes []string) error
+	RemovePaste(ctx context.Context, hash, pasteHash string) error
+	SetOrder(ctx context.Context, hash string, pastes []string) error
+	CreateGrant(ctx context.Context, hash, username string) error
+	DeleteGrant(ctx context.Context, hash, username string) error
+	GrantExists(ctx context.Context, hash, userID string) (bool, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Formats --output ./mocks --outpkg mocks
+type Formats interface {
+	Detect(ctx context.Context, text entity.File) entity.FormatDetection
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name FormatDetector --output ./mocks --outpkg mocks
+type FormatDetector interface {
+	Detect(text entity.File) entity.FormatDetection
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name FormatValidator --output ./mocks --outpkg mocks
+type FormatValidator interface {
+	Validate(format string, text entity.File) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name FormatConverter --output ./mocks --outpkg mocks
+type FormatConverter interface {
+	Convert(format string, text entity.File, opts entity.ConvertOptions) (entity.File, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name FormatQuerier --output ./mocks --outpkg mocks
+type FormatQuerier interface {
+	Query(ctx context.Context, format string, text entity.File, expr string) ([]json.RawMessage, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name SchemaValidator --output ./mocks --outpkg mocks
+type SchemaValidator interface {
+	Validate(schemaFormat string, schema entity.File, format string, text entity.File) ([]entity.SchemaViolation, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteViewsCounter --output ./mocks --outpkg mocks
+type PasteViewsCounter interface {
+	Incr(ctx context.Context, hash string, views, maxViews int) (int, error)
+	Pending(ctx context.Context, limit int) (map[string]int, error)
+	Flushed(ctx context.Context, views map[string]int) error
+	Delete(ctx context.Context, hash string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteStarsCounter --output ./mocks --outpkg mocks
+type PasteStarsCounter interface {
+	Incr(ctx context.Context, hash string, stars, delta int) (int, error)
+	Get(ctx context.Context, hash string) (int, bool, error)
+	Pending(ctx context.Context, limit int) ([]string, error)
+	Set(ctx context.Context, stars map[string]int) error
+	Delete(ctx context.Context, hash string) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteStarsRepo --output ./mocks --outpkg mocks
+type PasteStarsRepo interface {
+	Create(ctx context.Context, hash, userID string) (bool, error)
+	Delete(ctx context.Context, hash, userID string) (bool, error)
+	ListByUser(ctx context.Context, q entity.StarListQuery) ([]*entity.StarredPaste, error)
+	Reconcile(ctx context.Context, hashes []string) (map[string]int, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name PasteCommentsRepo --output ./mocks --outpkg mocks
+type PasteCommentsRepo interface {
+	Create(ctx context.Context, c *entity.PasteComment) error
+	Get(ctx context.Context, id int64) (*entity.PasteComment, error)
+	List(ctx context.Context, hash string) ([]*entity.PasteComment, error)
+	Update(ctx context.Context, c *entity.PasteComment) error
+	Delete(ctx context.Context, id int64) error
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name Locker --output ./mocks --outpkg mocks
+type Locker interface {
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, key string) error
+}
+
 //go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name AuthWebAPI --output ./mocks --outpkg mocks
 type AuthWebAPI interface {
 	GetToken(ctx context.Context, code string) (*oauth2.Token, error)
@@ -48,10 +271,19 @@ type AuthWebAPI interface {
 type Auth interface {
 	Token(ctx context.Context, req entity.CreateTokenRequest) (*entity.TokenCredentails, error)
 	CreateUser(ctx context.Context, req entity.CreateTokenRequest) (*entity.User, error)
+	Authenticate(ctx context.Context, token string) (*entity.User, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name AuthTokensCache --output ./mocks --outpkg mocks
+type AuthTokensCache interface {
+	Get(ctx context.Context, token string) (*entity.CachedToken, bool, error)
+	Set(ctx context.Context, token string, cached *entity.CachedToken) error
+	Delete(ctx context.Context, token string) error
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.20.2 --name UsersRepo --output ./mocks --outpkg mocks
 type UsersRepo interface {
 	Create(ctx context.Context, u *entity.User) error
 	GetByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetByUsername(ctx context.Context, username string) (*entity.User, error)
 }
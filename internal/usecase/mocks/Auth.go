@@ -14,6 +14,32 @@ type Auth struct {
 	mock.Mock
 }
 
+// Authenticate provides a mock function with given fields: ctx, token
+func (_m *Auth) Authenticate(ctx context.Context, token string) (*entity.User, error) {
+	ret := _m.Called(ctx, token)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateUser provides a mock function with given fields: ctx, req
 func (_m *Auth) CreateUser(ctx context.Context, req entity.CreateTokenRequest) (*entity.User, error) {
 	ret := _m.Called(ctx, req)
@@ -3,9 +3,10 @@
 package mocks
 
 import (
+	context "context"
+
 	entity "github.com/romankravchuk/pastebin/internal/entity"
 	mock "github.com/stretchr/testify/mock"
-
 	oauth2 "golang.org/x/oauth2"
 )
 
@@ -14,25 +15,25 @@ type AuthWebAPI struct {
 	mock.Mock
 }
 
-// GetToken provides a mock function with given fields: code
-func (_m *AuthWebAPI) GetToken(code string) (*oauth2.Token, error) {
-	ret := _m.Called(code)
+// GetToken provides a mock function with given fields: ctx, code
+func (_m *AuthWebAPI) GetToken(ctx context.Context, code string) (*oauth2.Token, error) {
+	ret := _m.Called(ctx, code)
 
 	var r0 *oauth2.Token
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*oauth2.Token, error)); ok {
-		return rf(code)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*oauth2.Token, error)); ok {
+		return rf(ctx, code)
 	}
-	if rf, ok := ret.Get(0).(func(string) *oauth2.Token); ok {
-		r0 = rf(code)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *oauth2.Token); ok {
+		r0 = rf(ctx, code)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*oauth2.Token)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(code)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -40,25 +41,25 @@ func (_m *AuthWebAPI) GetToken(code string) (*oauth2.Token, error) {
 	return r0, r1
 }
 
-// GetUserInfo provides a mock function with given fields: token
-func (_m *AuthWebAPI) GetUserInfo(token *oauth2.Token) (*entity.APIUser, error) {
-	ret := _m.Called(token)
+// GetUserInfo provides a mock function with given fields: ctx, token
+func (_m *AuthWebAPI) GetUserInfo(ctx context.Context, token *oauth2.Token) (*entity.APIUser, error) {
+	ret := _m.Called(ctx, token)
 
 	var r0 *entity.APIUser
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*oauth2.Token) (*entity.APIUser, error)); ok {
-		return rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *oauth2.Token) (*entity.APIUser, error)); ok {
+		return rf(ctx, token)
 	}
-	if rf, ok := ret.Get(0).(func(*oauth2.Token) *entity.APIUser); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, *oauth2.Token) *entity.APIUser); ok {
+		r0 = rf(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*entity.APIUser)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*oauth2.Token) error); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(context.Context, *oauth2.Token) error); ok {
+		r1 = rf(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	json "encoding/json"
 
 	entity "github.com/romankravchuk/pastebin/internal/entity"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +15,20 @@ type Pastes struct {
 	mock.Mock
 }
 
+// Convert provides a mock function with given fields: ctx, conv, password, opts, save
+func (_m *Pastes) Convert(ctx context.Context, conv *entity.Paste, password string, opts entity.ConvertOptions, save bool) error {
+	ret := _m.Called(ctx, conv, password, opts, save)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Paste, string, entity.ConvertOptions, bool) error); ok {
+		r0 = rf(ctx, conv, password, opts, save)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: _a0, _a1
 func (_m *Pastes) Create(_a0 context.Context, _a1 *entity.Paste) error {
 	ret := _m.Called(_a0, _a1)
@@ -42,6 +57,98 @@ func (_m *Pastes) Delete(_a0 context.Context, _a1 string) error {
 	return r0
 }
 
+// Diff provides a mock function with given fields: ctx, from, to
+func (_m *Pastes) Diff(ctx context.Context, from entity.DiffSource, to entity.DiffSource) (*entity.Diff, error) {
+	ret := _m.Called(ctx, from, to)
+
+	var r0 *entity.Diff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DiffSource, entity.DiffSource) (*entity.Diff, error)); ok {
+		return rf(ctx, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.DiffSource, entity.DiffSource) *entity.Diff); ok {
+		r0 = rf(ctx, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Diff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.DiffSource, entity.DiffSource) error); ok {
+		r1 = rf(ctx, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DiffRevisions provides a mock function with given fields: ctx, hash, password, from, to
+func (_m *Pastes) DiffRevisions(ctx context.Context, hash string, password string, from int, to int) (*entity.Diff, error) {
+	ret := _m.Called(ctx, hash, password, from, to)
+
+	var r0 *entity.Diff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) (*entity.Diff, error)); ok {
+		return rf(ctx, hash, password, from, to)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, int) *entity.Diff); ok {
+		r0 = rf(ctx, hash, password, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Diff)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, int) error); ok {
+		r1 = rf(ctx, hash, password, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Extend provides a mock function with given fields: ctx, hash, e
+func (_m *Pastes) Extend(ctx context.Context, hash string, e entity.Expiration) (*entity.Paste, error) {
+	ret := _m.Called(ctx, hash, e)
+
+	var r0 *entity.Paste
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Expiration) (*entity.Paste, error)); ok {
+		return rf(ctx, hash, e)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, entity.Expiration) *entity.Paste); ok {
+		r0 = rf(ctx, hash, e)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Paste)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, entity.Expiration) error); ok {
+		r1 = rf(ctx, hash, e)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Fork provides a mock function with given fields: ctx, fork, password
+func (_m *Pastes) Fork(ctx context.Context, fork *entity.Paste, password string) error {
+	ret := _m.Called(ctx, fork, password)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Paste, string) error); ok {
+		r0 = rf(ctx, fork, password)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: _a0, _a1
 func (_m *Pastes) Get(_a0 context.Context, _a1 string) (*entity.Paste, error) {
 	ret := _m.Called(_a0, _a1)
@@ -68,6 +175,242 @@ func (_m *Pastes) Get(_a0 context.Context, _a1 string) (*entity.Paste, error) {
 	return r0, r1
 }
 
+// GetForks provides a mock function with given fields: ctx, hash
+func (_m *Pastes) GetForks(ctx context.Context, hash string) ([]*entity.Paste, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 []*entity.Paste
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Paste, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Paste); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Paste)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGrants provides a mock function with given fields: ctx, hash
+func (_m *Pastes) GetGrants(ctx context.Context, hash string) ([]string, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevision provides a mock function with given fields: ctx, hash, password, revision
+func (_m *Pastes) GetRevision(ctx context.Context, hash string, password string, revision int) (*entity.PasteRevision, error) {
+	ret := _m.Called(ctx, hash, password, revision)
+
+	var r0 *entity.PasteRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (*entity.PasteRevision, error)); ok {
+		return rf(ctx, hash, password, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) *entity.PasteRevision); ok {
+		r0 = rf(ctx, hash, password, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PasteRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, hash, password, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, hash, password
+func (_m *Pastes) GetRevisions(ctx context.Context, hash string, password string) ([]*entity.PasteRevision, error) {
+	ret := _m.Called(ctx, hash, password)
+
+	var r0 []*entity.PasteRevision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) ([]*entity.PasteRevision, error)); ok {
+		return rf(ctx, hash, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*entity.PasteRevision); ok {
+		r0 = rf(ctx, hash, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PasteRevision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, hash, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantAccess provides a mock function with given fields: ctx, hash, username
+func (_m *Pastes) GrantAccess(ctx context.Context, hash string, username string) error {
+	ret := _m.Called(ctx, hash, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, hash, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Highlight provides a mock function with given fields: ctx, hash, password, opts
+func (_m *Pastes) Highlight(ctx context.Context, hash string, password string, opts entity.HighlightOptions) ([]byte, error) {
+	ret := _m.Called(ctx, hash, password, opts)
+
+	var r0 []byte
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, entity.HighlightOptions) ([]byte, error)); ok {
+		return rf(ctx, hash, password, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, entity.HighlightOptions) []byte); ok {
+		r0 = rf(ctx, hash, password, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, entity.HighlightOptions) error); ok {
+		r1 = rf(ctx, hash, password, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserPastes provides a mock function with given fields: ctx, q
+func (_m *Pastes) ListUserPastes(ctx context.Context, q entity.PasteListQuery) (*entity.PastesPage, error) {
+	ret := _m.Called(ctx, q)
+
+	var r0 *entity.PastesPage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PasteListQuery) (*entity.PastesPage, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PasteListQuery) *entity.PastesPage); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PastesPage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PasteListQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Query provides a mock function with given fields: ctx, hash, password, expr
+func (_m *Pastes) Query(ctx context.Context, hash string, password string, expr string) ([]json.RawMessage, error) {
+	ret := _m.Called(ctx, hash, password, expr)
+
+	var r0 []json.RawMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]json.RawMessage, error)); ok {
+		return rf(ctx, hash, password, expr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []json.RawMessage); ok {
+		r0 = rf(ctx, hash, password, expr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]json.RawMessage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, hash, password, expr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAccess provides a mock function with given fields: ctx, hash, username
+func (_m *Pastes) RevokeAccess(ctx context.Context, hash string, username string) error {
+	ret := _m.Called(ctx, hash, username)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, hash, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unlock provides a mock function with given fields: ctx, hash, password
+func (_m *Pastes) Unlock(ctx context.Context, hash string, password string) (*entity.Paste, error) {
+	ret := _m.Called(ctx, hash, password)
+
+	var r0 *entity.Paste
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.Paste, error)); ok {
+		return rf(ctx, hash, password)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.Paste); ok {
+		r0 = rf(ctx, hash, password)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Paste)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, hash, password)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: _a0, _a1
 func (_m *Pastes) Update(_a0 context.Context, _a1 *entity.Paste) error {
 	ret := _m.Called(_a0, _a1)
@@ -82,6 +425,32 @@ func (_m *Pastes) Update(_a0 context.Context, _a1 *entity.Paste) error {
 	return r0
 }
 
+// ValidateSchema provides a mock function with given fields: ctx, hash, password, schema
+func (_m *Pastes) ValidateSchema(ctx context.Context, hash string, password string, schema string) ([]entity.SchemaViolation, error) {
+	ret := _m.Called(ctx, hash, password, schema)
+
+	var r0 []entity.SchemaViolation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]entity.SchemaViolation, error)); ok {
+		return rf(ctx, hash, password, schema)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []entity.SchemaViolation); ok {
+		r0 = rf(ctx, hash, password, schema)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]entity.SchemaViolation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, hash, password, schema)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewPastes interface {
 	mock.TestingT
 	Cleanup(func())
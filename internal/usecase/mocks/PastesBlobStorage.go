@@ -28,6 +28,34 @@ func (_m *PastesBlobStorage) Create(ctx context.Context, p *entity.Paste) error
 	return r0
 }
 
+// CreateFile provides a mock function with given fields: ctx, p, f
+func (_m *PastesBlobStorage) CreateFile(ctx context.Context, p *entity.Paste, f *entity.PasteFile) error {
+	ret := _m.Called(ctx, p, f)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Paste, *entity.PasteFile) error); ok {
+		r0 = rf(ctx, p, f)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateRevision provides a mock function with given fields: ctx, p
+func (_m *PastesBlobStorage) CreateRevision(ctx context.Context, p *entity.Paste) error {
+	ret := _m.Called(ctx, p)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Paste) error); ok {
+		r0 = rf(ctx, p)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Delete provides a mock function with given fields: ctx, userID, hash
 func (_m *PastesBlobStorage) Delete(ctx context.Context, userID string, hash string) error {
 	ret := _m.Called(ctx, userID, hash)
@@ -68,6 +96,84 @@ func (_m *PastesBlobStorage) Get(ctx context.Context, userID string, hash string
 	return r0, r1
 }
 
+// GetFile provides a mock function with given fields: ctx, userID, hash, name
+func (_m *PastesBlobStorage) GetFile(ctx context.Context, userID string, hash string, name string) (entity.File, error) {
+	ret := _m.Called(ctx, userID, hash, name)
+
+	var r0 entity.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (entity.File, error)); ok {
+		return rf(ctx, userID, hash, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) entity.File); ok {
+		r0 = rf(ctx, userID, hash, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(entity.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, userID, hash, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevision provides a mock function with given fields: ctx, userID, hash, revision
+func (_m *PastesBlobStorage) GetRevision(ctx context.Context, userID string, hash string, revision int) (entity.File, error) {
+	ret := _m.Called(ctx, userID, hash, revision)
+
+	var r0 entity.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) (entity.File, error)); ok {
+		return rf(ctx, userID, hash, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int) entity.File); ok {
+		r0 = rf(ctx, userID, hash, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(entity.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int) error); ok {
+		r1 = rf(ctx, userID, hash, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisionFile provides a mock function with given fields: ctx, userID, hash, revision, name
+func (_m *PastesBlobStorage) GetRevisionFile(ctx context.Context, userID string, hash string, revision int, name string) (entity.File, error) {
+	ret := _m.Called(ctx, userID, hash, revision, name)
+
+	var r0 entity.File
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, string) (entity.File, error)); ok {
+		return rf(ctx, userID, hash, revision, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, string) entity.File); ok {
+		r0 = rf(ctx, userID, hash, revision, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(entity.File)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, string) error); ok {
+		r1 = rf(ctx, userID, hash, revision, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, p
 func (_m *PastesBlobStorage) Update(ctx context.Context, p *entity.Paste) error {
 	ret := _m.Called(ctx, p)
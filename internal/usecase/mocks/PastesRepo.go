@@ -4,6 +4,7 @@ package mocks
 
 import (
 	context "context"
+	time "time"
 
 	entity "github.com/romankravchuk/pastebin/internal/entity"
 	mock "github.com/stretchr/testify/mock"
@@ -14,6 +15,20 @@ type PastesRepo struct {
 	mock.Mock
 }
 
+// Burn provides a mock function with given fields: ctx, hash
+func (_m *PastesRepo) Burn(ctx context.Context, hash string) error {
+	ret := _m.Called(ctx, hash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: _a0, _a1
 func (_m *PastesRepo) Create(_a0 context.Context, _a1 *entity.Paste) error {
 	ret := _m.Called(_a0, _a1)
@@ -42,6 +57,32 @@ func (_m *PastesRepo) Delete(_a0 context.Context, _a1 string) error {
 	return r0
 }
 
+// DeleteExpired provides a mock function with given fields: ctx, limit
+func (_m *PastesRepo) DeleteExpired(ctx context.Context, limit int) ([]*entity.Paste, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []*entity.Paste
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]*entity.Paste, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*entity.Paste); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Paste)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: _a0, _a1
 func (_m *PastesRepo) Get(_a0 context.Context, _a1 string) (*entity.Paste, error) {
 	ret := _m.Called(_a0, _a1)
@@ -68,13 +109,145 @@ func (_m *PastesRepo) Get(_a0 context.Context, _a1 string) (*entity.Paste, error
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: _a0, _a1
-func (_m *PastesRepo) Update(_a0 context.Context, _a1 *entity.Paste) error {
-	ret := _m.Called(_a0, _a1)
+// ListByTags provides a mock function with given fields: ctx, q
+func (_m *PastesRepo) ListByTags(ctx context.Context, q entity.TagQuery) ([]*entity.Paste, error) {
+	ret := _m.Called(ctx, q)
+
+	var r0 []*entity.Paste
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TagQuery) ([]*entity.Paste, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.TagQuery) []*entity.Paste); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Paste)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.TagQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListByUser provides a mock function with given fields: ctx, q
+func (_m *PastesRepo) ListByUser(ctx context.Context, q entity.PasteListQuery) ([]*entity.Paste, error) {
+	ret := _m.Called(ctx, q)
+
+	var r0 []*entity.Paste
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PasteListQuery) ([]*entity.Paste, error)); ok {
+		return rf(ctx, q)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, entity.PasteListQuery) []*entity.Paste); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Paste)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, entity.PasteListQuery) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFeed provides a mock function with given fields: ctx, userID, limit
+func (_m *PastesRepo) ListFeed(ctx context.Context, userID string, limit int) ([]*entity.FeedEntry, error) {
+	ret := _m.Called(ctx, userID, limit)
+
+	var r0 []*entity.FeedEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]*entity.FeedEntry, error)); ok {
+		return rf(ctx, userID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []*entity.FeedEntry); ok {
+		r0 = rf(ctx, userID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.FeedEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, userID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListForks provides a mock function with given fields: ctx, hash
+func (_m *PastesRepo) ListForks(ctx context.Context, hash string) ([]*entity.Paste, error) {
+	ret := _m.Called(ctx, hash)
+
+	var r0 []*entity.Paste
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*entity.Paste, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*entity.Paste); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Paste)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetExpiration provides a mock function with given fields: ctx, hash, expiresAt
+func (_m *PastesRepo) SetExpiration(ctx context.Context, hash string, expiresAt time.Time) error {
+	ret := _m.Called(ctx, hash, expiresAt)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entity.Paste) error); ok {
-		r0 = rf(_a0, _a1)
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, hash, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetViews provides a mock function with given fields: ctx, views
+func (_m *PastesRepo) SetViews(ctx context.Context, views map[string]int) error {
+	ret := _m.Called(ctx, views)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]int) error); ok {
+		r0 = rf(ctx, views)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, hash, update
+func (_m *PastesRepo) Update(ctx context.Context, hash string, update func(p *entity.Paste) error) error {
+	ret := _m.Called(ctx, hash, update)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(p *entity.Paste) error) error); ok {
+		r0 = rf(ctx, hash, update)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -54,6 +54,32 @@ func (_m *UsersRepo) GetByEmail(ctx context.Context, email string) (*entity.User
 	return r0, r1
 }
 
+// GetByUsername provides a mock function with given fields: ctx, username
+func (_m *UsersRepo) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	ret := _m.Called(ctx, username)
+
+	var r0 *entity.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.User, error)); ok {
+		return rf(ctx, username)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.User); ok {
+		r0 = rf(ctx, username)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, username)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewUsersRepo interface {
 	mock.TestingT
 	Cleanup(func())
@@ -1,46 +1,200 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/romankravchuk/pastebin/internal/entity"
 )
 
+const (
+	// viewsFlushBatch is a max number of view counters flushed to database at once.
+	viewsFlushBatch = 500
+	// expiredBatch is a max number of expired pastes deleted at once.
+	expiredBatch = 100
+	// expiredLock is a lock allowing only one instance to delete expired pastes.
+	expiredLock    = "pastes:expired"
+	expiredLockTTL = time.Minute
+	// maxSearchContent is a max size of a paste content indexed for search in bytes.
+	maxSearchContent = 256 << 10
+	// maxTags is a max number of tags of a paste.
+	maxTags = 10
+	// maxTagLen is a max length of a tag in runes.
+	maxTagLen = 32
+)
+
 type PastesUseCase struct {
-	repo  PastesRepo
-	objs  PastesBlobStorage
-	cache PastesCache
+	repo    PastesRepo
+	objs    PastesBlobStorage
+	cache   PastesCache
+	revs    PasteRevisionsRepo
+	files   PasteFilesRepo
+	views   PasteViewsCounter
+	lock    Locker
+	renders PastesRendersCache
+	hl      PastesHighlighter
+	formats FormatDetector
+	valid   FormatValidator
+	conv    FormatConverter
+	query   FormatQuerier
+	schemas SchemaValidator
+	search  PasteSearchRepo
+	access  PasteAccess
+	grants  PasteGrantsRepo
+	feeds   PastesFeedsCache
+	tags    PasteTagsRepo
+	starred PasteStarsCounter
+
+	policy ExpirationPolicy
 }
 
 var _ Pastes = (*PastesUseCase)(nil)
 
-func NewPastes(r PastesRepo, o PastesBlobStorage, c PastesCache) *PastesUseCase {
+func NewPastes(
+	r PastesRepo,
+	o PastesBlobStorage,
+	c PastesCache,
+	rv PasteRevisionsRepo,
+	f PasteFilesRepo,
+	vc PasteViewsCounter,
+	lk Locker,
+	hc PastesRendersCache,
+	hl PastesHighlighter,
+	fd FormatDetector,
+	fv FormatValidator,
+	fc FormatConverter,
+	fq FormatQuerier,
+	sv SchemaValidator,
+	s PasteSearchRepo,
+	a PasteAccess,
+	g PasteGrantsRepo,
+	fe PastesFeedsCache,
+	tg PasteTagsRepo,
+	sc PasteStarsCounter,
+	policy ExpirationPolicy,
+) *PastesUseCase {
 	return &PastesUseCase{
-		objs:  o,
-		repo:  r,
-		cache: c,
+		objs:    o,
+		repo:    r,
+		cache:   c,
+		revs:    rv,
+		files:   f,
+		views:   vc,
+		lock:    lk,
+		renders: hc,
+		hl:      hl,
+		formats: fd,
+		valid:   fv,
+		conv:    fc,
+		query:   fq,
+		schemas: sv,
+		search:  s,
+		access:  a,
+		grants:  g,
+		feeds:   fe,
+		tags:    tg,
+		starred: sc,
+		policy:  policy,
 	}
 }
 
 // Create creates a new paste.
 //
+// The paste expiration date is set from the requested expiration by the expiration policy,
+// if it is not allowed returns ErrInvalidExpiration. Only authenticated users can create
+// never expiring pastes, otherwise returns ErrUnauthorized.
 // Uploads paste text to obj storage and stores paste metadata to database.
+// Files of a multi-file paste are uploaded next to the paste text, the first
+// file is the paste text itself.
+// Formats that are not set are detected from texts. Texts of structured formats
+// are validated, an invalid text returns *entity.FormatError unless the paste
+// allows invalid texts, then it is stored as not valid.
+// Searchable pastes are indexed for search with texts of all their files.
+// Pastes are unlisted unless other visibility is set, only authenticated users
+// can create private pastes, otherwise returns ErrUnauthorized. Cached feeds
+// are invalidated by new public pastes.
+// Tags are normalized, invalid tags or too many of them return ErrInvalidTags.
 func (uc *PastesUseCase) Create(ctx context.Context, p *entity.Paste) error {
 	userID, ok := ctx.Value(entity.UserIDKey).(string)
 	if ok {
-		p.UserID.String = userID
+		p.UserID.String, p.UserID.Valid = userID, true
+	}
+
+	if p.Visibility == "" {
+		p.Visibility = entity.VisibilityUnlisted
+	}
+
+	if p.Visibility == entity.VisibilityPrivate && !ok {
+		return ErrUnauthorized
+	}
+
+	expiresAt, err := uc.policy.expiresAt(p.Expiration, ok)
+	if err != nil {
+		return fmt.Errorf("PastesUseCase.Create: %w", err)
+	}
+
+	if p.Tags, err = normalizeTags(p.Tags); err != nil {
+		return fmt.Errorf("PastesUseCase.Create: %w", err)
+	}
+
+	p.ExpiresAt = expiresAt
+
+	uc.detectFormats(p)
+
+	if err := uc.validateFormats(p); err != nil {
+		return fmt.Errorf("PastesUseCase.Create: %w", err)
+	}
+
+	if err := uc.enforceSchema(ctx, p, p.Format, p.File); err != nil {
+		return fmt.Errorf("PastesUseCase.Create: %w", err)
 	}
 
 	if err := uc.objs.Create(ctx, p); err != nil {
 		return fmt.Errorf("PastesUseCase.Create: %w", err)
 	}
 
-	err := uc.repo.Create(ctx, p)
-	if err != nil {
+	for i := 1; i < len(p.Files); i++ {
+		if err := uc.objs.CreateFile(ctx, p, p.Files[i]); err != nil {
+			return fmt.Errorf("PastesUseCase.Create: %w", err)
+		}
+	}
+
+	if err := uc.repo.Create(ctx, p); err != nil {
 		return fmt.Errorf("PastesUseCase.Create: %w", err)
 	}
 
+	if len(p.Files) > 0 {
+		if err := uc.files.Create(ctx, p.Files); err != nil {
+			return fmt.Errorf("PastesUseCase.Create: %w", err)
+		}
+	}
+
+	if len(p.Tags) > 0 {
+		if err := uc.tags.Set(ctx, p.Hash, p.Tags); err != nil {
+			return fmt.Errorf("PastesUseCase.Create: %w", err)
+		}
+	}
+
+	if searchable(p) {
+		if err := uc.search.Index(ctx, p.Hash, p.Title, searchContent(p)); err != nil {
+			return fmt.Errorf("PastesUseCase.Create: %w", err)
+		}
+	}
+
+	if listed(p) {
+		if err := uc.invalidateFeeds(ctx, p); err != nil {
+			return fmt.Errorf("PastesUseCase.Create: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -55,6 +209,10 @@ func (uc *PastesUseCase) Delete(ctx context.Context, hash string) error {
 
 	paste, err := uc.repo.Get(ctx, hash)
 	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrPasteNotFound
+		}
+
 		return fmt.Errorf("PastesUseCase.Delete: %w", err)
 	}
 
@@ -62,7 +220,7 @@ func (uc *PastesUseCase) Delete(ctx context.Context, hash string) error {
 		return ErrNotPasteAuthor
 	}
 
-	if err := uc.objs.Delete(ctx, "", hash); err != nil {
+	if err := uc.objs.Delete(ctx, paste.UserID.String, hash); err != nil {
 		return fmt.Errorf("PastesUseCase.Delete: %w", err)
 	}
 
@@ -70,19 +228,96 @@ func (uc *PastesUseCase) Delete(ctx context.Context, hash string) error {
 		return fmt.Errorf("PastesUseCase.Delete: %w", err)
 	}
 
+	if err := uc.cache.Delete(ctx, hash); err != nil {
+		return fmt.Errorf("PastesUseCase.Delete: %w", err)
+	}
+
+	if err := uc.views.Delete(ctx, hash); err != nil {
+		return fmt.Errorf("PastesUseCase.Delete: %w", err)
+	}
+
+	if err := uc.renders.Delete(ctx, hash); err != nil {
+		return fmt.Errorf("PastesUseCase.Delete: %w", err)
+	}
+
+	if err := uc.starred.Delete(ctx, hash); err != nil {
+		return fmt.Errorf("PastesUseCase.Delete: %w", err)
+	}
+
+	if listed(paste) {
+		if err := uc.invalidateFeeds(ctx, paste); err != nil {
+			return fmt.Errorf("PastesUseCase.Delete: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // Get returns a paste by hash.
 //
 // First checks if the paste is in the cache. If not, it gets the paste from the database.
-// Then it gets the paste text from the obj storage.
+// A private paste is readable only by its author and users granted access, for others
+// returns ErrPasteNotFound, even if the paste is cached.
+// If the paste is expired returns ErrPasteExpired. Then it gets the paste text from the obj storage.
+// A read of a paste without password is counted as a view, if the views limit is
+// reached returns ErrPasteGone. A burn after read paste without password is burned
+// by the first successful read.
 func (uc *PastesUseCase) Get(ctx context.Context, hash string) (*entity.Paste, error) {
-	paste, ok, err := uc.cache.Get(ctx, hash)
+	paste, err := uc.get(ctx, hash)
 	if err != nil {
 		return nil, fmt.Errorf("PastesUseCase.Get: %w", err)
 	}
 
+	if paste.Password.Hash != nil {
+		return paste, nil
+	}
+
+	if err := uc.view(ctx, paste); err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Get: %w", err)
+	}
+
+	if paste.BurnAfterRead {
+		if err := uc.burn(ctx, paste); err != nil {
+			return nil, fmt.Errorf("PastesUseCase.Get: %w", err)
+		}
+	}
+
+	return paste, nil
+}
+
+// Unlock returns a paste by hash if the password matches the paste one,
+// otherwise returns ErrPasteLocked.
+// A successful unlock is counted as a view, if the views limit is reached
+// returns ErrPasteGone. A burn after read paste is burned by the first successful unlock.
+func (uc *PastesUseCase) Unlock(ctx context.Context, hash, password string) (*entity.Paste, error) {
+	paste, err := uc.get(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Unlock: %w", err)
+	}
+
+	if paste.Password.Hash != nil && !paste.Password.Matches(password) {
+		return nil, ErrPasteLocked
+	}
+
+	if err := uc.view(ctx, paste); err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Unlock: %w", err)
+	}
+
+	if paste.BurnAfterRead {
+		if err := uc.burn(ctx, paste); err != nil {
+			return nil, fmt.Errorf("PastesUseCase.Unlock: %w", err)
+		}
+	}
+
+	return paste, nil
+}
+
+func (uc *PastesUseCase) get(ctx context.Context, hash string) (*entity.Paste, error) {
+	paste, ok, err := uc.cache.Get(ctx, hash)
+	if err != nil {
+		return nil, err
+	}
+
 	if !ok {
 		paste, err = uc.repo.Get(ctx, hash)
 		if err != nil {
@@ -90,27 +325,1271 @@ func (uc *PastesUseCase) Get(ctx context.Context, hash string) (*entity.Paste, e
 				return nil, ErrPasteNotFound
 			}
 
-			return nil, fmt.Errorf("PastesUseCase.Get: %w", err)
+			return nil, err
 		}
 	}
 
+	if err := uc.access.Check(ctx, paste); err != nil {
+		return nil, err
+	}
+
+	if paste.Expired() {
+		return nil, ErrPasteExpired
+	}
+
 	paste.File, err = uc.objs.Get(ctx, paste.UserID.String, paste.Hash)
 	if err != nil {
-		return nil, fmt.Errorf("PastesUseCase.Get: %w", err)
+		return nil, err
+	}
+
+	if err := loadFiles(ctx, uc.objs, paste); err != nil {
+		return nil, err
 	}
 
 	return paste, nil
 }
 
-// Update implements Pastes.
+// burn claims a burn after read paste and deletes it everywhere.
+//
+// The claim is the deletion of the database row, so only one of concurrent
+// readers succeeds, the others get ErrPasteNotFound. Cached feeds are invalidated,
+// so they never keep a burned paste.
+func (uc *PastesUseCase) burn(ctx context.Context, paste *entity.Paste) error {
+	if err := uc.repo.Burn(ctx, paste.Hash); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrPasteNotFound
+		}
+
+		return err
+	}
+
+	if err := uc.objs.Delete(ctx, paste.UserID.String, paste.Hash); err != nil {
+		return err
+	}
+
+	if err := uc.cache.Delete(ctx, paste.Hash); err != nil {
+		return err
+	}
+
+	if err := uc.views.Delete(ctx, paste.Hash); err != nil {
+		return err
+	}
+
+	if err := uc.starred.Delete(ctx, paste.Hash); err != nil {
+		return err
+	}
+
+	return uc.invalidateFeeds(ctx, paste)
+}
+
+// view counts a paste view. If the views limit is reached returns ErrPasteGone.
+// The viewed paste gets the current number of stars.
+func (uc *PastesUseCase) view(ctx context.Context, paste *entity.Paste) error {
+	n, err := uc.views.Incr(ctx, paste.Hash, paste.Views, paste.MaxViews)
+	if err != nil {
+		return err
+	}
+
+	if n < 0 {
+		return ErrPasteGone
+	}
+
+	paste.Views = n
+
+	paste.Stars, err = starCount(ctx, uc.starred, paste)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes expired pastes from database, obj storage and cache, with their
+// views and stars counters and renders, in batches.
+// Cached feeds of all users and of authors of deleted pastes are invalidated.
+//
+// Only one instance of the service deletes expired pastes at a time,
+// if another instance holds the lock DeleteExpired does nothing.
+func (uc *PastesUseCase) DeleteExpired(ctx context.Context) error {
+	ok, err := uc.lock.Acquire(ctx, expiredLock, expiredLockTTL)
+	if err != nil {
+		return fmt.Errorf("PastesUseCase.DeleteExpired: %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	defer func() {
+		// The lock expires by itself, so an error only delays the next run.
+		_ = uc.lock.Release(context.WithoutCancel(ctx), expiredLock)
+	}()
+
+	var (
+		errs  []error
+		feeds = make(map[string]struct{})
+	)
+
+	for {
+		pastes, err := uc.repo.DeleteExpired(ctx, expiredBatch)
+		if err != nil {
+			return fmt.Errorf("PastesUseCase.DeleteExpired: %w", err)
+		}
+
+		for _, p := range pastes {
+			feeds[""] = struct{}{}
+			if p.UserID.Valid {
+				feeds[p.UserID.String] = struct{}{}
+			}
+
+			if err := uc.objs.Delete(ctx, p.UserID.String, p.Hash); err != nil {
+				errs = append(errs, err)
+			}
+
+			if err := uc.cache.Delete(ctx, p.Hash); err != nil {
+				errs = append(errs, err)
+			}
+
+			if err := uc.views.Delete(ctx, p.Hash); err != nil {
+				errs = append(errs, err)
+			}
+
+			if err := uc.renders.Delete(ctx, p.Hash); err != nil {
+				errs = append(errs, err)
+			}
+
+			if err := uc.starred.Delete(ctx, p.Hash); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if len(pastes) < expiredBatch {
+			break
+		}
+	}
+
+	for userID := range feeds {
+		if err := uc.feeds.Delete(ctx, userID); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("PastesUseCase.DeleteExpired: %w", err)
+	}
+
+	return nil
+}
+
+// FlushViews stores view counters from the counter to database in batches.
+// Pastes are marked flushed only after their views are stored.
+func (uc *PastesUseCase) FlushViews(ctx context.Context) error {
+	for {
+		views, err := uc.views.Pending(ctx, viewsFlushBatch)
+		if err != nil {
+			return fmt.Errorf("PastesUseCase.FlushViews: %w", err)
+		}
+
+		if len(views) == 0 {
+			return nil
+		}
+
+		if err := uc.repo.SetViews(ctx, views); err != nil {
+			return fmt.Errorf("PastesUseCase.FlushViews: %w", err)
+		}
+
+		if err := uc.views.Flushed(ctx, views); err != nil {
+			return fmt.Errorf("PastesUseCase.FlushViews: %w", err)
+		}
+	}
+}
+
+// Update updates a paste.
+//
+// Only the author of the paste can update it, otherwise returns ErrNotPasteAuthor.
+// The paste row is locked for the update, the previous version of the paste is kept
+// as a revision and the revision number is incremented in one transaction.
+// Zero valued fields of p are left unchanged, an empty password hash removes
+// the password. A new expiration is checked by the expiration policy as in Create.
+// A new text or format is validated as in Create. A valid schema of p replaces the paste
+// schema, an empty one removes it, and the paste text is checked against the new schema
+// as in Create, so pastes with missing schemas can be edited after the schema is replaced
+// or removed. The paste text is rewritten in the obj storage only when p has a file.
+// Files of p set new texts of the multi-file paste files by names, an unknown name
+// returns ErrFileNotFound, and the revision keeps texts of all files.
+// The cached paste and its renders are invalidated, and cached feeds if the paste
+// is public before or after the update. The paste is reindexed for search,
+// or removed from the index if it is not searchable anymore.
+// On success p is replaced with the updated paste.
 func (uc *PastesUseCase) Update(ctx context.Context, p *entity.Paste) error {
-	if err := uc.objs.Update(ctx, p); err != nil {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return ErrNotPasteAuthor
+	}
+
+	var err error
+
+	if !p.Expiration.IsZero() {
+		p.ExpiresAt, err = uc.policy.expiresAt(p.Expiration, true)
+		if err != nil {
+			return fmt.Errorf("PastesUseCase.Update: %w", err)
+		}
+	}
+
+	if p.Tags != nil {
+		if p.Tags, err = normalizeTags(p.Tags); err != nil {
+			return fmt.Errorf("PastesUseCase.Update: %w", err)
+		}
+	}
+
+	var (
+		paste     *entity.Paste
+		wasListed bool
+	)
+
+	err = uc.repo.Update(ctx, p.Hash, func(locked *entity.Paste) error {
+		paste, wasListed = locked, listed(locked)
+
+		return uc.revise(ctx, paste, p, userID)
+	})
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrPasteNotFound
+		}
+
+		return fmt.Errorf("PastesUseCase.Update: %w", err)
+	}
+
+	if p.Tags != nil {
+		if err := uc.tags.Set(ctx, paste.Hash, paste.Tags); err != nil {
+			return fmt.Errorf("PastesUseCase.Update: %w", err)
+		}
+	}
+
+	if err := indexPaste(ctx, uc.search, uc.objs, paste); err != nil {
+		return fmt.Errorf("PastesUseCase.Update: %w", err)
+	}
+
+	if err := uc.cache.Delete(ctx, paste.Hash); err != nil {
 		return fmt.Errorf("PastesUseCase.Update: %w", err)
 	}
 
-	if err := uc.repo.Update(ctx, p); err != nil {
+	if err := uc.renders.Delete(ctx, paste.Hash); err != nil {
 		return fmt.Errorf("PastesUseCase.Update: %w", err)
 	}
 
+	if wasListed || listed(paste) {
+		if err := uc.invalidateFeeds(ctx, paste); err != nil {
+			return fmt.Errorf("PastesUseCase.Update: %w", err)
+		}
+	}
+
+	*p = *paste
+
+	return nil
+}
+
+// revise applies the changes of p to the paste locked by Update. It is called
+// inside the update transaction, so the revision object is copied and the new
+// text is written while no other update of the paste can run.
+func (uc *PastesUseCase) revise(ctx context.Context, paste, p *entity.Paste, userID string) error {
+	if paste.UserID.String != userID {
+		return ErrNotPasteAuthor
+	}
+
+	var err error
+
+	paste.File, err = uc.objs.Get(ctx, paste.UserID.String, paste.Hash)
+	if err != nil {
+		return err
+	}
+
+	if err := loadFiles(ctx, uc.objs, paste); err != nil {
+		return err
+	}
+
+	files, err := updatedFiles(paste, p)
+	if err != nil {
+		return err
+	}
+
+	valid := paste.Valid
+
+	if p.Schema.Valid {
+		paste.Schema.String, paste.Schema.Valid = p.Schema.String, p.Schema.String != ""
+	}
+
+	if p.File != nil || p.Format != "" || p.Schema.Valid {
+		format, text := paste.Format, paste.File
+		if p.Format != "" {
+			format = p.Format
+		}
+
+		if p.File != nil {
+			text = p.File
+		}
+
+		if p.File != nil || p.Format != "" {
+			valid, err = uc.validateText(format, text, p.AllowInvalid)
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := uc.enforceSchema(ctx, paste, format, text); err != nil {
+			return err
+		}
+	}
+
+	if len(paste.Files) > 0 && (p.File != nil || p.Format != "" || len(files) > 0) {
+		if p.File == nil && p.Format == "" {
+			// The paste text is unchanged, so only its validity is checked.
+			if valid, err = uc.validateText(paste.Format, paste.File, true); err != nil {
+				return err
+			}
+		}
+
+		filesValid, err := uc.validateFiles(paste, files, p.AllowInvalid)
+		if err != nil {
+			return err
+		}
+
+		valid = valid && filesValid
+	}
+
+	if err := uc.objs.CreateRevision(ctx, paste); err != nil {
+		return err
+	}
+
+	mergePaste(paste, p)
+	paste.Valid = valid
+	paste.Revision++
+
+	if p.File != nil {
+		if err := uc.objs.Update(ctx, paste); err != nil {
+			return err
+		}
+	}
+
+	for i, f := range paste.Files {
+		text, ok := files[i]
+		if !ok {
+			continue
+		}
+
+		if err := uc.objs.CreateFile(ctx, paste, &entity.PasteFile{Hash: f.Hash, Name: f.Name, File: text}); err != nil {
+			return err
+		}
+
+		f.File = text
+	}
+
+	if len(paste.Files) > 0 {
+		paste.Files[0].Format, paste.Files[0].File = paste.Format, paste.File
+	}
+
 	return nil
 }
+
+// updatedFiles returns new texts of the paste files from the update p by file
+// positions. The first file is the paste text, so its new text becomes the text
+// of the update unless the update has one. An unknown file name returns ErrFileNotFound.
+func updatedFiles(paste, p *entity.Paste) (map[int]entity.File, error) {
+	files := make(map[int]entity.File, len(p.Files))
+
+	for _, f := range p.Files {
+		i := len(paste.Files) - 1
+		for i >= 0 && paste.Files[i].Name != f.Name {
+			i--
+		}
+
+		if i < 0 {
+			return nil, ErrFileNotFound
+		}
+
+		files[i] = f.File
+	}
+
+	if text, ok := files[0]; ok && p.File == nil {
+		p.File = text
+	}
+
+	delete(files, 0)
+
+	return files, nil
+}
+
+// validateFiles validates new texts of the paste files other than the first one
+// as in Create and reports whether all these files are valid after the update.
+// Unchanged texts are only checked for validity, so an update never fails on them.
+func (uc *PastesUseCase) validateFiles(paste *entity.Paste, files map[int]entity.File, allowInvalid bool) (bool, error) {
+	valid := true
+
+	for i := 1; i < len(paste.Files); i++ {
+		f := paste.Files[i]
+
+		text, changed := files[i]
+		if !changed {
+			text = f.File
+		}
+
+		ok, err := uc.validateText(f.Format, text, allowInvalid || !changed)
+		if err != nil {
+			var fe *entity.FormatError
+			if errors.As(err, &fe) {
+				fe.File = f.Name
+			}
+
+			return false, err
+		}
+
+		valid = valid && ok
+	}
+
+	return valid, nil
+}
+
+// Extend sets a new expiration date of a paste from the requested expiration.
+//
+// Only the author of the paste can extend it, otherwise returns ErrNotPasteAuthor.
+// An already expired paste can not be extended, in this case returns ErrPasteExpired.
+// The expiration is checked by the expiration policy as in Create.
+// Returns the paste metadata with the new expiration date.
+func (uc *PastesUseCase) Extend(ctx context.Context, hash string, e entity.Expiration) (*entity.Paste, error) {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return nil, ErrNotPasteAuthor
+	}
+
+	paste, err := uc.repo.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrPasteNotFound
+		}
+
+		return nil, fmt.Errorf("PastesUseCase.Extend: %w", err)
+	}
+
+	if paste.UserID.String != userID {
+		return nil, ErrNotPasteAuthor
+	}
+
+	if paste.Expired() {
+		return nil, ErrPasteExpired
+	}
+
+	expiresAt, err := uc.policy.expiresAt(e, true)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Extend: %w", err)
+	}
+
+	if err := uc.repo.SetExpiration(ctx, hash, expiresAt); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrPasteExpired
+		}
+
+		return nil, fmt.Errorf("PastesUseCase.Extend: %w", err)
+	}
+
+	if err := uc.cache.Delete(ctx, hash); err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Extend: %w", err)
+	}
+
+	paste.ExpiresAt = expiresAt
+
+	return paste, nil
+}
+
+// Highlight returns the paste rendered with syntax highlighting to the output of opts.
+//
+// The paste is read as in Get, or as in Unlock if the password is given, so a render
+// is counted as a view. If the paste is locked and the password is not given returns
+// ErrPasteLocked. If the theme is unknown returns ErrUnknownTheme.
+// Highlighted lines are sorted, merged and cut to the paste lines, so equal
+// highlights share a render. Renders are cached per paste and options until
+// the paste is updated.
+func (uc *PastesUseCase) Highlight(ctx context.Context, hash, password string, opts entity.HighlightOptions) ([]byte, error) {
+	if opts.Theme != "" && !uc.hl.HasTheme(opts.Theme) {
+		return nil, ErrUnknownTheme
+	}
+
+	paste, err := uc.read(ctx, hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Highlight: %w", err)
+	}
+
+	opts.Lines = normalizeLines(opts.Lines, lineCount(paste.File))
+	key := opts.Key()
+
+	render, ok, err := uc.renders.Get(ctx, hash, key)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Highlight: %w", err)
+	}
+
+	if ok {
+		return render, nil
+	}
+
+	render, err = uc.hl.Highlight(paste, opts)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Highlight: %w", err)
+	}
+
+	// A burned paste is never read again.
+	if !paste.BurnAfterRead {
+		if err := uc.renders.Set(ctx, hash, key, render); err != nil {
+			return nil, fmt.Errorf("PastesUseCase.Highlight: %w", err)
+		}
+	}
+
+	return render, nil
+}
+
+// Convert converts the paste text to the structured format of opts.
+//
+// The conversion must have converted_from set, the source paste is read as in Highlight.
+// The conversion gets the converted text, the target format and the source title and
+// visibility unless already set. If save is true the conversion is stored as a new paste
+// through Create with the source password, burn after read and views limit, so it must
+// have a hash. If the paste can not be converted returns ErrNotConvertible,
+// if the paste text does not match its format returns *entity.FormatError.
+func (uc *PastesUseCase) Convert(ctx context.Context, conv *entity.Paste, password string, opts entity.ConvertOptions, save bool) error {
+	source, err := uc.read(ctx, conv.ConvertedFrom.String, password)
+	if err != nil {
+		return fmt.Errorf("PastesUseCase.Convert: %w", err)
+	}
+
+	if opts.To == "" {
+		opts.To = source.Format
+	}
+
+	conv.File, err = uc.conv.Convert(source.Format, source.File, opts)
+	if err != nil {
+		return fmt.Errorf("PastesUseCase.Convert: %w", err)
+	}
+
+	conv.Format = opts.To
+
+	if conv.Title == "" {
+		conv.Title = source.Title
+	}
+
+	if conv.Visibility == "" {
+		conv.Visibility = source.Visibility
+	}
+
+	if conv.Tags == nil {
+		conv.Tags = source.Tags
+	}
+
+	if !save {
+		return nil
+	}
+	// The saved paste keeps the source protections, so it does not outlive them as an open copy.
+	conv.Password.Hash = source.Password.Hash
+	conv.BurnAfterRead, conv.MaxViews = source.BurnAfterRead, source.MaxViews
+
+	if err := uc.Create(ctx, conv); err != nil {
+		return fmt.Errorf("PastesUseCase.Convert: %w", err)
+	}
+
+	return nil
+}
+
+// Query evaluates the expression against the paste text and returns matched values as JSON.
+//
+// The paste is read as in Highlight. If the paste format is not json, yaml, toml
+// or xml returns ErrNotQueryable, if the expression is invalid returns *entity.QueryError
+// and if the query exceeds the time or output size limits returns ErrQueryLimit.
+func (uc *PastesUseCase) Query(ctx context.Context, hash, password, expr string) ([]json.RawMessage, error) {
+	paste, err := uc.read(ctx, hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Query: %w", err)
+	}
+
+	results, err := uc.query.Query(ctx, paste.Format, paste.File, expr)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.Query: %w", err)
+	}
+
+	return results, nil
+}
+
+// ValidateSchema validates the paste text against the JSON schema of the schema paste
+// and returns violations of the schema, no violations mean the text is valid.
+//
+// The paste is read as in Highlight. If the schema hash is empty the paste schema is used.
+// If the schema paste is missing, expired or locked with password returns ErrSchemaNotFound,
+// if the paste is not json, yaml or toml returns ErrSchemaNotApplicable and
+// if the schema paste is not a JSON schema returns ErrInvalidSchema.
+func (uc *PastesUseCase) ValidateSchema(ctx context.Context, hash, password, schema string) ([]entity.SchemaViolation, error) {
+	paste, err := uc.read(ctx, hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.ValidateSchema: %w", err)
+	}
+
+	if schema == "" {
+		schema = paste.Schema.String
+	}
+
+	violations, err := uc.checkSchema(ctx, schema, paste.Format, paste.File)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.ValidateSchema: %w", err)
+	}
+
+	return violations, nil
+}
+
+// enforceSchema returns *entity.SchemaError if the text of the format violates the paste schema.
+// Pastes without schema accept any text.
+func (uc *PastesUseCase) enforceSchema(ctx context.Context, p *entity.Paste, format string, text entity.File) error {
+	if !p.Schema.Valid {
+		return nil
+	}
+
+	violations, err := uc.checkSchema(ctx, p.Schema.String, format, text)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return &entity.SchemaError{Schema: p.Schema.String, Violations: violations}
+	}
+
+	return nil
+}
+
+// checkSchema validates the text of the format against the schema paste.
+// Schema pastes are read without counting views, so burn after read
+// and views limited schemas are not used up by validations.
+func (uc *PastesUseCase) checkSchema(ctx context.Context, schema, format string, text entity.File) ([]entity.SchemaViolation, error) {
+	if schema == "" {
+		return nil, ErrSchemaNotFound
+	}
+
+	s, err := uc.get(ctx, schema)
+	if err != nil {
+		if errors.Is(err, ErrPasteNotFound) || errors.Is(err, ErrPasteExpired) {
+			return nil, ErrSchemaNotFound
+		}
+
+		return nil, err
+	}
+
+	if s.Password.Hash != nil {
+		return nil, ErrSchemaNotFound
+	}
+
+	return uc.schemas.Validate(s.Format, s.File, format, text)
+}
+
+// read returns a paste as in Get, or as in Unlock if the password is given.
+// If the paste is locked and the password is not given returns ErrPasteLocked.
+func (uc *PastesUseCase) read(ctx context.Context, hash, password string) (*entity.Paste, error) {
+	var (
+		paste *entity.Paste
+		err   error
+	)
+
+	if password != "" {
+		paste, err = uc.Unlock(ctx, hash, password)
+	} else {
+		paste, err = uc.Get(ctx, hash)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if password == "" && paste.Password.Hash != nil {
+		return nil, ErrPasteLocked
+	}
+
+	return paste, nil
+}
+
+// GetRevisions returns all revisions of a paste.
+//
+// The list ends with the current version of the paste. Listed revisions do not contain files.
+// If the password does not match the paste one returns ErrPasteLocked.
+func (uc *PastesUseCase) GetRevisions(ctx context.Context, hash, password string) ([]*entity.PasteRevision, error) {
+	paste, err := uc.access.Meta(ctx, hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetRevisions: %w", err)
+	}
+
+	revs, err := uc.revs.List(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetRevisions: %w", err)
+	}
+
+	return append(revs, paste.Revisioned()), nil
+}
+
+// GetRevision returns a paste revision with its file.
+//
+// If the revision is the current one, the current paste file is returned.
+// Revisions of multi-file pastes have texts of all files, except revisions
+// stored before files were kept, which have only the paste text.
+// If the password does not match the paste one returns ErrPasteLocked.
+func (uc *PastesUseCase) GetRevision(ctx context.Context, hash, password string, revision int) (*entity.PasteRevision, error) {
+	paste, err := uc.access.Text(ctx, hash, password)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetRevision: %w", err)
+	}
+
+	rev, err := uc.getRevisionFile(ctx, paste, revision)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetRevision: %w", err)
+	}
+
+	if err := uc.loadRevisionFiles(ctx, paste, rev); err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetRevision: %w", err)
+	}
+
+	return rev, nil
+}
+
+// loadRevisionFiles loads files of a multi-file paste revision. File names and
+// formats do not change between revisions, so they are taken from the paste.
+func (uc *PastesUseCase) loadRevisionFiles(ctx context.Context, paste *entity.Paste, rev *entity.PasteRevision) error {
+	if rev.Revision == paste.Revision {
+		paste.File = rev.File
+
+		if err := loadFiles(ctx, uc.objs, paste); err != nil {
+			return err
+		}
+
+		rev.Files = paste.Files
+
+		return nil
+	}
+
+	if !rev.HasFiles {
+		return nil
+	}
+
+	for i, f := range paste.Files {
+		file := &entity.PasteFile{Hash: f.Hash, Position: f.Position, Name: f.Name, Format: f.Format}
+
+		if i == 0 {
+			file.Format, file.File = rev.Format, rev.File
+		} else {
+			text, err := uc.objs.GetRevisionFile(ctx, paste.UserID.String, paste.Hash, rev.Revision, f.Name)
+			if err != nil {
+				return err
+			}
+
+			file.File = text
+		}
+
+		rev.Files = append(rev.Files, file)
+	}
+
+	return nil
+}
+
+// Fork creates a copy of the paste owned by the user from context.
+//
+// The fork must have a hash and forked_from set, the rest is copied from the source
+// paste unless already set, the fork of a protected paste keeps its password.
+// The fork is stored through Create. If context does not
+// have user id returns ErrUnauthorized. If the password does not match the source
+// paste one returns ErrPasteLocked.
+func (uc *PastesUseCase) Fork(ctx context.Context, fork *entity.Paste, password string) error {
+	if _, ok := ctx.Value(entity.UserIDKey).(string); !ok {
+		return ErrUnauthorized
+	}
+
+	source, err := uc.access.Text(ctx, fork.ForkedFrom.String, password)
+	if err != nil {
+		return fmt.Errorf("PastesUseCase.Fork: %w", err)
+	}
+
+	source.File, err = uc.objs.Get(ctx, source.UserID.String, source.Hash)
+	if err != nil {
+		return fmt.Errorf("PastesUseCase.Fork: %w", err)
+	}
+
+	if err := loadFiles(ctx, uc.objs, source); err != nil {
+		return fmt.Errorf("PastesUseCase.Fork: %w", err)
+	}
+
+	fork.File = source.File
+
+	for _, f := range source.Files {
+		copied := *f
+		copied.Hash = fork.Hash
+		fork.Files = append(fork.Files, &copied)
+	}
+
+	if fork.Title == "" {
+		fork.Title = source.Title
+	}
+
+	fork.Format, fork.FormatConfidence = source.Format, source.FormatConfidence
+	// The fork of a protected paste is protected with the same password.
+	fork.Password.Hash = source.Password.Hash
+
+	if fork.Visibility == "" {
+		fork.Visibility = source.Visibility
+	}
+
+	if fork.Tags == nil {
+		fork.Tags = source.Tags
+	}
+	// The fork copies the source text as is, even if it does not match the format.
+	fork.AllowInvalid = true
+
+	if err := uc.Create(ctx, fork); err != nil {
+		return fmt.Errorf("PastesUseCase.Fork: %w", err)
+	}
+
+	if err := uc.cache.Delete(ctx, source.Hash); err != nil {
+		return fmt.Errorf("PastesUseCase.Fork: %w", err)
+	}
+
+	return nil
+}
+
+// GetForks returns metadata of pastes forked from the paste.
+// Private pastes and their private forks are visible only to their authors
+// and users granted access.
+func (uc *PastesUseCase) GetForks(ctx context.Context, hash string) ([]*entity.Paste, error) {
+	paste, err := uc.repo.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrPasteNotFound
+		}
+
+		return nil, fmt.Errorf("PastesUseCase.GetForks: %w", err)
+	}
+
+	if err := uc.access.Check(ctx, paste); err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetForks: %w", err)
+	}
+
+	forks, err := uc.repo.ListForks(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetForks: %w", err)
+	}
+
+	visible := make([]*entity.Paste, 0, len(forks))
+
+	for _, f := range forks {
+		if err := uc.access.Check(ctx, f); err != nil {
+			if errors.Is(err, ErrPasteNotFound) {
+				continue
+			}
+
+			return nil, fmt.Errorf("PastesUseCase.GetForks: %w", err)
+		}
+
+		visible = append(visible, f)
+	}
+
+	return visible, nil
+}
+
+// GrantAccess grants the user with the username access to the private paste.
+//
+// Only the author of the paste can grant access, otherwise returns ErrNotPasteAuthor.
+// If the user does not exist returns ErrUserNotFound.
+func (uc *PastesUseCase) GrantAccess(ctx context.Context, hash, username string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("PastesUseCase.GrantAccess: %w", err)
+	}
+
+	if err := uc.grants.Create(ctx, hash, username); err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return ErrUserNotFound
+		}
+
+		return fmt.Errorf("PastesUseCase.GrantAccess: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAccess revokes access of the user with the username to the private paste.
+// Only the author of the paste can revoke access, otherwise returns ErrNotPasteAuthor.
+func (uc *PastesUseCase) RevokeAccess(ctx context.Context, hash, username string) error {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return fmt.Errorf("PastesUseCase.RevokeAccess: %w", err)
+	}
+
+	if err := uc.grants.Delete(ctx, hash, username); err != nil {
+		return fmt.Errorf("PastesUseCase.RevokeAccess: %w", err)
+	}
+
+	return nil
+}
+
+// GetGrants returns names of users granted access to the paste.
+// Only the author of the paste can get them, otherwise returns ErrNotPasteAuthor.
+func (uc *PastesUseCase) GetGrants(ctx context.Context, hash string) ([]string, error) {
+	if _, err := uc.own(ctx, hash); err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetGrants: %w", err)
+	}
+
+	usernames, err := uc.grants.List(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.GetGrants: %w", err)
+	}
+
+	return usernames, nil
+}
+
+// own returns a paste metadata from database if the user from context is its author,
+// otherwise returns ErrNotPasteAuthor.
+func (uc *PastesUseCase) own(ctx context.Context, hash string) (*entity.Paste, error) {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return nil, ErrNotPasteAuthor
+	}
+
+	paste, err := uc.repo.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil, ErrPasteNotFound
+		}
+
+		return nil, err
+	}
+
+	if paste.UserID.String != userID {
+		return nil, ErrNotPasteAuthor
+	}
+
+	return paste, nil
+}
+
+// detectFormats detects formats of the paste and its files that are not set.
+// Formats set by the author have confidence 1.
+func (uc *PastesUseCase) detectFormats(p *entity.Paste) {
+	switch {
+	case p.Format == "":
+		d := uc.formats.Detect(p.File)
+		p.Format, p.FormatConfidence = d.Format, d.Confidence
+	case p.FormatConfidence == 0:
+		p.FormatConfidence = 1
+	}
+
+	for i, f := range p.Files {
+		switch {
+		case i == 0:
+			f.Format = p.Format
+		case f.Format == "":
+			f.Format = uc.formats.Detect(f.File).Format
+		}
+	}
+}
+
+// validateFormats validates texts of the paste and its files and sets the paste validity.
+func (uc *PastesUseCase) validateFormats(p *entity.Paste) error {
+	if len(p.Files) == 0 {
+		valid, err := uc.validateText(p.Format, p.File, p.AllowInvalid)
+		p.Valid = valid
+
+		return err
+	}
+
+	p.Valid = true
+
+	for _, f := range p.Files {
+		valid, err := uc.validateText(f.Format, f.File, p.AllowInvalid)
+		if err != nil {
+			var fe *entity.FormatError
+			if errors.As(err, &fe) {
+				fe.File = f.Name
+			}
+
+			return err
+		}
+
+		p.Valid = p.Valid && valid
+	}
+
+	return nil
+}
+
+// validateText reports whether the text matches the format.
+// If invalid texts are not allowed, an invalid text returns *entity.FormatError.
+func (uc *PastesUseCase) validateText(format string, text entity.File, allowInvalid bool) (bool, error) {
+	err := uc.valid.Validate(format, text)
+	if err == nil {
+		return true, nil
+	}
+
+	if allowInvalid && errors.As(err, new(*entity.FormatError)) {
+		return false, nil
+	}
+
+	return false, err
+}
+
+// ListUserPastes returns a page of metadata of pastes of the user from context.
+//
+// If context does not have user id returns ErrUnauthorized. Texts are not loaded.
+// The next page cursor is set only if there are more pastes.
+func (uc *PastesUseCase) ListUserPastes(ctx context.Context, q entity.PasteListQuery) (*entity.PastesPage, error) {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	if len(q.Tags) > 0 {
+		var err error
+		if q.Tags, err = normalizeTags(q.Tags); err != nil {
+			return nil, fmt.Errorf("PastesUseCase.ListUserPastes: %w", err)
+		}
+	}
+
+	q.UserID = userID
+
+	pastes, next, err := listPage(q.Limit, func(limit int) ([]*entity.Paste, error) {
+		q.Limit = limit
+
+		return uc.repo.ListByUser(ctx, q)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("PastesUseCase.ListUserPastes: %w", err)
+	}
+
+	page := &entity.PastesPage{Pastes: pastes}
+
+	if next {
+		page.Next = q.Sort.Cursor(pastes[len(pastes)-1])
+	}
+
+	return page, nil
+}
+
+// invalidateFeeds removes cached feeds that may list the paste.
+func (uc *PastesUseCase) invalidateFeeds(ctx context.Context, paste *entity.Paste) error {
+	if err := uc.feeds.Delete(ctx, ""); err != nil {
+		return err
+	}
+
+	if paste.UserID.Valid {
+		return uc.feeds.Delete(ctx, paste.UserID.String)
+	}
+
+	return nil
+}
+
+// loadFiles loads texts of files of a multi-file paste, the files metadata is read
+// together with the paste. The first file is the paste text itself, so it takes
+// the paste text and format. Texts of files that are already loaded are kept.
+// Single file pastes have no files.
+func loadFiles(ctx context.Context, objs PastesBlobStorage, paste *entity.Paste) error {
+	var err error
+
+	for i, f := range paste.Files {
+		if i == 0 {
+			f.Format, f.File = paste.Format, paste.File
+
+			continue
+		}
+
+		if f.File != nil {
+			continue
+		}
+
+		f.File, err = objs.GetFile(ctx, paste.UserID.String, paste.Hash, f.Name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// indexPaste reindexes the paste for search with texts of all its files,
+// or removes it from the index if it is not searchable.
+func indexPaste(ctx context.Context, search PasteSearchRepo, objs PastesBlobStorage, paste *entity.Paste) error {
+	if !searchable(paste) {
+		return search.Delete(ctx, paste.Hash)
+	}
+
+	if err := loadFiles(ctx, objs, paste); err != nil {
+		return err
+	}
+
+	return search.Index(ctx, paste.Hash, paste.Title, searchContent(paste))
+}
+
+// searchable reports whether the paste text can be found by search.
+// Texts of pastes locked with password or with limited views must not leak
+// through search snippets.
+func searchable(p *entity.Paste) bool {
+	return p.Password.Hash == nil && !p.BurnAfterRead && p.MaxViews == 0
+}
+
+// listed reports whether the paste is listed in feeds. Feeds show excerpts of texts,
+// so they list only public pastes that are searchable.
+func listed(p *entity.Paste) bool {
+	return p.Visibility == entity.VisibilityPublic && searchable(p)
+}
+
+// searchContent returns texts of all files of the paste joined by new lines.
+// The content is cut to maxSearchContent bytes, and invalid UTF-8 and NUL bytes
+// that database texts can not hold are dropped.
+func searchContent(p *entity.Paste) string {
+	var b strings.Builder
+
+	if len(p.Files) == 0 {
+		b.Write(p.File)
+	}
+
+	for i, f := range p.Files {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+
+		b.Write(f.File)
+	}
+
+	content := strings.ReplaceAll(strings.ToValidUTF8(b.String(), ""), "\x00", "")
+	if len(content) <= maxSearchContent {
+		return content
+	}
+
+	end := maxSearchContent
+	for end > 0 && !utf8.RuneStart(content[end]) {
+		end--
+	}
+
+	return content[:end]
+}
+
+// mergePaste copies non-zero fields of src into dst.
+func mergePaste(dst, src *entity.Paste) {
+	if src.Title != "" {
+		dst.Title = src.Title
+	}
+
+	if src.Format != "" {
+		dst.Format = src.Format
+		dst.FormatConfidence = 1
+	}
+
+	// An empty password hash removes the password.
+	switch {
+	case src.Password.Hash == nil:
+	case len(src.Password.Hash) == 0:
+		dst.Password = entity.Password{}
+	default:
+		dst.Password = src.Password
+	}
+
+	if !src.Expiration.IsZero() {
+		dst.ExpiresAt = src.ExpiresAt
+	}
+
+	if src.File != nil {
+		dst.File = src.File
+	}
+
+	if src.Visibility != "" {
+		dst.Visibility = src.Visibility
+	}
+
+	if src.Tags != nil {
+		dst.Tags = src.Tags
+	}
+}
+
+// listPage lists one item more than limit, the extra item tells whether there is
+// a next page. Returns at most limit items and whether there is a next page after
+// the last of them, a zero limit never has one.
+func listPage[T any](limit int, list func(limit int) ([]T, error)) ([]T, bool, error) {
+	items, err := list(limit + 1)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(items) <= limit {
+		return items, false, nil
+	}
+
+	return items[:limit], limit > 0, nil
+}
+
+// normalizeTags returns unique tags in lower case ordered by name, with inner spaces
+// replaced by hyphens. Tags may contain letters, digits and "-_.+#" characters,
+// otherwise or if there are more than maxTags tags returns ErrInvalidTags.
+// The result is never nil, so empty tags of an update remove all tags.
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(tags))
+	normalized := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.Join(strings.Fields(tag), "-"))
+
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLen || strings.IndexFunc(tag, invalidTagRune) >= 0 {
+			return nil, ErrInvalidTags
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > maxTags {
+		return nil, ErrInvalidTags
+	}
+
+	sort.Strings(normalized)
+
+	return normalized, nil
+}
+
+// lineCount returns the number of lines of the text, a final new line does not start a line.
+func lineCount(text entity.File) int {
+	n := bytes.Count(text, []byte("\n"))
+	if len(text) > 0 && text[len(text)-1] != '\n' {
+		n++
+	}
+
+	return n
+}
+
+// normalizeLines returns inclusive line ranges sorted by start, with overlapping and
+// adjacent ranges merged and ranges cut to the count of lines.
+func normalizeLines(ranges [][2]int, count int) [][2]int {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sorted := make([][2]int, 0, len(ranges))
+
+	for _, r := range ranges {
+		if r[0] <= count {
+			sorted = append(sorted, [2]int{r[0], min(r[1], count)})
+		}
+	}
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+
+	merged := sorted[:0]
+
+	for _, r := range sorted {
+		if n := len(merged); n > 0 && r[0] <= merged[n-1][1]+1 {
+			merged[n-1][1] = max(merged[n-1][1], r[1])
+
+			continue
+		}
+
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+func invalidTagRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("-_.+#", r)
+}
@@ -2,26 +2,84 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/romankravchuk/pastebin/internal/entity"
 	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 var errTest = errors.New("test error")
 
-func newPastesUseCase(t *testing.T) (*PastesUseCase, *mocks.PastesRepo, *mocks.PastesBlobStorage, *mocks.PastesCache) {
+var testPolicy = ExpirationPolicy{
+	Min:        time.Minute,
+	Max:        30 * 24 * time.Hour,
+	Default:    24 * time.Hour,
+	AllowNever: true,
+}
+
+type pastesMocks struct {
+	repo    *mocks.PastesRepo
+	blob    *mocks.PastesBlobStorage
+	cache   *mocks.PastesCache
+	revs    *mocks.PasteRevisionsRepo
+	files   *mocks.PasteFilesRepo
+	views   *mocks.PasteViewsCounter
+	lock    *mocks.Locker
+	renders *mocks.PastesRendersCache
+	hl      *mocks.PastesHighlighter
+	formats *mocks.FormatDetector
+	valid   *mocks.FormatValidator
+	conv    *mocks.FormatConverter
+	query   *mocks.FormatQuerier
+	schemas *mocks.SchemaValidator
+	search  *mocks.PasteSearchRepo
+	grants  *mocks.PasteGrantsRepo
+	feeds   *mocks.PastesFeedsCache
+	tags    *mocks.PasteTagsRepo
+	starred *mocks.PasteStarsCounter
+}
+
+func newPastesUseCase(t *testing.T) (*PastesUseCase, *pastesMocks) {
 	t.Helper()
 
-	var (
-		repo  = mocks.NewPastesRepo(t)
-		cache = mocks.NewPastesCache(t)
-		blob  = mocks.NewPastesBlobStorage(t)
-	)
+	m := &pastesMocks{
+		repo:    mocks.NewPastesRepo(t),
+		cache:   mocks.NewPastesCache(t),
+		blob:    mocks.NewPastesBlobStorage(t),
+		revs:    mocks.NewPasteRevisionsRepo(t),
+		files:   mocks.NewPasteFilesRepo(t),
+		views:   mocks.NewPasteViewsCounter(t),
+		lock:    mocks.NewLocker(t),
+		renders: mocks.NewPastesRendersCache(t),
+		hl:      mocks.NewPastesHighlighter(t),
+		formats: mocks.NewFormatDetector(t),
+		valid:   mocks.NewFormatValidator(t),
+		conv:    mocks.NewFormatConverter(t),
+		query:   mocks.NewFormatQuerier(t),
+		schemas: mocks.NewSchemaValidator(t),
+		search:  mocks.NewPasteSearchRepo(t),
+		grants:  mocks.NewPasteGrantsRepo(t),
+		feeds:   mocks.NewPastesFeedsCache(t),
+		tags:    mocks.NewPasteTagsRepo(t),
+		starred: mocks.NewPasteStarsCounter(t),
+	}
+
+	return NewPastes(m.repo, m.blob, m.cache, m.revs, m.files, m.views, m.lock, m.renders, m.hl, m.formats, m.valid, m.conv, m.query, m.schemas, m.search, NewPasteAccess(m.repo, m.grants), m.grants, m.feeds, m.tags, m.starred, testPolicy), m
+}
 
-	return NewPastes(repo, blob, cache), repo, blob, cache
+// lockedUpdate returns the repo Update mock implementation, which passes stored
+// to the update function as the locked paste.
+func lockedUpdate(stored *entity.Paste) func(context.Context, string, func(*entity.Paste) error) error {
+	return func(_ context.Context, _ string, update func(*entity.Paste) error) error {
+		return update(stored)
+	}
 }
 
 func TestPastesUseCase_Create(t *testing.T) {
@@ -31,18 +89,25 @@ func TestPastesUseCase_Create(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, repo, blob, _ = newPastesUseCase(t)
-			ctx               = context.Background()
-			paste             = &entity.Paste{
-				Hash: "test",
-				File: []byte("test"),
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{
+				Hash:   "test",
+				Format: "plaintext",
+				File:   []byte("test"),
 			}
 		)
 
-		blob.On("Create", ctx, paste).
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
 			Once().
 			Return(nil)
-		repo.On("Create", ctx, paste).
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, "", "test").
 			Once().
 			Return(nil)
 
@@ -57,15 +122,19 @@ func TestPastesUseCase_Create(t *testing.T) {
 			t.Parallel()
 
 			var (
-				uc, _, blob, _ = newPastesUseCase(t)
-				ctx            = context.Background()
-				paste          = &entity.Paste{
-					Hash: "test",
-					File: []byte("test"),
+				uc, m = newPastesUseCase(t)
+				ctx   = context.Background()
+				paste = &entity.Paste{
+					Hash:   "test",
+					Format: "plaintext",
+					File:   []byte("test"),
 				}
 			)
 
-			blob.On("Create", ctx, paste).
+			m.valid.On("Validate", paste.Format, paste.File).
+				Once().
+				Return(nil)
+			m.blob.On("Create", ctx, paste).
 				Once().
 				Return(errTest)
 
@@ -77,18 +146,22 @@ func TestPastesUseCase_Create(t *testing.T) {
 			t.Parallel()
 
 			var (
-				uc, repo, blob, _ = newPastesUseCase(t)
-				ctx               = context.Background()
-				paste             = &entity.Paste{
-					Hash: "test",
-					File: []byte("test"),
+				uc, m = newPastesUseCase(t)
+				ctx   = context.Background()
+				paste = &entity.Paste{
+					Hash:   "test",
+					Format: "plaintext",
+					File:   []byte("test"),
 				}
 			)
 
-			blob.On("Create", ctx, paste).
+			m.valid.On("Validate", paste.Format, paste.File).
 				Once().
 				Return(nil)
-			repo.On("Create", ctx, paste).
+			m.blob.On("Create", ctx, paste).
+				Once().
+				Return(nil)
+			m.repo.On("Create", ctx, paste).
 				Once().
 				Return(errTest)
 
@@ -105,18 +178,59 @@ func TestPastesUseCase_Delete(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, repo, _, _ = newPastesUseCase(t)
-			ctx            = context.Background()
-			id             = "test"
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{
+				Hash:   "test",
+				UserID: sql.NullString{String: "user", Valid: true},
+			}
 		)
 
-		repo.On("Delete", ctx, id).
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.blob.On("Delete", ctx, paste.UserID.String, paste.Hash).
+			Once().
+			Return(nil)
+		m.repo.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.views.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.starred.On("Delete", ctx, paste.Hash).
 			Once().
 			Return(nil)
 
-		err := uc.Delete(ctx, id)
+		err := uc.Delete(ctx, paste.Hash)
 		require.NoError(t, err)
 	})
+
+	t.Run("Get error on not author", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "other")
+			paste = &entity.Paste{
+				Hash:   "test",
+				UserID: sql.NullString{String: "user", Valid: true},
+			}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		err := uc.Delete(ctx, paste.Hash)
+		require.ErrorIs(t, err, ErrNotPasteAuthor)
+	})
 }
 
 func TestPastesUseCase_Get(t *testing.T) {
@@ -126,17 +240,26 @@ func TestPastesUseCase_Get(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, _, _, cache = newPastesUseCase(t)
-			ctx             = context.Background()
-			expPaste        = &entity.Paste{
+			uc, m    = newPastesUseCase(t)
+			ctx      = context.Background()
+			expPaste = &entity.Paste{
 				Hash: "test",
 				File: []byte("test"),
 			}
 		)
 
-		cache.On("Get", ctx, expPaste.Hash).
+		m.cache.On("Get", ctx, expPaste.Hash).
 			Once().
 			Return(expPaste, true, nil)
+		m.blob.On("Get", ctx, "", expPaste.Hash).
+			Once().
+			Return(expPaste.File, nil)
+		m.views.On("Incr", ctx, expPaste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, expPaste.Hash).
+			Once().
+			Return(0, false, nil)
 
 		paste, err := uc.Get(ctx, expPaste.Hash)
 		require.NoError(t, err)
@@ -147,20 +270,29 @@ func TestPastesUseCase_Get(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, repo, _, cache = newPastesUseCase(t)
-			ctx                = context.Background()
-			expPaste           = &entity.Paste{
+			uc, m    = newPastesUseCase(t)
+			ctx      = context.Background()
+			expPaste = &entity.Paste{
 				Hash: "test",
 				File: []byte("test"),
 			}
 		)
 
-		cache.On("Get", ctx, expPaste.Hash).
+		m.cache.On("Get", ctx, expPaste.Hash).
 			Once().
 			Return(nil, false, nil)
-		repo.On("Get", ctx, expPaste.Hash).
+		m.repo.On("Get", ctx, expPaste.Hash).
 			Once().
 			Return(expPaste, nil)
+		m.blob.On("Get", ctx, "", expPaste.Hash).
+			Once().
+			Return(expPaste.File, nil)
+		m.views.On("Incr", ctx, expPaste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, expPaste.Hash).
+			Once().
+			Return(0, false, nil)
 
 		paste, err := uc.Get(ctx, expPaste.Hash)
 		require.NoError(t, err)
@@ -171,12 +303,12 @@ func TestPastesUseCase_Get(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, _, _, cache = newPastesUseCase(t)
-			ctx             = context.Background()
-			id              = "test"
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			id    = "test"
 		)
 
-		cache.On("Get", ctx, id).
+		m.cache.On("Get", ctx, id).
 			Once().
 			Return(nil, false, entity.ErrPasteNotFound)
 
@@ -189,15 +321,15 @@ func TestPastesUseCase_Get(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, repo, _, cache = newPastesUseCase(t)
-			ctx                = context.Background()
-			id                 = "test"
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			id    = "test"
 		)
 
-		cache.On("Get", ctx, id).
+		m.cache.On("Get", ctx, id).
 			Once().
 			Return(nil, false, nil)
-		repo.On("Get", ctx, id).
+		m.repo.On("Get", ctx, id).
 			Once().
 			Return(nil, errTest)
 
@@ -214,39 +346,2648 @@ func TestPastesUseCase_Update(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, repo, _, _ = newPastesUseCase(t)
-			ctx            = context.Background()
-			paste          = &entity.Paste{
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:     "test",
+				Title:    "old",
+				Format:   "plaintext",
+				Revision: 1,
+				UserID:   sql.NullString{String: "user", Valid: true},
+			}
+			paste = &entity.Paste{
+				Hash:  "test",
+				Title: "new",
+				File:  []byte("test"),
+			}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("old"), nil)
+		m.valid.On("Validate", "plaintext", paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("CreateRevision", ctx, stored).
+			Once().
+			Return(nil)
+		m.blob.On("Update", ctx, stored).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, stored.Hash, "new", "test").
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Update(ctx, paste)
+		require.NoError(t, err)
+		require.Equal(t, "new", paste.Title)
+		require.Equal(t, "plaintext", paste.Format)
+		require.Equal(t, 2, paste.Revision)
+		require.True(t, paste.Valid)
+	})
+
+	t.Run("Update paste created by the author", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newPastesUseCase(t)
+			ctx     = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			created = &entity.Paste{Hash: "test", Format: "plaintext", File: entity.File("old")}
+			paste   = &entity.Paste{Hash: "test", Title: "new"}
+		)
+
+		m.valid.On("Validate", "plaintext", created.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, created).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, created).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, created.Hash, "", "old").
+			Once().
+			Return(nil)
+
+		require.NoError(t, uc.Create(ctx, created))
+
+		stored := *created
+		stored.File = nil
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(&stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("old"), nil)
+		m.blob.On("CreateRevision", ctx, &stored).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, stored.Hash, "new", "old").
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Update(ctx, paste)
+		require.NoError(t, err)
+		require.Equal(t, "new", paste.Title)
+		require.Equal(t, sql.NullString{String: "user", Valid: true}, paste.UserID)
+	})
+
+	t.Run("Remove paste password", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:   "test",
+				Format: "plaintext",
+				UserID: sql.NullString{String: "user", Valid: true},
+			}
+			paste = &entity.Paste{Hash: "test", Password: entity.Password{Hash: []byte{}}}
+		)
+
+		stored.Password.Set("secret")
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("text"), nil)
+		m.blob.On("CreateRevision", ctx, stored).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, stored.Hash, "", "text").
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Update(ctx, paste)
+		require.NoError(t, err)
+		require.Nil(t, paste.Password.Hash)
+	})
+
+	t.Run("Get error on not author", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "other")
+			paste = &entity.Paste{
 				Hash: "test",
 				File: []byte("test"),
 			}
 		)
 
-		repo.On("Update", ctx, paste).
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(&entity.Paste{Hash: "test"}))
+
+		err := uc.Update(ctx, paste)
+		require.ErrorIs(t, err, ErrNotPasteAuthor)
+	})
+
+	t.Run("Update paste file", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:     "test",
+				Format:   "dockerfile",
+				Revision: 1,
+				Valid:    true,
+				UserID:   sql.NullString{String: "user", Valid: true},
+				Files: []*entity.PasteFile{
+					{Hash: "test", Position: 0, Name: "Dockerfile", Format: "dockerfile"},
+					{Hash: "test", Position: 1, Name: "compose.yaml", Format: "yaml"},
+				},
+			}
+			paste = &entity.Paste{
+				Hash:  "test",
+				Files: []*entity.PasteFile{{Hash: "test", Name: "compose.yaml", File: entity.File("services: {a: 1}")}},
+			}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("FROM scratch"), nil)
+		m.blob.On("GetFile", ctx, "user", stored.Hash, "compose.yaml").
+			Once().
+			Return(entity.File("services: {}"), nil)
+		m.valid.On("Validate", "dockerfile", entity.File("FROM scratch")).
+			Once().
+			Return(nil)
+		m.valid.On("Validate", "yaml", entity.File("services: {a: 1}")).
+			Once().
+			Return(nil)
+		m.blob.On("CreateRevision", ctx, mock.MatchedBy(func(p *entity.Paste) bool {
+			return p.Revision == 1 && string(p.Files[1].File) == "services: {}"
+		})).
+			Once().
+			Return(nil)
+		m.blob.On("CreateFile", ctx, stored, &entity.PasteFile{
+			Hash: "test",
+			Name: "compose.yaml",
+			File: entity.File("services: {a: 1}"),
+		}).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, stored.Hash, "", "FROM scratch\nservices: {a: 1}").
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, stored.Hash).
 			Once().
 			Return(nil)
 
 		err := uc.Update(ctx, paste)
 		require.NoError(t, err)
+		require.Equal(t, 2, paste.Revision)
+		require.True(t, paste.Valid)
+		require.Equal(t, entity.File("services: {a: 1}"), paste.Files[1].File)
+	})
+
+	t.Run("Get error on unknown paste file", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:   "test",
+				UserID: sql.NullString{String: "user", Valid: true},
+			}
+			paste = &entity.Paste{
+				Hash:  "test",
+				Files: []*entity.PasteFile{{Hash: "test", Name: "compose.yaml", File: entity.File("services: {}")}},
+			}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("text"), nil)
+
+		err := uc.Update(ctx, paste)
+		require.ErrorIs(t, err, ErrFileNotFound)
+	})
+
+	t.Run("Get error on anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, _ = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test"}
+		)
+
+		err := uc.Update(ctx, paste)
+		require.ErrorIs(t, err, ErrNotPasteAuthor)
 	})
 
 	t.Run("Get error on update", func(t *testing.T) {
 		t.Parallel()
 
 		var (
-			uc, repo, _, _ = newPastesUseCase(t)
-			ctx            = context.Background()
-			paste          = &entity.Paste{
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{
 				Hash: "test",
 				File: []byte("test"),
 			}
 		)
 
-		repo.On("Update", ctx, paste).
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
 			Once().
 			Return(errTest)
 
 		err := uc.Update(ctx, paste)
-		require.Error(t, err)
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("Get error on text update", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:   "test",
+				UserID: sql.NullString{String: "user", Valid: true},
+			}
+			paste = &entity.Paste{
+				Hash: "test",
+				File: []byte("test"),
+			}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("old"), nil)
+		m.valid.On("Validate", "", paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("CreateRevision", ctx, stored).
+			Once().
+			Return(nil)
+		m.blob.On("Update", ctx, stored).
+			Once().
+			Return(errTest)
+
+		err := uc.Update(ctx, paste)
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestPastesUseCase_GetRevision(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get previous revision", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Revision: 2}
+			rev   = &entity.PasteRevision{Hash: "test", Revision: 1}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.revs.On("Get", ctx, paste.Hash, 1).
+			Once().
+			Return(rev, nil)
+		m.blob.On("GetRevision", ctx, "", paste.Hash, 1).
+			Once().
+			Return(entity.File("old"), nil)
+
+		got, err := uc.GetRevision(ctx, paste.Hash, "", 1)
+		require.NoError(t, err)
+		require.Equal(t, entity.File("old"), got.File)
+	})
+
+	t.Run("Get previous revision with files", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{
+				Hash:     "test",
+				Format:   "dockerfile",
+				Revision: 2,
+				Files: []*entity.PasteFile{
+					{Hash: "test", Position: 0, Name: "Dockerfile", Format: "dockerfile"},
+					{Hash: "test", Position: 1, Name: "compose.yaml", Format: "yaml"},
+				},
+			}
+			rev = &entity.PasteRevision{Hash: "test", Revision: 1, Format: "dockerfile", HasFiles: true}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.revs.On("Get", ctx, paste.Hash, 1).
+			Once().
+			Return(rev, nil)
+		m.blob.On("GetRevision", ctx, "", paste.Hash, 1).
+			Once().
+			Return(entity.File("FROM scratch"), nil)
+		m.blob.On("GetRevisionFile", ctx, "", paste.Hash, 1, "compose.yaml").
+			Once().
+			Return(entity.File("services: {}"), nil)
+
+		got, err := uc.GetRevision(ctx, paste.Hash, "", 1)
+		require.NoError(t, err)
+		require.Len(t, got.Files, 2)
+		require.Equal(t, entity.File("FROM scratch"), got.Files[0].File)
+		require.Equal(t, entity.File("services: {}"), got.Files[1].File)
+	})
+
+	t.Run("Get previous revision stored without files", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{
+				Hash:     "test",
+				Revision: 2,
+				Files: []*entity.PasteFile{
+					{Hash: "test", Position: 0, Name: "Dockerfile", Format: "dockerfile"},
+					{Hash: "test", Position: 1, Name: "compose.yaml", Format: "yaml"},
+				},
+			}
+			rev = &entity.PasteRevision{Hash: "test", Revision: 1}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.revs.On("Get", ctx, paste.Hash, 1).
+			Once().
+			Return(rev, nil)
+		m.blob.On("GetRevision", ctx, "", paste.Hash, 1).
+			Once().
+			Return(entity.File("FROM scratch"), nil)
+
+		got, err := uc.GetRevision(ctx, paste.Hash, "", 1)
+		require.NoError(t, err)
+		require.Equal(t, entity.File("FROM scratch"), got.File)
+		require.Empty(t, got.Files)
+	})
+
+	t.Run("Get current revision", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Revision: 2}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("new"), nil)
+
+		got, err := uc.GetRevision(ctx, paste.Hash, "", 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, got.Revision)
+		require.Equal(t, entity.File("new"), got.File)
+	})
+
+	t.Run("Get error on unknown revision", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Revision: 2}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.revs.On("Get", ctx, paste.Hash, 5).
+			Once().
+			Return(nil, ErrRecordNotFound)
+
+		_, err := uc.GetRevision(ctx, paste.Hash, "", 5)
+		require.ErrorIs(t, err, ErrRevisionNotFound)
+	})
+
+	t.Run("Get error on locked paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Revision: 2}
+		)
+
+		paste.Password.Set("secret")
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		_, err := uc.GetRevision(ctx, paste.Hash, "", 1)
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+}
+
+func TestPastesUseCase_Fork(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Fork paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			source = &entity.Paste{
+				Hash:   "source",
+				Title:  "template",
+				Format: "yaml",
+				UserID: sql.NullString{String: "owner", Valid: true},
+			}
+			fork = &entity.Paste{
+				Hash:       "fork",
+				ForkedFrom: sql.NullString{String: "source", Valid: true},
+			}
+		)
+
+		m.repo.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, nil)
+		m.blob.On("Get", ctx, "owner", source.Hash).
+			Once().
+			Return(entity.File("key: value"), nil)
+		m.valid.On("Validate", "yaml", entity.File("key: value")).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, fork).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, fork).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, fork.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, source.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Fork(ctx, fork, "")
+		require.NoError(t, err)
+		require.Equal(t, sql.NullString{String: "user", Valid: true}, fork.UserID)
+		require.Equal(t, "template", fork.Title)
+		require.Equal(t, "yaml", fork.Format)
+		require.Equal(t, entity.File("key: value"), fork.File)
+	})
+
+	t.Run("Get error on anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, _ = newPastesUseCase(t)
+			ctx   = context.Background()
+			fork  = &entity.Paste{
+				Hash:       "fork",
+				ForkedFrom: sql.NullString{String: "source", Valid: true},
+			}
+		)
+
+		err := uc.Fork(ctx, fork, "")
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Fork protected paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			source = &entity.Paste{
+				Hash:   "source",
+				Format: "plaintext",
+				UserID: sql.NullString{String: "owner", Valid: true},
+			}
+			fork = &entity.Paste{
+				Hash:       "fork",
+				ForkedFrom: sql.NullString{String: "source", Valid: true},
+			}
+		)
+
+		source.Password.Set("secret")
+
+		m.repo.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, nil)
+		m.blob.On("Get", ctx, "owner", source.Hash).
+			Once().
+			Return(entity.File("secret text"), nil)
+		m.valid.On("Validate", "plaintext", entity.File("secret text")).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, fork).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, fork).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, source.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Fork(ctx, fork, "secret")
+		require.NoError(t, err)
+		require.True(t, fork.Password.Matches("secret"))
+	})
+
+	t.Run("Fork own views limited paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			source = &entity.Paste{
+				Hash:     "source",
+				Format:   "plaintext",
+				MaxViews: 3,
+				UserID:   sql.NullString{String: "user", Valid: true},
+			}
+			fork = &entity.Paste{
+				Hash:       "fork",
+				ForkedFrom: sql.NullString{String: "source", Valid: true},
+			}
+		)
+
+		m.repo.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, nil)
+		m.blob.On("Get", ctx, "user", source.Hash).
+			Once().
+			Return(entity.File("text"), nil)
+		m.valid.On("Validate", "plaintext", entity.File("text")).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, fork).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, fork).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, fork.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, source.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Fork(ctx, fork, "")
+		require.NoError(t, err)
+		require.Zero(t, fork.MaxViews)
+	})
+
+	t.Run("Get error on views limited paste of other user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			source = &entity.Paste{Hash: "source", MaxViews: 3}
+			fork   = &entity.Paste{
+				Hash:       "fork",
+				ForkedFrom: sql.NullString{String: "source", Valid: true},
+			}
+		)
+
+		m.repo.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, nil)
+
+		err := uc.Fork(ctx, fork, "")
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+}
+
+func TestPastesUseCase_BurnAfterRead(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Burn on first read", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", BurnAfterRead: true}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(nil, false, nil)
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.repo.On("Burn", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.blob.On("Delete", ctx, "", paste.Hash).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.views.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.starred.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.feeds.On("Delete", ctx, "").
+			Once().
+			Return(nil)
+
+		got, err := uc.Get(ctx, paste.Hash)
+		require.NoError(t, err)
+		require.Equal(t, entity.File("secret"), got.File)
+	})
+
+	t.Run("Get error on lost claim", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", BurnAfterRead: true}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.repo.On("Burn", ctx, paste.Hash).
+			Once().
+			Return(ErrRecordNotFound)
+
+		got, err := uc.Get(ctx, paste.Hash)
+		require.ErrorIs(t, err, ErrPasteNotFound)
+		require.Nil(t, got)
+	})
+
+	t.Run("Do not burn locked paste on get", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", BurnAfterRead: true}
+		)
+
+		paste.Password.Set("secret")
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+
+		_, err := uc.Get(ctx, paste.Hash)
+		require.NoError(t, err)
+	})
+
+	t.Run("Do not burn on wrong password", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", BurnAfterRead: true}
+		)
+
+		paste.Password.Set("secret")
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+
+		_, err := uc.Unlock(ctx, paste.Hash, "wrong")
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+
+	t.Run("Burn on unlock", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", BurnAfterRead: true}
+		)
+
+		paste.Password.Set("secret")
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.repo.On("Burn", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.blob.On("Delete", ctx, "", paste.Hash).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.views.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.starred.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+		m.feeds.On("Delete", ctx, "").
+			Once().
+			Return(nil)
+
+		_, err := uc.Unlock(ctx, paste.Hash, "secret")
+		require.NoError(t, err)
+	})
+}
+
+func TestPastesUseCase_Views(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Count view", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Views: 2, MaxViews: 5}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("test"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 2, 5).
+			Once().
+			Return(3, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+
+		got, err := uc.Get(ctx, paste.Hash)
+		require.NoError(t, err)
+		require.Equal(t, 3, got.Views)
+	})
+
+	t.Run("Get error on views limit", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Views: 5, MaxViews: 5}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("test"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 5, 5).
+			Once().
+			Return(-1, nil)
+
+		got, err := uc.Get(ctx, paste.Hash)
+		require.ErrorIs(t, err, ErrPasteGone)
+		require.Nil(t, got)
+	})
+
+	t.Run("Flush views", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			views = map[string]int{"a": 1, "b": 7}
+		)
+
+		m.views.On("Pending", ctx, viewsFlushBatch).
+			Once().
+			Return(views, nil)
+		m.repo.On("SetViews", ctx, views).
+			Once().
+			Return(nil)
+		m.views.On("Flushed", ctx, views).
+			Once().
+			Return(nil)
+		m.views.On("Pending", ctx, viewsFlushBatch).
+			Once().
+			Return(map[string]int{}, nil)
+
+		err := uc.FlushViews(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Get error on flush views", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			views = map[string]int{"a": 1}
+		)
+
+		m.views.On("Pending", ctx, viewsFlushBatch).
+			Once().
+			Return(views, nil)
+		m.repo.On("SetViews", ctx, views).
+			Once().
+			Return(errTest)
+
+		err := uc.FlushViews(ctx)
+		require.ErrorIs(t, err, errTest)
+		m.views.AssertNotCalled(t, "Flushed", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPastesUseCase_Expiration(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Get error on expired paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", ExpiresAt: time.Now().Add(-time.Minute)}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+
+		got, err := uc.Get(ctx, paste.Hash)
+		require.ErrorIs(t, err, ErrPasteExpired)
+		require.Nil(t, got)
+	})
+
+	t.Run("Create paste with default expiration", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: []byte("test")}
+		)
+
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(testPolicy.Default), paste.ExpiresAt, time.Second)
+	})
+
+	t.Run("Create paste with absolute expiration", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m     = newPastesUseCase(t)
+			ctx       = context.Background()
+			expiresAt = time.Now().Add(72 * time.Hour).Truncate(time.Second)
+			paste     = &entity.Paste{Hash: "test", Format: "plaintext", Expiration: entity.Expiration{At: expiresAt}}
+		)
+
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.Equal(t, expiresAt, paste.ExpiresAt)
+	})
+
+	t.Run("Create never expiring paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", Expiration: entity.Expiration{Never: true}}
+		)
+
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.True(t, paste.ExpiresAt.IsZero())
+	})
+
+	t.Run("Create error on never expiring anonymous paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, _ = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Expiration: entity.Expiration{Never: true}}
+		)
+
+		err := uc.Create(ctx, paste)
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Create error on expiration out of range", func(t *testing.T) {
+		t.Parallel()
+
+		for _, d := range []time.Duration{time.Second, 365 * 24 * time.Hour, -time.Hour} {
+			var (
+				uc, _ = newPastesUseCase(t)
+				ctx   = context.Background()
+				paste = &entity.Paste{Hash: "test", Expiration: entity.Expiration{Duration: d}}
+			)
+
+			err := uc.Create(ctx, paste)
+			require.ErrorIs(t, err, ErrInvalidExpiration, d)
+		}
+	})
+
+	t.Run("Extend paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{
+				Hash:      "test",
+				UserID:    sql.NullString{String: "user", Valid: true},
+				ExpiresAt: time.Now().Add(time.Hour),
+			}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("SetExpiration", ctx, paste.Hash, mock.AnythingOfType("time.Time")).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, paste.Hash).
+			Once().
+			Return(nil)
+
+		got, err := uc.Extend(ctx, paste.Hash, entity.Expiration{Duration: 7 * 24 * time.Hour})
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(7*24*time.Hour), got.ExpiresAt, time.Second)
+	})
+
+	t.Run("Extend error on not author", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "other")
+			paste = &entity.Paste{Hash: "test", UserID: sql.NullString{String: "user", Valid: true}}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		_, err := uc.Extend(ctx, paste.Hash, entity.Expiration{})
+		require.ErrorIs(t, err, ErrNotPasteAuthor)
+	})
+
+	t.Run("Extend error on expired paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{
+				Hash:      "test",
+				UserID:    sql.NullString{String: "user", Valid: true},
+				ExpiresAt: time.Now().Add(-time.Hour),
+			}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		_, err := uc.Extend(ctx, paste.Hash, entity.Expiration{})
+		require.ErrorIs(t, err, ErrPasteExpired)
+	})
+
+	t.Run("Delete expired pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			pastes = []*entity.Paste{
+				{Hash: "a"},
+				{Hash: "b", UserID: sql.NullString{String: "user", Valid: true}},
+			}
+		)
+
+		m.lock.On("Acquire", ctx, expiredLock, expiredLockTTL).
+			Once().
+			Return(true, nil)
+		m.repo.On("DeleteExpired", ctx, expiredBatch).
+			Once().
+			Return(pastes, nil)
+		m.blob.On("Delete", ctx, "", "a").
+			Once().
+			Return(nil)
+		m.blob.On("Delete", ctx, "user", "b").
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, mock.Anything).
+			Twice().
+			Return(nil)
+		m.views.On("Delete", ctx, "a").
+			Once().
+			Return(nil)
+		m.views.On("Delete", ctx, "b").
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, "a").
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, "b").
+			Once().
+			Return(nil)
+		m.starred.On("Delete", ctx, "a").
+			Once().
+			Return(nil)
+		m.starred.On("Delete", ctx, "b").
+			Once().
+			Return(nil)
+		m.feeds.On("Delete", ctx, "").
+			Once().
+			Return(nil)
+		m.feeds.On("Delete", ctx, "user").
+			Once().
+			Return(nil)
+		m.lock.On("Release", mock.Anything, expiredLock).
+			Once().
+			Return(nil)
+
+		err := uc.DeleteExpired(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Skip deleting expired pastes if locked", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.lock.On("Acquire", ctx, expiredLock, expiredLockTTL).
+			Once().
+			Return(false, nil)
+
+		err := uc.DeleteExpired(ctx)
+		require.NoError(t, err)
+	})
+}
+
+func TestPastesUseCase_Files(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create multi-file paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			files = []*entity.PasteFile{
+				{Hash: "test", Position: 0, Name: "Dockerfile", Format: "plaintext", File: entity.File("FROM scratch")},
+				{Hash: "test", Position: 1, Name: "compose.yaml", Format: "yaml", File: entity.File("services: {}")},
+			}
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: files[0].File, Files: files}
+		)
+
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.valid.On("Validate", mock.Anything, mock.Anything).
+			Twice().
+			Return(nil)
+		m.blob.On("CreateFile", ctx, paste, files[1]).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.files.On("Create", ctx, files).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, "", "FROM scratch\nservices: {}").
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+	})
+
+	t.Run("Get multi-file paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{
+				Hash:   "test",
+				Format: "plaintext",
+				Files: []*entity.PasteFile{
+					{Hash: "test", Position: 0, Name: "Dockerfile", Format: "json"},
+					{Hash: "test", Position: 1, Name: "compose.yaml", Format: "yaml"},
+				},
+			}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("FROM scratch"), nil)
+		m.blob.On("GetFile", ctx, "", paste.Hash, "compose.yaml").
+			Once().
+			Return(entity.File("services: {}"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+
+		got, err := uc.Get(ctx, paste.Hash)
+		require.NoError(t, err)
+		require.Len(t, got.Files, 2)
+		require.Equal(t, "plaintext", got.Files[0].Format)
+		require.Equal(t, entity.File("FROM scratch"), got.Files[0].File)
+		require.Equal(t, entity.File("services: {}"), got.Files[1].File)
+	})
+}
+
+func TestPastesUseCase_Highlight(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Render and cache paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json"}
+			text  = entity.File("{\n  \"a\": 1,\n  \"b\": 2\n}\n")
+			opts  = entity.HighlightOptions{Theme: "monokai", Lines: [][2]int{{3, 9}, {7, 8}, {2, 2}}}
+			html  = []byte("<html></html>")
+		)
+
+		m.hl.On("HasTheme", opts.Theme).
+			Once().
+			Return(true)
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(text, nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.renders.On("Get", ctx, paste.Hash, ":monokai:2-4").
+			Once().
+			Return(nil, false, nil)
+		m.hl.On("Highlight", paste, entity.HighlightOptions{Theme: "monokai", Lines: [][2]int{{2, 4}}}).
+			Once().
+			Return(html, nil)
+		m.renders.On("Set", ctx, paste.Hash, ":monokai:2-4", html).
+			Once().
+			Return(nil)
+
+		got, err := uc.Highlight(ctx, paste.Hash, "", opts)
+		require.NoError(t, err)
+		require.Equal(t, html, got)
+	})
+
+	t.Run("Get cached render", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json"}
+			html  = []byte("<html></html>")
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("{}"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.renders.On("Get", ctx, paste.Hash, ":").
+			Once().
+			Return(html, true, nil)
+
+		got, err := uc.Highlight(ctx, paste.Hash, "", entity.HighlightOptions{})
+		require.NoError(t, err)
+		require.Equal(t, html, got)
+	})
+
+	t.Run("Get error on unknown theme", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.hl.On("HasTheme", "unknown").
+			Once().
+			Return(false)
+
+		_, err := uc.Highlight(ctx, "test", "", entity.HighlightOptions{Theme: "unknown"})
+		require.ErrorIs(t, err, ErrUnknownTheme)
+	})
+
+	t.Run("Get error on locked paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test"}
+		)
+
+		paste.Password.Set("secret")
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+
+		_, err := uc.Highlight(ctx, paste.Hash, "", entity.HighlightOptions{})
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+}
+
+func TestPastesUseCase_DetectFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create paste with detected format", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", File: entity.File(`{"key": "value"}`)}
+		)
+
+		m.formats.On("Detect", paste.File).
+			Once().
+			Return(entity.FormatDetection{Format: "json", Confidence: 1})
+		m.valid.On("Validate", "json", paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.Equal(t, "json", paste.Format)
+		require.Equal(t, 1.0, paste.FormatConfidence)
+	})
+
+	t.Run("Create paste with author format", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "yaml", File: entity.File("key: value")}
+		)
+
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.Equal(t, "yaml", paste.Format)
+		require.Equal(t, 1.0, paste.FormatConfidence)
+		m.formats.AssertNotCalled(t, "Detect", mock.Anything)
+	})
+
+	t.Run("Create multi-file paste with detected formats", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			files = []*entity.PasteFile{
+				{Hash: "test", Position: 0, Name: "main.go", File: entity.File("package main")},
+				{Hash: "test", Position: 1, Name: "go.mod", Format: "plaintext", File: entity.File("module test")},
+				{Hash: "test", Position: 2, Name: "config.toml", File: entity.File("key = 1")},
+			}
+			paste = &entity.Paste{Hash: "test", File: files[0].File, Files: files}
+		)
+
+		m.formats.On("Detect", files[0].File).
+			Once().
+			Return(entity.FormatDetection{Format: "go", Confidence: 0.5})
+		m.formats.On("Detect", files[2].File).
+			Once().
+			Return(entity.FormatDetection{Format: "toml", Confidence: 0.9})
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.valid.On("Validate", mock.Anything, mock.Anything).
+			Times(3).
+			Return(nil)
+		m.blob.On("CreateFile", ctx, paste, mock.Anything).
+			Twice().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+		m.files.On("Create", ctx, files).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.Equal(t, "go", paste.Format)
+		require.Equal(t, 0.5, paste.FormatConfidence)
+		require.Equal(t, []string{"go", "plaintext", "toml"}, []string{files[0].Format, files[1].Format, files[2].Format})
+	})
+}
+
+func TestPastesUseCase_ValidateFormat(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create error on invalid text", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json", File: entity.File(`{"key":}`)}
+		)
+
+		m.valid.On("Validate", "json", paste.File).
+			Once().
+			Return(&entity.FormatError{Format: "json", Line: 1, Column: 8, Msg: "invalid character '}'"})
+
+		err := uc.Create(ctx, paste)
+
+		var fe *entity.FormatError
+		require.ErrorAs(t, err, &fe)
+		require.Equal(t, 1, fe.Line)
+		require.Equal(t, 8, fe.Column)
+	})
+
+	t.Run("Create invalid paste if allowed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json", File: entity.File(`{"key":}`), AllowInvalid: true}
+		)
+
+		m.valid.On("Validate", "json", paste.File).
+			Once().
+			Return(&entity.FormatError{Format: "json", Line: 1, Column: 8, Msg: "invalid character '}'"})
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.False(t, paste.Valid)
+	})
+
+	t.Run("Create error on invalid file", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			files = []*entity.PasteFile{
+				{Hash: "test", Position: 0, Name: "README", Format: "plaintext", File: entity.File("readme")},
+				{Hash: "test", Position: 1, Name: "config.toml", Format: "toml", File: entity.File("key =")},
+			}
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: files[0].File, Files: files}
+		)
+
+		m.valid.On("Validate", "plaintext", files[0].File).
+			Once().
+			Return(nil)
+		m.valid.On("Validate", "toml", files[1].File).
+			Once().
+			Return(&entity.FormatError{Format: "toml", Line: 1, Column: 6, Msg: "expected value"})
+
+		err := uc.Create(ctx, paste)
+
+		var fe *entity.FormatError
+		require.ErrorAs(t, err, &fe)
+		require.Equal(t, "config.toml", fe.File)
+	})
+
+	t.Run("Update error on invalid format", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:   "test",
+				Format: "plaintext",
+				UserID: sql.NullString{String: "user", Valid: true},
+				Valid:  true,
+			}
+			paste = &entity.Paste{Hash: "test", Format: "yaml"}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("key: [value"), nil)
+		m.valid.On("Validate", "yaml", entity.File("key: [value")).
+			Once().
+			Return(&entity.FormatError{Format: "yaml", Line: 1, Msg: "did not find expected ',' or ']'"})
+
+		err := uc.Update(ctx, paste)
+
+		var fe *entity.FormatError
+		require.ErrorAs(t, err, &fe)
+		require.Equal(t, "plaintext", stored.Format)
+	})
+}
+
+func TestPastesUseCase_Convert(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Convert paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			source = &entity.Paste{Hash: "source", Title: "config", Format: "json"}
+			conv   = &entity.Paste{ConvertedFrom: sql.NullString{String: "source", Valid: true}}
+			opts   = entity.ConvertOptions{To: "yaml"}
+		)
+
+		m.cache.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, true, nil)
+		m.blob.On("Get", ctx, "", source.Hash).
+			Once().
+			Return(entity.File(`{"key":"value"}`), nil)
+		m.views.On("Incr", ctx, source.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, source.Hash).
+			Once().
+			Return(0, false, nil)
+		m.conv.On("Convert", "json", entity.File(`{"key":"value"}`), opts).
+			Once().
+			Return(entity.File("key: value\n"), nil)
+
+		err := uc.Convert(ctx, conv, "", opts, false)
+		require.NoError(t, err)
+		require.Equal(t, "yaml", conv.Format)
+		require.Equal(t, "config", conv.Title)
+		require.Equal(t, entity.File("key: value\n"), conv.File)
+	})
+
+	t.Run("Pretty print and save paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			source = &entity.Paste{Hash: "source", Format: "json"}
+			conv   = &entity.Paste{
+				Hash:          "conv",
+				Title:         "pretty",
+				ConvertedFrom: sql.NullString{String: "source", Valid: true},
+			}
+		)
+
+		m.cache.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, true, nil)
+		m.blob.On("Get", ctx, "", source.Hash).
+			Once().
+			Return(entity.File(`{"key":"value"}`), nil)
+		m.views.On("Incr", ctx, source.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, source.Hash).
+			Once().
+			Return(0, false, nil)
+		m.conv.On("Convert", "json", entity.File(`{"key":"value"}`), entity.ConvertOptions{To: "json"}).
+			Once().
+			Return(entity.File("{\n  \"key\": \"value\"\n}"), nil)
+		m.valid.On("Validate", "json", entity.File("{\n  \"key\": \"value\"\n}")).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, conv).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, conv).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, conv.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+
+		err := uc.Convert(ctx, conv, "", entity.ConvertOptions{}, true)
+		require.NoError(t, err)
+		require.Equal(t, "json", conv.Format)
+		require.Equal(t, "pretty", conv.Title)
+		require.True(t, conv.Valid)
+	})
+
+	t.Run("Get error on not convertible paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			source = &entity.Paste{Hash: "source", Format: "yaml"}
+			conv   = &entity.Paste{ConvertedFrom: sql.NullString{String: "source", Valid: true}}
+			opts   = entity.ConvertOptions{To: "toml"}
+		)
+
+		m.cache.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, true, nil)
+		m.blob.On("Get", ctx, "", source.Hash).
+			Once().
+			Return(entity.File("- item"), nil)
+		m.views.On("Incr", ctx, source.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, source.Hash).
+			Once().
+			Return(0, false, nil)
+		m.conv.On("Convert", "yaml", entity.File("- item"), opts).
+			Once().
+			Return(nil, ErrNotConvertible)
+
+		err := uc.Convert(ctx, conv, "", opts, false)
+		require.ErrorIs(t, err, ErrNotConvertible)
+	})
+
+	t.Run("Get error on locked paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			source = &entity.Paste{Hash: "source", Format: "json"}
+			conv   = &entity.Paste{ConvertedFrom: sql.NullString{String: "source", Valid: true}}
+		)
+
+		source.Password.Set("secret")
+
+		m.cache.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, true, nil)
+		m.blob.On("Get", ctx, "", source.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+
+		err := uc.Convert(ctx, conv, "", entity.ConvertOptions{}, false)
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+
+	t.Run("Save paste with source protections", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			source = &entity.Paste{Hash: "source", Format: "json", MaxViews: 5}
+			conv   = &entity.Paste{Hash: "conv", ConvertedFrom: sql.NullString{String: "source", Valid: true}}
+			opts   = entity.ConvertOptions{To: "yaml"}
+		)
+
+		source.Password.Set("secret")
+
+		m.cache.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, true, nil)
+		m.blob.On("Get", ctx, "", source.Hash).
+			Once().
+			Return(entity.File(`{"key":"value"}`), nil)
+		m.views.On("Incr", ctx, source.Hash, 0, 5).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, source.Hash).
+			Once().
+			Return(0, false, nil)
+		m.conv.On("Convert", "json", entity.File(`{"key":"value"}`), opts).
+			Once().
+			Return(entity.File("key: value\n"), nil)
+		m.valid.On("Validate", "yaml", entity.File("key: value\n")).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, conv).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, conv).
+			Once().
+			Return(nil)
+
+		err := uc.Convert(ctx, conv, "secret", opts, true)
+		require.NoError(t, err)
+		require.True(t, conv.Password.Matches("secret"))
+		require.Equal(t, 5, conv.MaxViews)
+	})
+}
+
+func TestPastesUseCase_Query(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Query paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newPastesUseCase(t)
+			ctx     = context.Background()
+			paste   = &entity.Paste{Hash: "test", Format: "yaml"}
+			text    = entity.File("spec:\n  image: nginx\n")
+			results = []json.RawMessage{json.RawMessage(`"nginx"`)}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(text, nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.query.On("Query", ctx, "yaml", text, ".spec.image").
+			Once().
+			Return(results, nil)
+
+		got, err := uc.Query(ctx, paste.Hash, "", ".spec.image")
+		require.NoError(t, err)
+		require.Equal(t, results, got)
+	})
+
+	t.Run("Get error on query limit", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json"}
+			text  = entity.File("[1, 2, 3]")
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(text, nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.query.On("Query", ctx, "json", text, "[..,..] | [..,..]").
+			Once().
+			Return(nil, ErrQueryLimit)
+
+		_, err := uc.Query(ctx, paste.Hash, "", "[..,..] | [..,..]")
+		require.ErrorIs(t, err, ErrQueryLimit)
+	})
+
+	t.Run("Get error on locked paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json"}
+		)
+
+		paste.Password.Set("secret")
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+
+		_, err := uc.Query(ctx, paste.Hash, "", ".")
+		require.ErrorIs(t, err, ErrPasteLocked)
+	})
+}
+
+func TestPastesUseCase_ValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	var (
+		schemaText = entity.File(`{"type": "object", "required": ["name"]}`)
+		violations = []entity.SchemaViolation{{Pointer: "", Keyword: "required", Msg: `missing property "name"`}}
+	)
+
+	t.Run("Validate paste against schema", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			paste  = &entity.Paste{Hash: "test", Format: "yaml"}
+			schema = &entity.Paste{Hash: "schema", Format: "json"}
+			text   = entity.File("replicas: 1")
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(text, nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.cache.On("Get", ctx, schema.Hash).
+			Once().
+			Return(schema, true, nil)
+		m.blob.On("Get", ctx, "", schema.Hash).
+			Once().
+			Return(schemaText, nil)
+		m.schemas.On("Validate", "json", schemaText, "yaml", text).
+			Once().
+			Return(violations, nil)
+
+		got, err := uc.ValidateSchema(ctx, paste.Hash, "", schema.Hash)
+		require.NoError(t, err)
+		require.Equal(t, violations, got)
+	})
+
+	t.Run("Get error on paste without schema", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json"}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("{}"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+
+		_, err := uc.ValidateSchema(ctx, paste.Hash, "", "")
+		require.ErrorIs(t, err, ErrSchemaNotFound)
+	})
+
+	t.Run("Get error on missing schema", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "json"}
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.blob.On("Get", ctx, "", paste.Hash).
+			Once().
+			Return(entity.File("{}"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+		m.cache.On("Get", ctx, "schema").
+			Once().
+			Return(nil, false, nil)
+		m.repo.On("Get", ctx, "schema").
+			Once().
+			Return(nil, ErrRecordNotFound)
+
+		_, err := uc.ValidateSchema(ctx, paste.Hash, "", "schema")
+		require.ErrorIs(t, err, ErrSchemaNotFound)
+	})
+
+	t.Run("Create error on schema violation", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.Background()
+			schema = &entity.Paste{Hash: "schema", Format: "json"}
+			paste  = &entity.Paste{
+				Hash:   "test",
+				Format: "json",
+				File:   entity.File("{}"),
+				Schema: sql.NullString{String: "schema", Valid: true},
+			}
+		)
+
+		m.valid.On("Validate", "json", paste.File).
+			Once().
+			Return(nil)
+		m.cache.On("Get", ctx, schema.Hash).
+			Once().
+			Return(schema, true, nil)
+		m.blob.On("Get", ctx, "", schema.Hash).
+			Once().
+			Return(schemaText, nil)
+		m.schemas.On("Validate", "json", schemaText, "json", paste.File).
+			Once().
+			Return(violations, nil)
+
+		err := uc.Create(ctx, paste)
+
+		var se *entity.SchemaError
+		require.ErrorAs(t, err, &se)
+		require.Equal(t, violations, se.Violations)
+	})
+
+	t.Run("Update error on schema violation", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			schema = &entity.Paste{Hash: "schema", Format: "json"}
+			stored = &entity.Paste{
+				Hash:   "test",
+				Format: "json",
+				UserID: sql.NullString{String: "user", Valid: true},
+				Schema: sql.NullString{String: "schema", Valid: true},
+				Valid:  true,
+			}
+			paste = &entity.Paste{Hash: "test", File: entity.File(`{"replicas": 1}`)}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File(`{"name": "web"}`), nil)
+		m.valid.On("Validate", "json", paste.File).
+			Once().
+			Return(nil)
+		m.cache.On("Get", ctx, schema.Hash).
+			Once().
+			Return(schema, true, nil)
+		m.blob.On("Get", ctx, "", schema.Hash).
+			Once().
+			Return(schemaText, nil)
+		m.schemas.On("Validate", "json", schemaText, "json", paste.File).
+			Once().
+			Return(violations, nil)
+
+		err := uc.Update(ctx, paste)
+
+		var se *entity.SchemaError
+		require.ErrorAs(t, err, &se)
+		require.Equal(t, 0, stored.Revision)
+	})
+
+	t.Run("Update paste with removed schema", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:   "test",
+				Format: "json",
+				UserID: sql.NullString{String: "user", Valid: true},
+				Schema: sql.NullString{String: "missing", Valid: true},
+				Valid:  true,
+			}
+			paste = &entity.Paste{
+				Hash:   "test",
+				File:   entity.File(`{"replicas": 1}`),
+				Schema: sql.NullString{Valid: true},
+			}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File(`{"name": "web"}`), nil)
+		m.valid.On("Validate", "json", paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("CreateRevision", ctx, stored).
+			Once().
+			Return(nil)
+		m.blob.On("Update", ctx, stored).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, stored.Hash, mock.Anything, mock.Anything).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Update(ctx, paste)
+		require.NoError(t, err)
+		require.False(t, paste.Schema.Valid)
+		require.Equal(t, 1, paste.Revision)
+	})
+}
+
+func TestPastesUseCase_Index(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create locked paste without indexing", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: entity.File("secret")}
+		)
+
+		paste.Password.Set("password")
+
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+	})
+
+	t.Run("Remove paste locked on update from index", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:   "test",
+				UserID: sql.NullString{String: "user", Valid: true},
+			}
+			paste = &entity.Paste{Hash: "test"}
+		)
+
+		paste.Password.Set("password")
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+		m.blob.On("CreateRevision", ctx, stored).
+			Once().
+			Return(nil)
+		m.search.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Update(ctx, paste)
+		require.NoError(t, err)
+	})
+}
+
+func TestPastesUseCase_ListUserPastes(t *testing.T) {
+	t.Parallel()
+
+	var (
+		created = time.Date(2023, 10, 29, 20, 38, 41, 0, time.UTC)
+		pastes  = []*entity.Paste{
+			{Hash: "first", Title: "b", CreatedAt: created.Add(time.Hour)},
+			{Hash: "second", Title: "a", CreatedAt: created},
+			{Hash: "third", Title: "c", CreatedAt: created.Add(-time.Hour)},
+		}
+	)
+
+	t.Run("List first page", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newPastesUseCase(t)
+			ctx     = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			expired = false
+			q       = entity.PasteListQuery{Expired: &expired, Sort: entity.SortCreated, Desc: true, Limit: 2}
+		)
+
+		m.repo.On("ListByUser", ctx, entity.PasteListQuery{UserID: "user", Expired: &expired, Sort: entity.SortCreated, Desc: true, Limit: 3}).
+			Once().
+			Return(pastes, nil)
+
+		page, err := uc.ListUserPastes(ctx, q)
+		require.NoError(t, err)
+		require.Equal(t, pastes[:2], page.Pastes)
+		require.Equal(t, &entity.PasteListCursor{Sort: entity.SortCreated, Key: "2023-10-29T20:38:41Z", Hash: "second"}, page.Next)
+	})
+
+	t.Run("List last page", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			after = &entity.PasteListCursor{Sort: entity.SortTitle, Key: "a", Hash: "second"}
+			q     = entity.PasteListQuery{Sort: entity.SortTitle, After: after, Limit: 2}
+		)
+
+		m.repo.On("ListByUser", ctx, entity.PasteListQuery{UserID: "user", Sort: entity.SortTitle, After: after, Limit: 3}).
+			Once().
+			Return([]*entity.Paste{pastes[0], pastes[2]}, nil)
+
+		page, err := uc.ListUserPastes(ctx, q)
+		require.NoError(t, err)
+		require.Len(t, page.Pastes, 2)
+		require.Nil(t, page.Next)
+	})
+
+	t.Run("Get error on anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		uc, _ := newPastesUseCase(t)
+
+		_, err := uc.ListUserPastes(context.Background(), entity.PasteListQuery{Limit: 2})
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Get error on list", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+		)
+
+		m.repo.On("ListByUser", ctx, mock.Anything).
+			Once().
+			Return(nil, errTest)
+
+		_, err := uc.ListUserPastes(ctx, entity.PasteListQuery{Limit: 2})
+		require.ErrorIs(t, err, errTest)
+	})
+}
+
+func TestPastesUseCase_Visibility(t *testing.T) {
+	t.Parallel()
+
+	newPrivate := func() *entity.Paste {
+		return &entity.Paste{
+			Hash:       "test",
+			UserID:     sql.NullString{String: "owner", Valid: true},
+			Visibility: entity.VisibilityPrivate,
+		}
+	}
+
+	t.Run("Get error on cached private paste of other user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "other")
+			paste = newPrivate()
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.grants.On("Exists", ctx, paste.Hash, "other").
+			Once().
+			Return(false, nil)
+
+		_, err := uc.Get(ctx, paste.Hash)
+		require.ErrorIs(t, err, ErrPasteNotFound)
+	})
+
+	t.Run("Get error on private paste of anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = newPrivate()
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(nil, false, nil)
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		_, err := uc.Get(ctx, paste.Hash)
+		require.ErrorIs(t, err, ErrPasteNotFound)
+	})
+
+	t.Run("Get private paste by granted user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "granted")
+			paste = newPrivate()
+		)
+
+		m.cache.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, true, nil)
+		m.grants.On("Exists", ctx, paste.Hash, "granted").
+			Once().
+			Return(true, nil)
+		m.blob.On("Get", ctx, "owner", paste.Hash).
+			Once().
+			Return(entity.File("secret"), nil)
+		m.views.On("Incr", ctx, paste.Hash, 0, 0).
+			Once().
+			Return(1, nil)
+		m.starred.On("Get", ctx, paste.Hash).
+			Once().
+			Return(0, false, nil)
+
+		got, err := uc.Get(ctx, paste.Hash)
+		require.NoError(t, err)
+		require.Equal(t, entity.File("secret"), got.File)
+	})
+
+	t.Run("Get revisions of views limited paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{Hash: "test", Revision: 2, MaxViews: 1}
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.revs.On("List", ctx, paste.Hash).
+			Once().
+			Return([]*entity.PasteRevision{{Hash: paste.Hash, Revision: 1}}, nil)
+
+		revs, err := uc.GetRevisions(ctx, paste.Hash, "")
+		require.NoError(t, err)
+		require.Len(t, revs, 2)
+	})
+
+	t.Run("Get revisions error on private paste of other user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "other")
+			paste = newPrivate()
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.grants.On("Exists", ctx, paste.Hash, "other").
+			Once().
+			Return(false, nil)
+
+		_, err := uc.GetRevisions(ctx, paste.Hash, "")
+		require.ErrorIs(t, err, ErrPasteNotFound)
+	})
+
+	t.Run("Get forks without private forks of other users", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+			source = &entity.Paste{Hash: "source", Visibility: entity.VisibilityPublic}
+			forks  = []*entity.Paste{
+				{Hash: "mine", UserID: sql.NullString{String: "owner", Valid: true}, Visibility: entity.VisibilityPrivate},
+				{Hash: "other", UserID: sql.NullString{String: "other", Valid: true}, Visibility: entity.VisibilityPrivate},
+				{Hash: "public", Visibility: entity.VisibilityPublic},
+			}
+		)
+
+		m.repo.On("Get", ctx, source.Hash).
+			Once().
+			Return(source, nil)
+		m.repo.On("ListForks", ctx, source.Hash).
+			Once().
+			Return(forks, nil)
+		m.grants.On("Exists", ctx, "other", "owner").
+			Once().
+			Return(false, nil)
+
+		got, err := uc.GetForks(ctx, source.Hash)
+		require.NoError(t, err)
+		require.Equal(t, []*entity.Paste{forks[0], forks[2]}, got)
+	})
+
+	t.Run("Create error on private paste of anonymous", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, _ = newPastesUseCase(t)
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: entity.File("test"), Visibility: entity.VisibilityPrivate}
+		)
+
+		err := uc.Create(context.Background(), paste)
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Grant access", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+			paste = newPrivate()
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.grants.On("Create", ctx, paste.Hash, "octocat").
+			Once().
+			Return(nil)
+
+		err := uc.GrantAccess(ctx, paste.Hash, "octocat")
+		require.NoError(t, err)
+	})
+
+	t.Run("Grant access error on unknown user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "owner")
+			paste = newPrivate()
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.grants.On("Create", ctx, paste.Hash, "nobody").
+			Once().
+			Return(ErrRecordNotFound)
+
+		err := uc.GrantAccess(ctx, paste.Hash, "nobody")
+		require.ErrorIs(t, err, ErrUserNotFound)
+	})
+
+	t.Run("Revoke access error on not author", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "other")
+			paste = newPrivate()
+		)
+
+		m.repo.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+
+		err := uc.RevokeAccess(ctx, paste.Hash, "octocat")
+		require.ErrorIs(t, err, ErrNotPasteAuthor)
+	})
+}
+
+func TestPastesUseCase_Feeds(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create public paste invalidates feeds", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: entity.File("test"), Visibility: entity.VisibilityPublic}
+		)
+
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, paste.Title, "test").
+			Once().
+			Return(nil)
+		m.feeds.On("Delete", ctx, "").
+			Once().
+			Return(nil)
+		m.feeds.On("Delete", ctx, "user").
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+	})
+}
+
+func TestPastesUseCase_Tags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Create paste with normalized tags", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newPastesUseCase(t)
+			ctx   = context.Background()
+			paste = &entity.Paste{
+				Hash:   "test",
+				Format: "plaintext",
+				File:   entity.File("test"),
+				Tags:   []string{" Go ", "K8s", "go", "CI  CD"},
+			}
+		)
+
+		m.valid.On("Validate", paste.Format, paste.File).
+			Once().
+			Return(nil)
+		m.blob.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.repo.On("Create", ctx, paste).
+			Once().
+			Return(nil)
+		m.tags.On("Set", ctx, paste.Hash, []string{"ci-cd", "go", "k8s"}).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, paste.Hash, paste.Title, "test").
+			Once().
+			Return(nil)
+
+		err := uc.Create(ctx, paste)
+		require.NoError(t, err)
+		require.Equal(t, []string{"ci-cd", "go", "k8s"}, paste.Tags)
+	})
+
+	t.Run("Create error on invalid tag", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, _ = newPastesUseCase(t)
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: entity.File("test"), Tags: []string{"a/b"}}
+		)
+
+		err := uc.Create(context.Background(), paste)
+		require.ErrorIs(t, err, ErrInvalidTags)
+	})
+
+	t.Run("Create error on too many tags", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, _ = newPastesUseCase(t)
+			paste = &entity.Paste{Hash: "test", Format: "plaintext", File: entity.File("test")}
+		)
+
+		for i := 0; i <= maxTags; i++ {
+			paste.Tags = append(paste.Tags, "tag"+strconv.Itoa(i))
+		}
+
+		err := uc.Create(context.Background(), paste)
+		require.ErrorIs(t, err, ErrInvalidTags)
+	})
+
+	t.Run("Update paste removes tags", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newPastesUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			stored = &entity.Paste{
+				Hash:     "test",
+				Format:   "plaintext",
+				Revision: 1,
+				UserID:   sql.NullString{String: "user", Valid: true},
+				Tags:     []string{"go"},
+			}
+			paste = &entity.Paste{Hash: "test", Tags: []string{}}
+		)
+
+		m.repo.On("Update", ctx, paste.Hash, mock.Anything).
+			Once().
+			Return(lockedUpdate(stored))
+		m.blob.On("Get", ctx, "user", stored.Hash).
+			Once().
+			Return(entity.File("test"), nil)
+		m.blob.On("CreateRevision", ctx, stored).
+			Once().
+			Return(nil)
+		m.tags.On("Set", ctx, stored.Hash, []string{}).
+			Once().
+			Return(nil)
+		m.search.On("Index", ctx, stored.Hash, "", "test").
+			Once().
+			Return(nil)
+		m.cache.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+		m.renders.On("Delete", ctx, stored.Hash).
+			Once().
+			Return(nil)
+
+		err := uc.Update(ctx, paste)
+		require.NoError(t, err)
+		require.Empty(t, paste.Tags)
 	})
 }
@@ -0,0 +1,348 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.CollectionsRepo = &CollectionsRepo{}
+
+type CollectionsRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewCollectionsRepository(pg *postgres.Postgres) *CollectionsRepo {
+	return &CollectionsRepo{pg: pg}
+}
+
+// Create inserts a collection without pastes.
+func (r *CollectionsRepo) Create(ctx context.Context, c *entity.Collection) error {
+	sql, args, err := r.pg.Builder.
+		Insert("collections").
+		Columns("hash", "user_id", "title", "visibility").
+		Values(c.Hash, c.UserID, c.Title, c.Visibility).
+		Suffix("RETURNING created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.Create.Builder: %w", err)
+	}
+
+	if err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&c.CreatedAt, &c.UpdatedAt); err != nil {
+		return fmt.Errorf("CollectionsRepo.Create.Pool.QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns a collection without pastes.
+// If the collection does not exist returns usecase.ErrRecordNotFound.
+func (r *CollectionsRepo) Get(ctx context.Context, hash string) (*entity.Collection, error) {
+	sql, args, err := r.pg.Builder.
+		Select("hash", "user_id", "title", "visibility", "created_at", "updated_at").
+		From("collections").
+		Where(sq.Eq{"hash": hash}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CollectionsRepo.Get.Builder: %w", err)
+	}
+
+	c := new(entity.Collection)
+
+	err = r.pg.Pool.QueryRow(ctx, sql, args...).
+		Scan(&c.Hash, &c.UserID, &c.Title, &c.Visibility, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecase.ErrRecordNotFound
+		}
+
+		return nil, fmt.Errorf("CollectionsRepo.Get.Pool.QueryRow: %w", err)
+	}
+
+	return c, nil
+}
+
+// Update updates a collection title and visibility and bumps updated_at.
+func (r *CollectionsRepo) Update(ctx context.Context, c *entity.Collection) error {
+	sql, args, err := r.pg.Builder.
+		Update("collections").
+		Set("title", c.Title).
+		Set("visibility", c.Visibility).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"hash": c.Hash}).
+		Suffix("RETURNING updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.Update.Builder: %w", err)
+	}
+
+	if err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&c.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecase.ErrRecordNotFound
+		}
+
+		return fmt.Errorf("CollectionsRepo.Update.Pool.QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a collection, pastes of the collection are kept.
+func (r *CollectionsRepo) Delete(ctx context.Context, hash string) error {
+	sql, args, err := r.pg.Builder.
+		Delete("collections").
+		Where(sq.Eq{"hash": hash}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.Delete.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("CollectionsRepo.Delete.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// ListPastes returns metadata of not expired pastes of the collection readable by the user
+// in the collection order. Private pastes are returned only to their authors and users
+// granted access, empty userID means an anonymous user.
+func (r *CollectionsRepo) ListPastes(ctx context.Context, hash, userID string) ([]*entity.Paste, error) {
+	sql, args, err := r.pg.Builder.
+		Select(
+			"pastes.hash", "pastes.user_id", "pastes.title", "pastes.format", "pastes.expires_at",
+			"pastes.created_at", "pastes.updated_at", tagsColumn,
+		).
+		From("collection_pastes cp").
+		Join("pastes ON pastes.hash = cp.paste_hash").
+		Where(sq.Eq{"cp.collection_hash": hash}).
+		Where(sq.Or{sq.Eq{"pastes.expires_at": nil}, sq.Expr("pastes.expires_at >= CURRENT_TIMESTAMP")}).
+		Where(sq.Or{visibleTo(userID), sq.Eq{"pastes.visibility": entity.VisibilityUnlisted}}).
+		OrderBy("cp.position").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CollectionsRepo.ListPastes.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionsRepo.ListPastes.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	pastes := make([]*entity.Paste, 0)
+
+	for rows.Next() {
+		var (
+			p         = new(entity.Paste)
+			expiresAt *time.Time
+		)
+
+		err = rows.Scan(&p.Hash, &p.UserID, &p.Title, &p.Format, &expiresAt, &p.CreatedAt, &p.UpdatedAt, &p.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("CollectionsRepo.ListPastes.Rows.Scan: %w", err)
+		}
+
+		p.ExpiresAt = fromNullTime(expiresAt)
+
+		pastes = append(pastes, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CollectionsRepo.ListPastes.Rows: %w", err)
+	}
+
+	return pastes, nil
+}
+
+// PasteHashes returns hashes of all pastes of the collection in the collection order.
+func (r *CollectionsRepo) PasteHashes(ctx context.Context, hash string) ([]string, error) {
+	sql, args, err := r.pg.Builder.
+		Select("paste_hash").
+		From("collection_pastes").
+		Where(sq.Eq{"collection_hash": hash}).
+		OrderBy("position").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("CollectionsRepo.PasteHashes.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("CollectionsRepo.PasteHashes.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make([]string, 0)
+
+	for rows.Next() {
+		var h string
+		if err = rows.Scan(&h); err != nil {
+			return nil, fmt.Errorf("CollectionsRepo.PasteHashes.Rows.Scan: %w", err)
+		}
+
+		hashes = append(hashes, h)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("CollectionsRepo.PasteHashes.Rows: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// AddPastes appends pastes to the end of the collection in order,
+// pastes already in the collection keep their positions.
+func (r *CollectionsRepo) AddPastes(ctx context.Context, hash string, pastes []string) error {
+	if len(pastes) == 0 {
+		return nil
+	}
+
+	query := r.pg.Builder.
+		Insert("collection_pastes").
+		Columns("collection_hash", "paste_hash", "position")
+
+	// All rows see positions before the insert, so offsets keep the order.
+	for i, p := range pastes {
+		query = query.Values(
+			hash,
+			p,
+			sq.Expr("(SELECT COALESCE(max(position) + 1, 0) FROM collection_pastes WHERE collection_hash = ?) + ?", hash, i),
+		)
+	}
+
+	sql, args, err := query.
+		Suffix("ON CONFLICT (collection_hash, paste_hash) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.AddPastes.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("CollectionsRepo.AddPastes.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// RemovePaste removes a paste from the collection.
+// If the paste is not in the collection returns usecase.ErrRecordNotFound.
+func (r *CollectionsRepo) RemovePaste(ctx context.Context, hash, pasteHash string) error {
+	sql, args, err := r.pg.Builder.
+		Delete("collection_pastes").
+		Where(sq.Eq{"collection_hash": hash, "paste_hash": pasteHash}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.RemovePaste.Builder: %w", err)
+	}
+
+	tag, err := r.pg.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.RemovePaste.Pool.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return usecase.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// SetOrder sets positions of pastes of the collection to their indexes in pastes.
+// Pastes must contain all pastes of the collection.
+func (r *CollectionsRepo) SetOrder(ctx context.Context, hash string, pastes []string) error {
+	sql, args, err := r.pg.Builder.
+		Update("collection_pastes").
+		Set("position", sq.Expr("array_position(?::varchar[], paste_hash) - 1", pastes)).
+		Where(sq.Eq{"collection_hash": hash}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.SetOrder.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("CollectionsRepo.SetOrder.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// CreateGrant grants the user access to a private collection, granting it again is a no-op.
+// If the user does not exist returns usecase.ErrRecordNotFound.
+func (r *CollectionsRepo) CreateGrant(ctx context.Context, hash, username string) error {
+	sql, args, err := r.pg.Builder.
+		Insert("collection_grants").
+		Columns("collection_hash", "user_id").
+		Select(
+			r.pg.Builder.
+				Select().
+				Column(sq.Expr("?::varchar", hash)).
+				Column("id").
+				From("users").
+				Where(sq.Eq{"username": username}),
+		).
+		Suffix("ON CONFLICT (collection_hash, user_id) DO UPDATE SET collection_hash = EXCLUDED.collection_hash RETURNING user_id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.CreateGrant.Builder: %w", err)
+	}
+
+	var userID string
+
+	if err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecase.ErrRecordNotFound
+		}
+
+		return fmt.Errorf("CollectionsRepo.CreateGrant.Pool.QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteGrant revokes access of the user to a private collection.
+func (r *CollectionsRepo) DeleteGrant(ctx context.Context, hash, username string) error {
+	sql, args, err := r.pg.Builder.
+		Delete("collection_grants").
+		Where(sq.Eq{"collection_hash": hash}).
+		Where("user_id = (SELECT id FROM users WHERE username = ?)", username).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("CollectionsRepo.DeleteGrant.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("CollectionsRepo.DeleteGrant.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// GrantExists reports whether the user is granted access to a collection.
+func (r *CollectionsRepo) GrantExists(ctx context.Context, hash, userID string) (bool, error) {
+	sql, args, err := r.pg.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("collection_grants").
+		Where(sq.Eq{"collection_hash": hash, "user_id": userID}).
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("CollectionsRepo.GrantExists.Builder: %w", err)
+	}
+
+	var exists bool
+
+	if err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("CollectionsRepo.GrantExists.Pool.QueryRow: %w", err)
+	}
+
+	return exists, nil
+}
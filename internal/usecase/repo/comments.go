@@ -0,0 +1,225 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.PasteCommentsRepo = &PasteCommentsRepo{}
+
+// commentColumns are columns of comments joined with their authors.
+var commentColumns = []string{
+	"c.id", "c.paste_hash", "c.user_id", "u.username", "COALESCE(c.parent_id, 0)", "c.revision",
+	"c.line_start", "c.line_end", "c.text", "c.deleted", "c.created_at", "c.updated_at",
+}
+
+// repliesQuery selects replies to the comment being changed.
+const repliesQuery = "SELECT 1 FROM paste_comments r WHERE r.parent_id = paste_comments.id"
+
+type PasteCommentsRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewPasteCommentsRepository(pg *postgres.Postgres) *PasteCommentsRepo {
+	return &PasteCommentsRepo{pg: pg}
+}
+
+// Create stores a new comment and sets its id, author name and dates.
+func (r *PasteCommentsRepo) Create(ctx context.Context, c *entity.PasteComment) error {
+	var parentID *int64
+	if c.ParentID != 0 {
+		parentID = &c.ParentID
+	}
+
+	sql, args, err := r.pg.Builder.
+		Insert("paste_comments").
+		Columns("paste_hash", "user_id", "parent_id", "revision", "line_start", "line_end", "text").
+		Values(c.PasteHash, c.UserID, parentID, c.Revision, c.LineStart, c.LineEnd, c.Text).
+		Suffix("RETURNING id, (SELECT username FROM users WHERE users.id = paste_comments.user_id), created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteCommentsRepo.Create.Builder: %w", err)
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&c.ID, &c.Author, &c.CreatedAt, &c.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("PasteCommentsRepo.Create.Pool.QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns a comment by id. If the comment does not exist returns usecase.ErrRecordNotFound.
+func (r *PasteCommentsRepo) Get(ctx context.Context, id int64) (*entity.PasteComment, error) {
+	sql, args, err := r.pg.Builder.
+		Select(commentColumns...).
+		From("paste_comments c").
+		Join("users u ON u.id = c.user_id").
+		Where(sq.Eq{"c.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteCommentsRepo.Get.Builder: %w", err)
+	}
+
+	c := new(entity.PasteComment)
+
+	if err = scanComment(r.pg.Pool.QueryRow(ctx, sql, args...), c); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecase.ErrRecordNotFound
+		}
+
+		return nil, fmt.Errorf("PasteCommentsRepo.Get.Pool.QueryRow: %w", err)
+	}
+
+	return c, nil
+}
+
+// List returns all comments of a paste in order of creation, so replies follow their parents.
+func (r *PasteCommentsRepo) List(ctx context.Context, hash string) ([]*entity.PasteComment, error) {
+	sql, args, err := r.pg.Builder.
+		Select(commentColumns...).
+		From("paste_comments c").
+		Join("users u ON u.id = c.user_id").
+		Where(sq.Eq{"c.paste_hash": hash}).
+		OrderBy("c.id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteCommentsRepo.List.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteCommentsRepo.List.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	comments := make([]*entity.PasteComment, 0)
+
+	for rows.Next() {
+		c := new(entity.PasteComment)
+		if err = scanComment(rows, c); err != nil {
+			return nil, fmt.Errorf("PasteCommentsRepo.List.Rows.Scan: %w", err)
+		}
+
+		comments = append(comments, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteCommentsRepo.List.Rows: %w", err)
+	}
+
+	return comments, nil
+}
+
+// Update updates the comment text and sets its update date.
+// If the comment does not exist returns usecase.ErrRecordNotFound.
+func (r *PasteCommentsRepo) Update(ctx context.Context, c *entity.PasteComment) error {
+	sql, args, err := r.pg.Builder.
+		Update("paste_comments").
+		Set("text", c.Text).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": c.ID}).
+		Suffix("RETURNING updated_at").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteCommentsRepo.Update.Builder: %w", err)
+	}
+
+	if err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&c.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecase.ErrRecordNotFound
+		}
+
+		return fmt.Errorf("PasteCommentsRepo.Update.Pool.QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes a comment. A comment with replies is only marked deleted and loses
+// its text, so the thread stays intact. Deleted parents left without replies are
+// deleted too. If the comment does not exist returns usecase.ErrRecordNotFound.
+func (r *PasteCommentsRepo) Delete(ctx context.Context, id int64) error {
+	hide, hideArgs, err := r.pg.Builder.
+		Update("paste_comments").
+		Set("text", "").
+		Set("deleted", true).
+		Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id}).
+		Where("EXISTS (" + repliesQuery + ")").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteCommentsRepo.Delete.Builder: %w", err)
+	}
+
+	err = r.pg.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, hide, hideArgs...)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() > 0 {
+			return nil
+		}
+
+		del := r.pg.Builder.
+			Delete("paste_comments").
+			Where(sq.Eq{"id": id}).
+			Suffix("RETURNING parent_id")
+
+		for first := true; ; first = false {
+			sql, args, err := del.ToSql()
+			if err != nil {
+				return err
+			}
+
+			var parentID *int64
+
+			if err = tx.QueryRow(ctx, sql, args...).Scan(&parentID); err != nil {
+				if !errors.Is(err, pgx.ErrNoRows) {
+					return err
+				}
+
+				if first {
+					return usecase.ErrRecordNotFound
+				}
+
+				return nil
+			}
+
+			if parentID == nil {
+				return nil
+			}
+
+			// Next are deleted ancestors of the comment left without replies.
+			del = r.pg.Builder.
+				Delete("paste_comments").
+				Where(sq.Eq{"id": *parentID, "deleted": true}).
+				Where("NOT EXISTS (" + repliesQuery + ")").
+				Suffix("RETURNING parent_id")
+		}
+	})
+	if err != nil {
+		if errors.Is(err, usecase.ErrRecordNotFound) {
+			return err
+		}
+
+		return fmt.Errorf("PasteCommentsRepo.Delete.Pool.BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+func scanComment(row pgx.Row, c *entity.PasteComment) error {
+	return row.Scan(
+		&c.ID, &c.PasteHash, &c.UserID, &c.Author, &c.ParentID, &c.Revision,
+		&c.LineStart, &c.LineEnd, &c.Text, &c.Deleted, &c.CreatedAt, &c.UpdatedAt,
+	)
+}
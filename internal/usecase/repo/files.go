@@ -0,0 +1,53 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.PasteFilesRepo = &PasteFilesRepo{}
+
+// filesColumn selects files metadata of the selected paste as a JSON array ordered by
+// position, so pastes are read with their files in one query. Single file pastes have NULL.
+const filesColumn = "(SELECT json_agg(json_build_object('position', f.position, 'name', f.name, 'format', f.format) ORDER BY f.position) FROM paste_files f WHERE f.paste_hash = pastes.hash) AS files"
+
+var fileColumns = []string{
+	"paste_hash",
+	"position",
+	"name",
+	"format",
+}
+
+type PasteFilesRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewPasteFilesRepository(pg *postgres.Postgres) *PasteFilesRepo {
+	return &PasteFilesRepo{pg: pg}
+}
+
+// Create inserts files metadata of a multi-file paste in database.
+func (r *PasteFilesRepo) Create(ctx context.Context, files []*entity.PasteFile) error {
+	query := r.pg.Builder.
+		Insert("paste_files").
+		Columns(fileColumns...)
+
+	for _, f := range files {
+		query = query.Values(f.Hash, f.Position, f.Name, f.Format)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteFilesRepo.Create.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("PasteFilesRepo.Create.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.PasteGrantsRepo = &PasteGrantsRepo{}
+
+type PasteGrantsRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewPasteGrantsRepository(pg *postgres.Postgres) *PasteGrantsRepo {
+	return &PasteGrantsRepo{pg: pg}
+}
+
+// Create grants the user access to a private paste, granting it again is a no-op.
+// If the user does not exist returns usecase.ErrRecordNotFound.
+func (r *PasteGrantsRepo) Create(ctx context.Context, hash, username string) error {
+	sql, args, err := r.pg.Builder.
+		Insert("paste_grants").
+		Columns("paste_hash", "user_id").
+		Select(
+			r.pg.Builder.
+				Select().
+				Column(sq.Expr("?::varchar", hash)).
+				Column("id").
+				From("users").
+				Where(sq.Eq{"username": username}),
+		).
+		Suffix("ON CONFLICT (paste_hash, user_id) DO UPDATE SET paste_hash = EXCLUDED.paste_hash RETURNING user_id").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteGrantsRepo.Create.Builder: %w", err)
+	}
+
+	var userID string
+
+	if err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecase.ErrRecordNotFound
+		}
+
+		return fmt.Errorf("PasteGrantsRepo.Create.Pool.QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+// Delete revokes access of the user to a private paste.
+func (r *PasteGrantsRepo) Delete(ctx context.Context, hash, username string) error {
+	sql, args, err := r.pg.Builder.
+		Delete("paste_grants").
+		Where(sq.Eq{"paste_hash": hash}).
+		Where("user_id = (SELECT id FROM users WHERE username = ?)", username).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteGrantsRepo.Delete.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("PasteGrantsRepo.Delete.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// List returns names of users granted access to a paste ordered by name.
+func (r *PasteGrantsRepo) List(ctx context.Context, hash string) ([]string, error) {
+	sql, args, err := r.pg.Builder.
+		Select("u.username").
+		From("paste_grants g").
+		Join("users u ON u.id = g.user_id").
+		Where(sq.Eq{"g.paste_hash": hash}).
+		OrderBy("u.username").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteGrantsRepo.List.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteGrantsRepo.List.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	usernames := make([]string, 0)
+
+	for rows.Next() {
+		var username string
+		if err = rows.Scan(&username); err != nil {
+			return nil, fmt.Errorf("PasteGrantsRepo.List.Rows.Scan: %w", err)
+		}
+
+		usernames = append(usernames, username)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteGrantsRepo.List.Rows: %w", err)
+	}
+
+	return usernames, nil
+}
+
+// Exists reports whether the user is granted access to a paste.
+func (r *PasteGrantsRepo) Exists(ctx context.Context, hash, userID string) (bool, error) {
+	sql, args, err := r.pg.Builder.
+		Select("1").
+		Prefix("SELECT EXISTS (").
+		From("paste_grants").
+		Where(sq.Eq{"paste_hash": hash, "user_id": userID}).
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("PasteGrantsRepo.Exists.Builder: %w", err)
+	}
+
+	var exists bool
+
+	if err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&exists); err != nil {
+		return false, fmt.Errorf("PasteGrantsRepo.Exists.Pool.QueryRow: %w", err)
+	}
+
+	return exists, nil
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/jackc/pgx/v4"
 	"github.com/romankravchuk/pastebin/internal/entity"
 	"github.com/romankravchuk/pastebin/internal/usecase"
@@ -13,6 +15,27 @@ import (
 
 var _ usecase.PastesRepo = &PastesRepo{}
 
+// forksColumn counts forks of the selected paste.
+const forksColumn = "(SELECT count(*) FROM pastes f WHERE f.forked_from = pastes.hash) AS forks"
+
+// nullTime returns nil for zero time, so pastes that never expire have NULL expires_at.
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// fromNullTime returns zero time for NULL.
+func fromNullTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
+
 type PastesRepo struct {
 	pg *postgres.Postgres
 }
@@ -41,45 +64,92 @@ func (r *PastesRepo) Delete(ctx context.Context, hash string) error {
 
 // GetPaste implements usecase.PastesRepo.
 func (r *PastesRepo) Get(ctx context.Context, hash string) (*entity.Paste, error) {
-	var (
-		columns = []string{
+	s, args, err := pasteQuery(r.pg.Builder, hash).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.GetPaste.Builder: %w", err)
+	}
+
+	paste, err := scanPaste(r.pg.Pool.QueryRow(ctx, s, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecase.ErrRecordNotFound
+		}
+
+		return nil, fmt.Errorf("PastesRepo.GetPaste.Pool.QueryRow: %w", err)
+	}
+
+	return paste, nil
+}
+
+// pasteQuery selects the paste metadata scanned by scanPaste.
+func pasteQuery(b sq.StatementBuilderType, hash string) sq.SelectBuilder {
+	return b.
+		Select(
 			"hash",
 			"user_id",
 			"title",
 			"format",
+			"format_confidence",
 			"password_hash",
 			"expires_at",
 			"created_at",
-		}
-		query = r.pg.Builder.
-			Select(columns...).
-			From("pastes").
-			Where("hash = ?", hash)
+			"updated_at",
+			"revision",
+			"forked_from",
+			"converted_from",
+			"schema",
+			forksColumn,
+			"burn_after_read",
+			"views",
+			"max_views",
+			"visibility",
+			tagsColumn,
+			"stars",
+			"valid",
+			filesColumn,
+		).
+		From("pastes").
+		Where("hash = ?", hash)
+}
+
+func scanPaste(row pgx.Row) (*entity.Paste, error) {
+	var (
+		paste     = entity.Paste{}
+		expiresAt *time.Time
 	)
 
-	s, args, err := query.ToSql()
+	err := row.Scan(
+		&paste.Hash,
+		&paste.UserID,
+		&paste.Title,
+		&paste.Format,
+		&paste.FormatConfidence,
+		&paste.Password.Hash,
+		&expiresAt,
+		&paste.CreatedAt,
+		&paste.UpdatedAt,
+		&paste.Revision,
+		&paste.ForkedFrom,
+		&paste.ConvertedFrom,
+		&paste.Schema,
+		&paste.Forks,
+		&paste.BurnAfterRead,
+		&paste.Views,
+		&paste.MaxViews,
+		&paste.Visibility,
+		&paste.Tags,
+		&paste.Stars,
+		&paste.Valid,
+		&paste.Files,
+	)
 	if err != nil {
-		return nil, fmt.Errorf("PastesRepo.GetPaste.Builder: %w", err)
+		return nil, err
 	}
 
-	paste := entity.Paste{}
+	paste.ExpiresAt = fromNullTime(expiresAt)
 
-	err = r.pg.Pool.QueryRow(ctx, s, args...).
-		Scan(
-			&paste.Hash,
-			&paste.UserID,
-			&paste.Title,
-			&paste.Format,
-			&paste.Password.Hash,
-			&paste.ExpiresAt,
-			&paste.CreatedAt,
-		)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, usecase.ErrRecordNotFound
-		}
-
-		return nil, fmt.Errorf("PastesRepo.GetPaste.Pool.QueryRow: %w", err)
+	for _, f := range paste.Files {
+		f.Hash = paste.Hash
 	}
 
 	return &paste, nil
@@ -88,8 +158,8 @@ func (r *PastesRepo) Get(ctx context.Context, hash string) (*entity.Paste, error
 // Create inserts a paste metadata in database and upload paste text in blob storage.
 func (r *PastesRepo) Create(ctx context.Context, p *entity.Paste) error {
 	var (
-		columns = []string{"hash", "format"}
-		values  = []any{p.Hash, p.Format}
+		columns = []string{"hash", "format", "format_confidence"}
+		values  = []any{p.Hash, p.Format, p.FormatConfidence}
 		query   = r.pg.Builder.Insert("pastes")
 	)
 
@@ -113,26 +183,521 @@ func (r *PastesRepo) Create(ctx context.Context, p *entity.Paste) error {
 		values = append(values, p.ExpiresAt)
 	}
 
+	if p.ForkedFrom.Valid {
+		columns = append(columns, "forked_from")
+		values = append(values, p.ForkedFrom)
+	}
+
+	if p.ConvertedFrom.Valid {
+		columns = append(columns, "converted_from")
+		values = append(values, p.ConvertedFrom)
+	}
+
+	if p.Schema.Valid {
+		columns = append(columns, "schema")
+		values = append(values, p.Schema)
+	}
+
+	if p.BurnAfterRead {
+		columns = append(columns, "burn_after_read")
+		values = append(values, p.BurnAfterRead)
+	}
+
+	if p.MaxViews > 0 {
+		columns = append(columns, "max_views")
+		values = append(values, p.MaxViews)
+	}
+
+	if p.Visibility != "" {
+		columns = append(columns, "visibility")
+		values = append(values, p.Visibility)
+	}
+
+	if !p.Valid {
+		columns = append(columns, "valid")
+		values = append(values, p.Valid)
+	}
+
 	sql, args, err := query.
 		Columns(columns...).
 		Values(values...).
-		Suffix("RETURNING created_at, expires_at").
+		Suffix("RETURNING created_at, updated_at, expires_at, revision").
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("PastesRepo.CreatePaste.Builder: %w", err)
 	}
 
+	var expiresAt *time.Time
+
 	err = r.pg.Pool.
 		QueryRow(ctx, sql, args...).
-		Scan(&p.CreatedAt, &p.ExpiresAt)
+		Scan(&p.CreatedAt, &p.UpdatedAt, &expiresAt, &p.Revision)
 	if err != nil {
 		return fmt.Errorf("PastesRepo.CreatePaste.Pool.Begin: %w", err)
 	}
 
+	p.ExpiresAt = fromNullTime(expiresAt)
+
 	return nil
 }
 
-// UpdatePaste implements usecase.PastesRepo.
-func (*PastesRepo) Update(context.Context, *entity.Paste) error {
-	panic("unimplemented")
+// Burn deletes a burn after read paste metadata from database.
+//
+// Only one of concurrent calls deletes the row, the others get ErrRecordNotFound.
+func (r *PastesRepo) Burn(ctx context.Context, hash string) error {
+	sql, args, err := r.pg.Builder.
+		Delete("pastes").
+		Where(sq.Eq{"hash": hash, "burn_after_read": true}).
+		Suffix("RETURNING hash").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PastesRepo.Burn.Builder: %w", err)
+	}
+
+	err = r.pg.Pool.QueryRow(ctx, sql, args...).Scan(&hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return usecase.ErrRecordNotFound
+		}
+
+		return fmt.Errorf("PastesRepo.Burn.Pool.QueryRow: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteExpired deletes at most limit expired pastes metadata from database and
+// returns their hashes and owners. Rows locked by other transactions are skipped.
+func (r *PastesRepo) DeleteExpired(ctx context.Context, limit int) ([]*entity.Paste, error) {
+	expired := r.pg.Builder.
+		Select("hash").
+		From("pastes").
+		Where("expires_at < CURRENT_TIMESTAMP").
+		Limit(uint64(limit)).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, err := r.pg.Builder.
+		Delete("pastes").
+		Where(sq.Expr("hash IN (?)", expired)).
+		Suffix("RETURNING hash, user_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.DeleteExpired.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.DeleteExpired.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	pastes := make([]*entity.Paste, 0)
+
+	for rows.Next() {
+		p := new(entity.Paste)
+
+		if err = rows.Scan(&p.Hash, &p.UserID); err != nil {
+			return nil, fmt.Errorf("PastesRepo.DeleteExpired.Rows.Scan: %w", err)
+		}
+
+		pastes = append(pastes, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PastesRepo.DeleteExpired.Rows: %w", err)
+	}
+
+	return pastes, nil
+}
+
+// SetExpiration sets a new expiration date of a not expired paste,
+// zero date means the paste never expires. Returns ErrRecordNotFound
+// if the paste does not exist or is already expired.
+func (r *PastesRepo) SetExpiration(ctx context.Context, hash string, expiresAt time.Time) error {
+	sql, args, err := r.pg.Builder.
+		Update("pastes").
+		Set("expires_at", nullTime(expiresAt)).
+		Where(sq.Eq{"hash": hash}).
+		Where(sq.Or{
+			sq.Eq{"expires_at": nil},
+			sq.Expr("expires_at > CURRENT_TIMESTAMP"),
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PastesRepo.SetExpiration.Builder: %w", err)
+	}
+
+	tag, err := r.pg.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("PastesRepo.SetExpiration.Pool.Exec: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return usecase.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// SetViews stores view counters of pastes in database.
+//
+// Counters never decrease, so an outdated counter does not overwrite a newer one.
+func (r *PastesRepo) SetViews(ctx context.Context, views map[string]int) error {
+	batch := new(pgx.Batch)
+
+	for hash, n := range views {
+		sql, args, err := r.pg.Builder.
+			Update("pastes").
+			Set("views", sq.Expr("GREATEST(views, ?)", n)).
+			Where(sq.Eq{"hash": hash}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("PastesRepo.SetViews.Builder: %w", err)
+		}
+
+		batch.Queue(sql, args...)
+	}
+
+	if err := r.pg.Pool.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("PastesRepo.SetViews.Pool.SendBatch: %w", err)
+	}
+
+	return nil
+}
+
+// Update locks the paste row with SELECT ... FOR UPDATE and passes the locked
+// paste to update. If update succeeds, the previous state of the paste is stored
+// in paste_revisions and the changed paste is written in the same transaction,
+// so concurrent updates can not take the same revision number.
+// An error returned by update rolls the transaction back and is returned as is.
+func (r *PastesRepo) Update(ctx context.Context, hash string, update func(p *entity.Paste) error) error {
+	sql, args, err := pasteQuery(r.pg.Builder, hash).Suffix("FOR UPDATE").ToSql()
+	if err != nil {
+		return fmt.Errorf("PastesRepo.UpdatePaste.Builder: %w", err)
+	}
+
+	var errUpdate error
+
+	err = r.pg.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		p, err := scanPaste(tx.QueryRow(ctx, sql, args...))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return usecase.ErrRecordNotFound
+			}
+
+			return err
+		}
+
+		rev := p.Revisioned()
+
+		if errUpdate = update(p); errUpdate != nil {
+			return errUpdate
+		}
+
+		revSQL, revArgs, err := r.pg.Builder.
+			Insert("paste_revisions").
+			Columns(revisionColumns...).
+			Values(rev.Hash, rev.Revision, rev.Title, rev.Format, rev.CreatedAt, rev.HasFiles).
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		if _, err = tx.Exec(ctx, revSQL, revArgs...); err != nil {
+			return err
+		}
+
+		updSQL, updArgs, err := r.pg.Builder.
+			Update("pastes").
+			Set("title", p.Title).
+			Set("format", p.Format).
+			Set("format_confidence", p.FormatConfidence).
+			Set("password_hash", p.Password.Hash).
+			Set("expires_at", nullTime(p.ExpiresAt)).
+			Set("revision", p.Revision).
+			Set("valid", p.Valid).
+			Set("visibility", p.Visibility).
+			Set("schema", p.Schema).
+			Set("updated_at", sq.Expr("CURRENT_TIMESTAMP")).
+			Where(sq.Eq{"hash": p.Hash}).
+			Suffix("RETURNING updated_at").
+			ToSql()
+		if err != nil {
+			return err
+		}
+
+		return tx.QueryRow(ctx, updSQL, updArgs...).Scan(&p.UpdatedAt)
+	})
+	if err != nil {
+		if errUpdate != nil || errors.Is(err, usecase.ErrRecordNotFound) {
+			return err
+		}
+
+		return fmt.Errorf("PastesRepo.UpdatePaste.Pool.BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// ListForks returns metadata of pastes forked from the paste ordered by creation date.
+func (r *PastesRepo) ListForks(ctx context.Context, hash string) ([]*entity.Paste, error) {
+	sql, args, err := r.pg.Builder.
+		Select("hash", "user_id", "title", "format", "expires_at", "created_at", "updated_at", "visibility").
+		From("pastes").
+		Where(sq.Eq{"forked_from": hash}).
+		OrderBy("created_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListForks.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListForks.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	forks := make([]*entity.Paste, 0)
+
+	for rows.Next() {
+		var (
+			p         = new(entity.Paste)
+			expiresAt *time.Time
+		)
+
+		p.ForkedFrom.String, p.ForkedFrom.Valid = hash, true
+
+		err = rows.Scan(&p.Hash, &p.UserID, &p.Title, &p.Format, &expiresAt, &p.CreatedAt, &p.UpdatedAt, &p.Visibility)
+		if err != nil {
+			return nil, fmt.Errorf("PastesRepo.ListForks.Rows.Scan: %w", err)
+		}
+
+		p.ExpiresAt = fromNullTime(expiresAt)
+
+		forks = append(forks, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListForks.Rows: %w", err)
+	}
+
+	return forks, nil
+}
+
+// feedExcerptLen is a max number of characters of excerpts of feed entries.
+const feedExcerptLen = 280
+
+// ListFeed returns recent public pastes of the user, or of all users if userID is empty,
+// with their authors and excerpts, newest first. Pastes with password or views limits
+// are not listed, as their excerpts would be read bypassing them.
+func (r *PastesRepo) ListFeed(ctx context.Context, userID string, limit int) ([]*entity.FeedEntry, error) {
+	query := r.pg.Builder.
+		Select("p.hash", "p.user_id", "p.title", "p.format", "p.expires_at", "p.created_at", "p.updated_at").
+		Column("COALESCE(u.username, '')").
+		Column(sq.Expr("COALESCE(LEFT(s.content, ?), '')", feedExcerptLen)).
+		From("pastes p").
+		LeftJoin("users u ON u.id = p.user_id").
+		LeftJoin("paste_search s ON s.paste_hash = p.hash").
+		Where(sq.Eq{
+			"p.visibility":      entity.VisibilityPublic,
+			"p.password_hash":   nil,
+			"p.burn_after_read": false,
+			"p.max_views":       0,
+		}).
+		Where(sq.Or{sq.Eq{"p.expires_at": nil}, sq.Expr("p.expires_at > CURRENT_TIMESTAMP")}).
+		OrderBy("p.created_at DESC", "p.hash DESC").
+		Limit(uint64(limit))
+
+	if userID != "" {
+		query = query.Where(sq.Eq{"p.user_id": userID})
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListFeed.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListFeed.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	entries := make([]*entity.FeedEntry, 0, limit)
+
+	for rows.Next() {
+		var (
+			e         = &entity.FeedEntry{Paste: new(entity.Paste)}
+			p         = e.Paste
+			expiresAt *time.Time
+		)
+
+		err = rows.Scan(&p.Hash, &p.UserID, &p.Title, &p.Format, &expiresAt, &p.CreatedAt, &p.UpdatedAt, &e.Author, &e.Excerpt)
+		if err != nil {
+			return nil, fmt.Errorf("PastesRepo.ListFeed.Rows.Scan: %w", err)
+		}
+
+		p.ExpiresAt = fromNullTime(expiresAt)
+		p.Visibility = entity.VisibilityPublic
+
+		entries = append(entries, e)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListFeed.Rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+// pasteSortKeys are sort expressions and their types by sort fields.
+// Never expiring pastes have no expiration date, so they are sorted as expiring at infinity.
+var pasteSortKeys = map[entity.PasteSort][2]string{
+	entity.SortCreated: {"created_at", "timestamptz"},
+	entity.SortExpires: {"COALESCE(expires_at, 'infinity')", "timestamptz"},
+	entity.SortTitle:   {"title", "text"},
+}
+
+// ListByUser returns metadata of pastes of the user ordered by the sort field and hash.
+func (r *PastesRepo) ListByUser(ctx context.Context, q entity.PasteListQuery) ([]*entity.Paste, error) {
+	key, ok := pasteSortKeys[q.Sort]
+	if !ok {
+		key = pasteSortKeys[entity.SortCreated]
+	}
+
+	order, cmp := "ASC", ">"
+	if q.Desc {
+		order, cmp = "DESC", "<"
+	}
+
+	query := r.pg.Builder.
+		Select(
+			"hash", "user_id", "title", "format", "password_hash", "expires_at", "created_at", "updated_at",
+			"burn_after_read", "views", "max_views", "visibility", tagsColumn,
+		).
+		From("pastes").
+		Where(sq.Eq{"user_id": q.UserID}).
+		OrderBy(key[0]+" "+order, "hash "+order).
+		Limit(uint64(q.Limit))
+
+	if q.Format != "" {
+		query = query.Where(sq.Eq{"format": q.Format})
+	}
+
+	if q.Expired != nil {
+		if *q.Expired {
+			query = query.Where("expires_at < CURRENT_TIMESTAMP")
+		} else {
+			query = query.Where(sq.Or{sq.Eq{"expires_at": nil}, sq.Expr("expires_at >= CURRENT_TIMESTAMP")})
+		}
+	}
+
+	if q.Locked != nil {
+		if *q.Locked {
+			query = query.Where(sq.NotEq{"password_hash": nil})
+		} else {
+			query = query.Where(sq.Eq{"password_hash": nil})
+		}
+	}
+
+	if len(q.Tags) > 0 {
+		query = query.Where(taggedWith(q.Tags, q.AnyTag))
+	}
+
+	if q.After != nil {
+		query = query.Where(fmt.Sprintf("(%s, hash) %s (?::%s, ?)", key[0], cmp, key[1]), q.After.Key, q.After.Hash)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListByUser.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListByUser.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	pastes := make([]*entity.Paste, 0, q.Limit)
+
+	for rows.Next() {
+		var (
+			p         = new(entity.Paste)
+			expiresAt *time.Time
+		)
+
+		err = rows.Scan(
+			&p.Hash, &p.UserID, &p.Title, &p.Format, &p.Password.Hash, &expiresAt, &p.CreatedAt, &p.UpdatedAt,
+			&p.BurnAfterRead, &p.Views, &p.MaxViews, &p.Visibility, &p.Tags,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("PastesRepo.ListByUser.Rows.Scan: %w", err)
+		}
+
+		p.ExpiresAt = fromNullTime(expiresAt)
+
+		pastes = append(pastes, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListByUser.Rows: %w", err)
+	}
+
+	return pastes, nil
+}
+
+// ListByTags returns metadata of not expired pastes visible to the user with the tags
+// ordered by creation date and hash from newest to oldest.
+func (r *PastesRepo) ListByTags(ctx context.Context, q entity.TagQuery) ([]*entity.Paste, error) {
+	query := r.pg.Builder.
+		Select("hash", "user_id", "title", "format", "expires_at", "created_at", "updated_at", tagsColumn).
+		From("pastes").
+		Where(visibleTo(q.UserID)).
+		Where(sq.Or{sq.Eq{"expires_at": nil}, sq.Expr("expires_at >= CURRENT_TIMESTAMP")}).
+		OrderBy("created_at DESC", "hash DESC").
+		Limit(uint64(q.Limit))
+
+	if len(q.Tags) > 0 {
+		query = query.Where(taggedWith(q.Tags, q.Any))
+	}
+
+	if q.After != nil {
+		query = query.Where("(created_at, hash) < (?::timestamptz, ?)", q.After.Key, q.After.Hash)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListByTags.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListByTags.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	pastes := make([]*entity.Paste, 0, q.Limit)
+
+	for rows.Next() {
+		var (
+			p         = new(entity.Paste)
+			expiresAt *time.Time
+		)
+
+		err = rows.Scan(&p.Hash, &p.UserID, &p.Title, &p.Format, &expiresAt, &p.CreatedAt, &p.UpdatedAt, &p.Tags)
+		if err != nil {
+			return nil, fmt.Errorf("PastesRepo.ListByTags.Rows.Scan: %w", err)
+		}
+
+		p.ExpiresAt = fromNullTime(expiresAt)
+
+		pastes = append(pastes, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PastesRepo.ListByTags.Rows: %w", err)
+	}
+
+	return pastes, nil
 }
@@ -0,0 +1,97 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.PasteRevisionsRepo = &PasteRevisionsRepo{}
+
+var revisionColumns = []string{
+	"paste_hash",
+	"revision",
+	"title",
+	"format",
+	"created_at",
+	"files",
+}
+
+type PasteRevisionsRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewPasteRevisionsRepository(pg *postgres.Postgres) *PasteRevisionsRepo {
+	return &PasteRevisionsRepo{pg: pg}
+}
+
+// Get returns a paste revision metadata by paste hash and revision number.
+func (r *PasteRevisionsRepo) Get(ctx context.Context, hash string, revision int) (*entity.PasteRevision, error) {
+	sql, args, err := r.pg.Builder.
+		Select(revisionColumns...).
+		From("paste_revisions").
+		Where(sq.Eq{"paste_hash": hash, "revision": revision}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteRevisionsRepo.Get.Builder: %w", err)
+	}
+
+	rev := new(entity.PasteRevision)
+
+	err = r.pg.Pool.
+		QueryRow(ctx, sql, args...).
+		Scan(&rev.Hash, &rev.Revision, &rev.Title, &rev.Format, &rev.CreatedAt, &rev.HasFiles)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecase.ErrRecordNotFound
+		}
+
+		return nil, fmt.Errorf("PasteRevisionsRepo.Get.Pool.QueryRow: %w", err)
+	}
+
+	return rev, nil
+}
+
+// List returns all revisions metadata of a paste ordered by revision number.
+func (r *PasteRevisionsRepo) List(ctx context.Context, hash string) ([]*entity.PasteRevision, error) {
+	sql, args, err := r.pg.Builder.
+		Select(revisionColumns...).
+		From("paste_revisions").
+		Where(sq.Eq{"paste_hash": hash}).
+		OrderBy("revision").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteRevisionsRepo.List.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteRevisionsRepo.List.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	revs := make([]*entity.PasteRevision, 0)
+
+	for rows.Next() {
+		rev := new(entity.PasteRevision)
+
+		err = rows.Scan(&rev.Hash, &rev.Revision, &rev.Title, &rev.Format, &rev.CreatedAt, &rev.HasFiles)
+		if err != nil {
+			return nil, fmt.Errorf("PasteRevisionsRepo.List.Rows.Scan: %w", err)
+		}
+
+		revs = append(revs, rev)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteRevisionsRepo.List.Rows: %w", err)
+	}
+
+	return revs, nil
+}
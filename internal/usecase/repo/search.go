@@ -0,0 +1,253 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.PasteSearchRepo = &PasteSearchRepo{}
+
+// Markers of matches in snippets. Private use characters may occur in user texts,
+// so they are replaced in indexed contents by markerEscaper.
+const (
+	snippetStart = ""
+	snippetStop  = ""
+)
+
+// markerEscaper replaces snippet markers in contents with the replacement character,
+// so only markers added by ts_headline are found in snippets.
+var markerEscaper = strings.NewReplacer(snippetStart, "\ufffd", snippetStop, "\ufffd")
+
+// snippetOptions are options of ts_headline for snippets.
+const snippetOptions = "StartSel=" + snippetStart + ", StopSel=" + snippetStop +
+	`, MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" … "`
+
+// searchQuery is a parsed web search query.
+const searchQuery = "websearch_to_tsquery('simple', ?)"
+
+type PasteSearchRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewPasteSearchRepository(pg *postgres.Postgres) *PasteSearchRepo {
+	return &PasteSearchRepo{pg: pg}
+}
+
+// Index inserts or replaces the search document of a paste.
+// Title words rank higher than words of the content. Snippet markers
+// in the stored content are replaced, the document keeps the content as is.
+func (r *PasteSearchRepo) Index(ctx context.Context, hash, title, content string) error {
+	sql, args, err := r.pg.Builder.
+		Insert("paste_search").
+		Columns("paste_hash", "content", "document").
+		Values(
+			hash,
+			markerEscaper.Replace(content),
+			sq.Expr("setweight(to_tsvector('simple', ?), 'A') || setweight(to_tsvector('simple', ?), 'B')", title, content),
+		).
+		Suffix("ON CONFLICT (paste_hash) DO UPDATE SET content = EXCLUDED.content, document = EXCLUDED.document").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteSearchRepo.Index.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("PasteSearchRepo.Index.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the search document of a paste.
+func (r *PasteSearchRepo) Delete(ctx context.Context, hash string) error {
+	sql, args, err := r.pg.Builder.
+		Delete("paste_search").
+		Where(sq.Eq{"paste_hash": hash}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteSearchRepo.Delete.Builder: %w", err)
+	}
+
+	if _, err = r.pg.Pool.Exec(ctx, sql, args...); err != nil {
+		return fmt.Errorf("PasteSearchRepo.Delete.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// Missing returns hashes of searchable pastes that are not indexed, ordered by hash
+// and starting after the given hash. Searchable pastes are not expired and have
+// no password and views limits.
+func (r *PasteSearchRepo) Missing(ctx context.Context, after string, limit int) ([]string, error) {
+	sql, args, err := r.pg.Builder.
+		Select("hash").
+		From("pastes").
+		Where("NOT EXISTS (SELECT 1 FROM paste_search s WHERE s.paste_hash = pastes.hash)").
+		Where("password_hash IS NULL").
+		Where("NOT burn_after_read").
+		Where("max_views = 0").
+		Where("(expires_at IS NULL OR expires_at > now())").
+		Where(sq.Gt{"hash": after}).
+		OrderBy("hash").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteSearchRepo.Missing.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteSearchRepo.Missing.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	hashes := make([]string, 0, limit)
+
+	for rows.Next() {
+		var hash string
+
+		if err = rows.Scan(&hash); err != nil {
+			return nil, fmt.Errorf("PasteSearchRepo.Missing.Rows.Scan: %w", err)
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteSearchRepo.Missing.Rows: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// Search returns pastes matching the query ordered by rank and hash.
+//
+// Pastes match by words of titles and contents or by titles similar to the query.
+// Only pastes without password and views limits that are not expired are returned.
+// Unlisted and private pastes are returned only to their authors.
+// Snippets are made only for the page, as they are expensive.
+func (r *PasteSearchRepo) Search(ctx context.Context, q entity.SearchQuery) ([]*entity.SearchResult, error) {
+	ranked := r.pg.Builder.
+		Select("p.hash", "p.user_id", "p.title", "p.format", "p.expires_at", "p.created_at", "p.updated_at", "s.content").
+		Column(sq.Expr("(ts_rank_cd(s.document, "+searchQuery+") + similarity(p.title, ?))::real AS rank", q.Query, q.Query)).
+		From("paste_search s").
+		Join("pastes p ON p.hash = s.paste_hash").
+		Where(sq.Expr("(s.document @@ "+searchQuery+" OR p.title % ?)", q.Query, q.Query)).
+		Where("p.password_hash IS NULL").
+		Where("NOT p.burn_after_read").
+		Where("p.max_views = 0").
+		Where("(p.expires_at IS NULL OR p.expires_at > now())")
+
+	if q.UserID != "" {
+		ranked = ranked.Where(sq.Or{sq.Eq{"p.visibility": entity.VisibilityPublic}, sq.Eq{"p.user_id": q.UserID}})
+	} else {
+		ranked = ranked.Where(sq.Eq{"p.visibility": entity.VisibilityPublic})
+	}
+
+	if q.Format != "" {
+		ranked = ranked.Where(sq.Eq{"p.format": q.Format})
+	}
+
+	if q.Owner != "" {
+		ranked = ranked.Where("p.user_id = (SELECT id FROM users WHERE username = ?)", q.Owner)
+	}
+
+	if !q.From.IsZero() {
+		ranked = ranked.Where(sq.GtOrEq{"p.created_at": q.From})
+	}
+
+	if !q.To.IsZero() {
+		ranked = ranked.Where(sq.Lt{"p.created_at": q.To})
+	}
+
+	page := r.pg.Builder.
+		Select("*").
+		FromSelect(ranked, "ranked").
+		OrderBy("rank DESC", "hash").
+		Limit(uint64(q.Limit))
+
+	if q.After != nil {
+		page = page.Where("(rank < ? OR rank = ? AND hash > ?)", q.After.Rank, q.After.Rank, q.After.Hash)
+	}
+
+	sql, args, err := r.pg.Builder.
+		Select("hash", "user_id", "title", "format", "expires_at", "created_at", "updated_at", "rank").
+		Column(sq.Expr("ts_headline('simple', content, "+searchQuery+", ?)", q.Query, snippetOptions)).
+		FromSelect(page, "page").
+		OrderBy("rank DESC", "hash").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteSearchRepo.Search.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteSearchRepo.Search.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]*entity.SearchResult, 0, q.Limit)
+
+	for rows.Next() {
+		var (
+			res       = &entity.SearchResult{Paste: new(entity.Paste)}
+			p         = res.Paste
+			expiresAt *time.Time
+			snippet   string
+		)
+
+		err = rows.Scan(&p.Hash, &p.UserID, &p.Title, &p.Format, &expiresAt, &p.CreatedAt, &p.UpdatedAt, &res.Rank, &snippet)
+		if err != nil {
+			return nil, fmt.Errorf("PasteSearchRepo.Search.Rows.Scan: %w", err)
+		}
+
+		p.ExpiresAt = fromNullTime(expiresAt)
+		res.Snippet, res.Highlights = highlights(snippet)
+
+		results = append(results, res)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteSearchRepo.Search.Rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// highlights removes match markers from the snippet and returns rune offsets of matches.
+func highlights(snippet string) (string, [][2]int) {
+	var (
+		b      strings.Builder
+		ranges = make([][2]int, 0)
+		pos    int
+	)
+
+	for _, part := range strings.SplitAfter(snippet, snippetStop) {
+		before, match, ok := strings.Cut(part, snippetStart)
+
+		b.WriteString(before)
+		pos += utf8.RuneCountInString(before)
+
+		if !ok {
+			continue
+		}
+
+		match = strings.TrimSuffix(match, snippetStop)
+		start := pos
+
+		b.WriteString(match)
+		pos += utf8.RuneCountInString(match)
+
+		ranges = append(ranges, [2]int{start, pos})
+	}
+
+	return b.String(), ranges
+}
@@ -0,0 +1,159 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.PasteStarsRepo = &PasteStarsRepo{}
+
+type PasteStarsRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewPasteStarsRepository(pg *postgres.Postgres) *PasteStarsRepo {
+	return &PasteStarsRepo{pg: pg}
+}
+
+// Create stars a paste by the user, returns false if the paste is already starred.
+func (r *PasteStarsRepo) Create(ctx context.Context, hash, userID string) (bool, error) {
+	sql, args, err := r.pg.Builder.
+		Insert("paste_stars").
+		Columns("paste_hash", "user_id").
+		Values(hash, userID).
+		Suffix("ON CONFLICT (paste_hash, user_id) DO NOTHING").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("PasteStarsRepo.Create.Builder: %w", err)
+	}
+
+	tag, err := r.pg.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("PasteStarsRepo.Create.Pool.Exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// Delete unstars a paste by the user, returns false if the paste is not starred.
+func (r *PasteStarsRepo) Delete(ctx context.Context, hash, userID string) (bool, error) {
+	sql, args, err := r.pg.Builder.
+		Delete("paste_stars").
+		Where(sq.Eq{"paste_hash": hash, "user_id": userID}).
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("PasteStarsRepo.Delete.Builder: %w", err)
+	}
+
+	tag, err := r.pg.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return false, fmt.Errorf("PasteStarsRepo.Delete.Pool.Exec: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// ListByUser returns pastes starred by the user, most recently starred first.
+// Expired pastes and private pastes the user can no longer read are skipped.
+func (r *PasteStarsRepo) ListByUser(ctx context.Context, q entity.StarListQuery) ([]*entity.StarredPaste, error) {
+	query := r.pg.Builder.
+		Select(
+			"pastes.hash", "pastes.user_id", "pastes.title", "pastes.format", "pastes.expires_at",
+			"pastes.created_at", "pastes.updated_at", "pastes.stars", tagsColumn, "s.created_at",
+		).
+		From("paste_stars s").
+		Join("pastes ON pastes.hash = s.paste_hash").
+		Where(sq.Eq{"s.user_id": q.UserID}).
+		Where(sq.Or{visibleTo(q.UserID), sq.Eq{"pastes.visibility": entity.VisibilityUnlisted}}).
+		Where(sq.Or{sq.Eq{"pastes.expires_at": nil}, sq.Expr("pastes.expires_at >= CURRENT_TIMESTAMP")}).
+		OrderBy("s.created_at DESC", "s.paste_hash DESC").
+		Limit(uint64(q.Limit))
+
+	if q.After != nil {
+		query = query.Where("(s.created_at, s.paste_hash) < (?::timestamptz, ?)", q.After.Key, q.After.Hash)
+	}
+
+	sql, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteStarsRepo.ListByUser.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteStarsRepo.ListByUser.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	starred := make([]*entity.StarredPaste, 0, q.Limit)
+
+	for rows.Next() {
+		var (
+			s         = &entity.StarredPaste{Paste: new(entity.Paste)}
+			p         = s.Paste
+			expiresAt *time.Time
+		)
+
+		err = rows.Scan(&p.Hash, &p.UserID, &p.Title, &p.Format, &expiresAt, &p.CreatedAt, &p.UpdatedAt, &p.Stars, &p.Tags, &s.StarredAt)
+		if err != nil {
+			return nil, fmt.Errorf("PasteStarsRepo.ListByUser.Rows.Scan: %w", err)
+		}
+
+		p.ExpiresAt = fromNullTime(expiresAt)
+
+		starred = append(starred, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteStarsRepo.ListByUser.Rows: %w", err)
+	}
+
+	return starred, nil
+}
+
+// Reconcile recounts stars of the pastes from stars of users, stores the counts
+// with the pastes and returns them by paste hash.
+// Deleted pastes are missing from the result.
+func (r *PasteStarsRepo) Reconcile(ctx context.Context, hashes []string) (map[string]int, error) {
+	sql, args, err := r.pg.Builder.
+		Update("pastes").
+		Set("stars", sq.Expr("(SELECT count(*) FROM paste_stars s WHERE s.paste_hash = pastes.hash)")).
+		Where("hash = ANY(?::varchar[])", hashes).
+		Suffix("RETURNING hash, stars").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteStarsRepo.Reconcile.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteStarsRepo.Reconcile.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	stars := make(map[string]int, len(hashes))
+
+	for rows.Next() {
+		var (
+			hash string
+			n    int
+		)
+
+		if err = rows.Scan(&hash, &n); err != nil {
+			return nil, fmt.Errorf("PasteStarsRepo.Reconcile.Rows.Scan: %w", err)
+		}
+
+		stars[hash] = n
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteStarsRepo.Reconcile.Rows: %w", err)
+	}
+
+	return stars, nil
+}
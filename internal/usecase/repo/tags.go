@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase"
+	"github.com/romankravchuk/pastebin/pkg/postgres"
+)
+
+var _ usecase.PasteTagsRepo = &PasteTagsRepo{}
+
+// tagsColumn aggregates tags of the selected paste ordered by name.
+const tagsColumn = "ARRAY(SELECT t.tag FROM paste_tags t WHERE t.paste_hash = pastes.hash ORDER BY t.tag) AS tags"
+
+// maxTagCounts is a max number of tags returned by Counts.
+const maxTagCounts = 100
+
+type PasteTagsRepo struct {
+	pg *postgres.Postgres
+}
+
+func NewPasteTagsRepository(pg *postgres.Postgres) *PasteTagsRepo {
+	return &PasteTagsRepo{pg: pg}
+}
+
+// Set replaces tags of a paste, empty tags remove all tags of the paste.
+// Tags are replaced in a transaction, so concurrent readers see either old or new tags.
+func (r *PasteTagsRepo) Set(ctx context.Context, hash string, tags []string) error {
+	del, delArgs, err := r.pg.Builder.
+		Delete("paste_tags").
+		Where(sq.Eq{"paste_hash": hash}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("PasteTagsRepo.Set.Builder: %w", err)
+	}
+
+	var (
+		ins     string
+		insArgs []any
+	)
+
+	if len(tags) > 0 {
+		insert := r.pg.Builder.
+			Insert("paste_tags").
+			Columns("paste_hash", "tag")
+
+		for _, tag := range tags {
+			insert = insert.Values(hash, tag)
+		}
+
+		if ins, insArgs, err = insert.ToSql(); err != nil {
+			return fmt.Errorf("PasteTagsRepo.Set.Builder: %w", err)
+		}
+	}
+
+	err = r.pg.Pool.BeginFunc(ctx, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, del, delArgs...); err != nil {
+			return err
+		}
+
+		if ins == "" {
+			return nil
+		}
+
+		_, err := tx.Exec(ctx, ins, insArgs...)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("PasteTagsRepo.Set.Pool.BeginFunc: %w", err)
+	}
+
+	return nil
+}
+
+// Counts returns the most used tags of not expired pastes visible to the user
+// ordered by number of pastes and name. Empty userID counts only public pastes.
+func (r *PasteTagsRepo) Counts(ctx context.Context, userID string) ([]*entity.TagCount, error) {
+	sql, args, err := r.pg.Builder.
+		Select("t.tag", "count(*)").
+		From("paste_tags t").
+		Join("pastes ON pastes.hash = t.paste_hash").
+		Where(visibleTo(userID)).
+		Where(sq.Or{sq.Eq{"pastes.expires_at": nil}, sq.Expr("pastes.expires_at >= CURRENT_TIMESTAMP")}).
+		GroupBy("t.tag").
+		OrderBy("count(*) DESC", "t.tag").
+		Limit(maxTagCounts).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("PasteTagsRepo.Counts.Builder: %w", err)
+	}
+
+	rows, err := r.pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("PasteTagsRepo.Counts.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make([]*entity.TagCount, 0)
+
+	for rows.Next() {
+		c := new(entity.TagCount)
+		if err = rows.Scan(&c.Tag, &c.Count); err != nil {
+			return nil, fmt.Errorf("PasteTagsRepo.Counts.Rows.Scan: %w", err)
+		}
+
+		counts = append(counts, c)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("PasteTagsRepo.Counts.Rows: %w", err)
+	}
+
+	return counts, nil
+}
+
+// visibleTo matches pastes the user can read: public pastes, pastes of the user
+// and private pastes the user is granted access to. Empty userID matches only public pastes.
+func visibleTo(userID string) sq.Sqlizer {
+	public := sq.Eq{"pastes.visibility": entity.VisibilityPublic}
+	if userID == "" {
+		return public
+	}
+
+	return sq.Or{
+		public,
+		sq.Eq{"pastes.user_id": userID},
+		sq.Expr("EXISTS (SELECT 1 FROM paste_grants g WHERE g.paste_hash = pastes.hash AND g.user_id = ?)", userID),
+	}
+}
+
+// taggedWith matches pastes with all the tags, or with any of them if anyTag is true.
+// Tags must be unique.
+func taggedWith(tags []string, anyTag bool) sq.Sqlizer {
+	if anyTag {
+		return sq.Expr("EXISTS (SELECT 1 FROM paste_tags t WHERE t.paste_hash = pastes.hash AND t.tag = ANY(?::varchar[]))", tags)
+	}
+
+	return sq.Expr("(SELECT count(*) FROM paste_tags t WHERE t.paste_hash = pastes.hash AND t.tag = ANY(?::varchar[])) = ?", tags, len(tags))
+}
@@ -2,8 +2,11 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v4"
 	"github.com/romankravchuk/pastebin/internal/entity"
 	"github.com/romankravchuk/pastebin/internal/usecase"
 	"github.com/romankravchuk/pastebin/pkg/postgres"
@@ -48,6 +51,38 @@ func (r *UsersRepo) GetByEmail(ctx context.Context, email string) (*entity.User,
 	return user, nil
 }
 
+// GetByUsername returns a user by name, if the user does not exist returns usecase.ErrRecordNotFound.
+func (r *UsersRepo) GetByUsername(ctx context.Context, username string) (*entity.User, error) {
+	sql, args, err := r.Builder.
+		Select("id", "email", "username", "avatar").
+		From("users").
+		Where(sq.Eq{"username": username}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("UsersRepo.GetByUsername.Builder: %w", err)
+	}
+
+	user := &entity.User{}
+
+	err = r.Pool.
+		QueryRow(ctx, sql, args...).
+		Scan(
+			&user.ID,
+			&user.Email,
+			&user.Username,
+			&user.Avatar,
+		)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, usecase.ErrRecordNotFound
+		}
+
+		return nil, fmt.Errorf("UsersRepo.GetByUsername.Pool: %w", err)
+	}
+
+	return user, nil
+}
+
 // Create godoc.
 func (r *UsersRepo) Create(ctx context.Context, u *entity.User) error {
 	sql, args, err := r.Builder.
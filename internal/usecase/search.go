@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+const (
+	// indexBatch is a max number of pastes indexed for search at once by IndexMissing.
+	indexBatch = 100
+	// indexLock is a lock allowing only one instance to index missing pastes.
+	indexLock    = "pastes:index"
+	indexLockTTL = 10 * time.Minute
+)
+
+var _ Search = (*SearchUseCase)(nil)
+
+type SearchUseCase struct {
+	repo   PasteSearchRepo
+	pastes PastesRepo
+	objs   PastesBlobStorage
+	lock   Locker
+}
+
+func NewSearch(r PasteSearchRepo, p PastesRepo, o PastesBlobStorage, lk Locker) *SearchUseCase {
+	return &SearchUseCase{
+		repo:   r,
+		pastes: p,
+		objs:   o,
+		lock:   lk,
+	}
+}
+
+// Search returns a page of pastes matching the query, ordered by relevance.
+//
+// Pastes locked with password, burn after read pastes and pastes with views limits
+// are never indexed, so they are not found. Only public pastes are found, and
+// unlisted and private pastes of the user from context. The next page cursor
+// is set only if there are more results.
+func (uc *SearchUseCase) Search(ctx context.Context, q entity.SearchQuery) (*entity.SearchPage, error) {
+	q.UserID, _ = ctx.Value(entity.UserIDKey).(string)
+
+	results, next, err := listPage(q.Limit, func(limit int) ([]*entity.SearchResult, error) {
+		q.Limit = limit
+
+		return uc.repo.Search(ctx, q)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SearchUseCase.Search: %w", err)
+	}
+
+	page := &entity.SearchPage{Results: results}
+
+	if next {
+		last := results[len(results)-1]
+		page.Next = &entity.SearchCursor{Rank: last.Rank, Hash: last.Paste.Hash}
+	}
+
+	return page, nil
+}
+
+// IndexMissing indexes searchable pastes that are missing from the search index
+// in batches, so pastes created before search was added become searchable.
+// Pastes that fail to index are skipped until the next run.
+//
+// Only one instance of the service indexes pastes at a time,
+// if another instance holds the lock IndexMissing does nothing.
+func (uc *SearchUseCase) IndexMissing(ctx context.Context) error {
+	ok, err := uc.lock.Acquire(ctx, indexLock, indexLockTTL)
+	if err != nil {
+		return fmt.Errorf("SearchUseCase.IndexMissing: %w", err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	defer func() {
+		// The lock expires by itself, so an error only delays the next run.
+		_ = uc.lock.Release(context.WithoutCancel(ctx), indexLock)
+	}()
+
+	var (
+		errs  []error
+		after string
+	)
+
+	for {
+		hashes, err := uc.repo.Missing(ctx, after, indexBatch)
+		if err != nil {
+			return fmt.Errorf("SearchUseCase.IndexMissing: %w", err)
+		}
+
+		for _, hash := range hashes {
+			if err := uc.indexMissing(ctx, hash); err != nil {
+				errs = append(errs, err)
+			}
+		}
+
+		if len(hashes) < indexBatch {
+			break
+		}
+
+		after = hashes[len(hashes)-1]
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("SearchUseCase.IndexMissing: %w", err)
+	}
+
+	return nil
+}
+
+// indexMissing reads the paste with texts of all its files and indexes it.
+// Pastes deleted since they were listed are skipped.
+func (uc *SearchUseCase) indexMissing(ctx context.Context, hash string) error {
+	paste, err := uc.pastes.Get(ctx, hash)
+	if err != nil {
+		if errors.Is(err, ErrRecordNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	paste.File, err = uc.objs.Get(ctx, paste.UserID.String, paste.Hash)
+	if err != nil {
+		return err
+	}
+
+	return indexPaste(ctx, uc.repo, uc.objs, paste)
+}
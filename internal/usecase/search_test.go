@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type searchMocks struct {
+	repo   *mocks.PasteSearchRepo
+	pastes *mocks.PastesRepo
+	blob   *mocks.PastesBlobStorage
+	lock   *mocks.Locker
+}
+
+func newSearchUseCase(t *testing.T) (*SearchUseCase, *searchMocks) {
+	t.Helper()
+
+	m := &searchMocks{
+		repo:   mocks.NewPasteSearchRepo(t),
+		pastes: mocks.NewPastesRepo(t),
+		blob:   mocks.NewPastesBlobStorage(t),
+		lock:   mocks.NewLocker(t),
+	}
+
+	return NewSearch(m.repo, m.pastes, m.blob, m.lock), m
+}
+
+func TestSearchUseCase_Search(t *testing.T) {
+	t.Parallel()
+
+	results := []*entity.SearchResult{
+		{Paste: &entity.Paste{Hash: "first"}, Rank: 0.5, Snippet: "image: nginx", Highlights: [][2]int{{7, 12}}},
+		{Paste: &entity.Paste{Hash: "second"}, Rank: 0.2},
+		{Paste: &entity.Paste{Hash: "third"}, Rank: 0.1},
+	}
+
+	t.Run("Search first page", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newSearchUseCase(t)
+			ctx   = context.Background()
+			q     = entity.SearchQuery{Query: "nginx", Format: "yaml", Limit: 2}
+		)
+
+		m.repo.On("Search", ctx, entity.SearchQuery{Query: "nginx", Format: "yaml", Limit: 3}).
+			Once().
+			Return(results, nil)
+
+		page, err := uc.Search(ctx, q)
+		require.NoError(t, err)
+		require.Equal(t, results[:2], page.Results)
+		require.Equal(t, &entity.SearchCursor{Rank: 0.2, Hash: "second"}, page.Next)
+	})
+
+	t.Run("Search last page", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newSearchUseCase(t)
+			ctx   = context.Background()
+			after = &entity.SearchCursor{Rank: 0.5, Hash: "first"}
+			q     = entity.SearchQuery{Query: "nginx", After: after, Limit: 2}
+		)
+
+		m.repo.On("Search", ctx, entity.SearchQuery{Query: "nginx", After: after, Limit: 3}).
+			Once().
+			Return(results[1:], nil)
+
+		page, err := uc.Search(ctx, q)
+		require.NoError(t, err)
+		require.Equal(t, results[1:], page.Results)
+		require.Nil(t, page.Next)
+	})
+
+	t.Run("Get error on search", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newSearchUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.repo.On("Search", ctx, mock.Anything).
+			Once().
+			Return(nil, errTest)
+
+		_, err := uc.Search(ctx, entity.SearchQuery{Query: "nginx", Limit: 2})
+		require.ErrorIs(t, err, errTest)
+	})
+
+	t.Run("Index missing pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newSearchUseCase(t)
+			ctx    = context.Background()
+			hashes = make([]string, indexBatch)
+		)
+
+		for i := range hashes {
+			hashes[i] = strconv.Itoa(i)
+		}
+
+		m.lock.On("Acquire", ctx, indexLock, indexLockTTL).
+			Once().
+			Return(true, nil)
+		m.repo.On("Missing", ctx, "", indexBatch).
+			Once().
+			Return(hashes, nil)
+		m.repo.On("Missing", ctx, hashes[indexBatch-1], indexBatch).
+			Once().
+			Return([]string{"gone"}, nil)
+		m.pastes.On("Get", ctx, mock.Anything).
+			Times(indexBatch).
+			Return(func(_ context.Context, hash string) (*entity.Paste, error) {
+				return &entity.Paste{Hash: hash, Title: "title"}, nil
+			})
+		m.pastes.On("Get", ctx, "gone").
+			Once().
+			Return(nil, ErrRecordNotFound)
+		m.blob.On("Get", ctx, "", mock.Anything).
+			Times(indexBatch).
+			Return(entity.File("text"), nil)
+		m.repo.On("Index", ctx, mock.Anything, "title", "text").
+			Times(indexBatch).
+			Return(nil)
+		m.lock.On("Release", mock.Anything, indexLock).
+			Once().
+			Return(nil)
+
+		err := uc.IndexMissing(ctx)
+		require.NoError(t, err)
+	})
+
+	t.Run("Index missing pastes after failed one", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newSearchUseCase(t)
+			ctx   = context.Background()
+		)
+
+		m.lock.On("Acquire", ctx, indexLock, indexLockTTL).
+			Once().
+			Return(true, nil)
+		m.repo.On("Missing", ctx, "", indexBatch).
+			Once().
+			Return([]string{"a", "b"}, nil)
+		m.pastes.On("Get", ctx, "a").
+			Once().
+			Return(nil, errTest)
+		m.pastes.On("Get", ctx, "b").
+			Once().
+			Return(&entity.Paste{Hash: "b"}, nil)
+		m.blob.On("Get", ctx, "", "b").
+			Once().
+			Return(entity.File("text"), nil)
+		m.repo.On("Index", ctx, "b", "", "text").
+			Once().
+			Return(nil)
+		m.lock.On("Release", mock.Anything, indexLock).
+			Once().
+			Return(nil)
+
+		err := uc.IndexMissing(ctx)
+		require.ErrorIs(t, err, errTest)
+	})
+}
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+// starsFlushBatch is a max number of star counters reconciled with database at once.
+const starsFlushBatch = 500
+
+var _ Stars = (*StarsUseCase)(nil)
+
+type StarsUseCase struct {
+	repo    PasteStarsRepo
+	counter PasteStarsCounter
+	access  PasteAccess
+}
+
+func NewStars(r PasteStarsRepo, c PasteStarsCounter, a PasteAccess) *StarsUseCase {
+	return &StarsUseCase{
+		repo:    r,
+		counter: c,
+		access:  a,
+	}
+}
+
+// Star stars a paste by the user from context and returns the number of paste stars.
+// Only authenticated users can star pastes, otherwise returns ErrUnauthorized.
+// If the paste is private and not readable by the user returns ErrPasteNotFound.
+// If the paste is expired returns ErrPasteExpired. Starring a starred paste
+// again changes nothing.
+func (uc *StarsUseCase) Star(ctx context.Context, hash string) (int, error) {
+	paste, userID, err := uc.starrable(ctx, hash)
+	if err != nil {
+		return 0, fmt.Errorf("StarsUseCase.Star: %w", err)
+	}
+
+	created, err := uc.repo.Create(ctx, hash, userID)
+	if err != nil {
+		return 0, fmt.Errorf("StarsUseCase.Star: %w", err)
+	}
+
+	n, err := uc.countStar(ctx, paste, created, 1)
+	if err != nil {
+		return 0, fmt.Errorf("StarsUseCase.Star: %w", err)
+	}
+
+	return n, nil
+}
+
+// Unstar removes a star of the user from context from a paste and returns
+// the number of paste stars. Errors are the same as in Star. Unstarring
+// a paste that is not starred changes nothing.
+func (uc *StarsUseCase) Unstar(ctx context.Context, hash string) (int, error) {
+	paste, userID, err := uc.starrable(ctx, hash)
+	if err != nil {
+		return 0, fmt.Errorf("StarsUseCase.Unstar: %w", err)
+	}
+
+	deleted, err := uc.repo.Delete(ctx, hash, userID)
+	if err != nil {
+		return 0, fmt.Errorf("StarsUseCase.Unstar: %w", err)
+	}
+
+	n, err := uc.countStar(ctx, paste, deleted, -1)
+	if err != nil {
+		return 0, fmt.Errorf("StarsUseCase.Unstar: %w", err)
+	}
+
+	return n, nil
+}
+
+// ListStarred returns a page of not expired pastes starred by the user from context,
+// most recently starred first. Only authenticated users have stars, otherwise
+// returns ErrUnauthorized.
+func (uc *StarsUseCase) ListStarred(ctx context.Context, q entity.StarListQuery) (*entity.StarsPage, error) {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return nil, ErrUnauthorized
+	}
+
+	q.UserID = userID
+
+	starred, next, err := listPage(q.Limit, func(limit int) ([]*entity.StarredPaste, error) {
+		q.Limit = limit
+
+		return uc.repo.ListByUser(ctx, q)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("StarsUseCase.ListStarred: %w", err)
+	}
+
+	page := &entity.StarsPage{Pastes: starred}
+
+	if next {
+		last := starred[len(starred)-1]
+		page.Next = &entity.PasteListCursor{
+			Sort: entity.SortStarred,
+			Key:  last.StarredAt.Format(time.RFC3339Nano),
+			Hash: last.Paste.Hash,
+		}
+	}
+
+	for _, s := range page.Pastes {
+		if s.Paste.Stars, err = starCount(ctx, uc.counter, s.Paste); err != nil {
+			return nil, fmt.Errorf("StarsUseCase.ListStarred: %w", err)
+		}
+	}
+
+	return page, nil
+}
+
+// FlushStars reconciles star counters with stars stored in database in batches.
+// Stars of users in database are the source of truth rather than the counters:
+// numbers of stars are recounted from them, stored with pastes and overwrite
+// the counters, so stars lost by the counter are restored.
+func (uc *StarsUseCase) FlushStars(ctx context.Context) error {
+	for {
+		hashes, err := uc.counter.Pending(ctx, starsFlushBatch)
+		if err != nil {
+			return fmt.Errorf("StarsUseCase.FlushStars: %w", err)
+		}
+
+		if len(hashes) == 0 {
+			return nil
+		}
+
+		stars, err := uc.repo.Reconcile(ctx, hashes)
+		if err != nil {
+			return fmt.Errorf("StarsUseCase.FlushStars: %w", err)
+		}
+
+		if err := uc.counter.Set(ctx, stars); err != nil {
+			return fmt.Errorf("StarsUseCase.FlushStars: %w", err)
+		}
+	}
+}
+
+// starrable returns a paste metadata from database and the user from context
+// if the user can star the paste.
+func (uc *StarsUseCase) starrable(ctx context.Context, hash string) (*entity.Paste, string, error) {
+	userID, ok := ctx.Value(entity.UserIDKey).(string)
+	if !ok {
+		return nil, "", ErrUnauthorized
+	}
+
+	paste, err := uc.access.Readable(ctx, hash)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return paste, userID, nil
+}
+
+// countStar adds the delta to the paste star counter if the star is changed
+// and returns the number of paste stars.
+func (uc *StarsUseCase) countStar(ctx context.Context, paste *entity.Paste, changed bool, delta int) (int, error) {
+	if !changed {
+		return starCount(ctx, uc.counter, paste)
+	}
+
+	return uc.counter.Incr(ctx, paste.Hash, paste.Stars, delta)
+}
+
+// starCount returns the number of paste stars from the counter, or stored
+// in database if the counter has none.
+func starCount(ctx context.Context, counter PasteStarsCounter, paste *entity.Paste) (int, error) {
+	n, ok, err := counter.Get(ctx, paste.Hash)
+	if err != nil {
+		return 0, err
+	}
+
+	if !ok {
+		return paste.Stars, nil
+	}
+
+	return n, nil
+}
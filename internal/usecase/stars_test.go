@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type starsMocks struct {
+	repo    *mocks.PasteStarsRepo
+	counter *mocks.PasteStarsCounter
+	pastes  *mocks.PastesRepo
+	grants  *mocks.PasteGrantsRepo
+}
+
+func newStarsUseCase(t *testing.T) (*StarsUseCase, *starsMocks) {
+	t.Helper()
+
+	m := &starsMocks{
+		repo:    mocks.NewPasteStarsRepo(t),
+		counter: mocks.NewPasteStarsCounter(t),
+		pastes:  mocks.NewPastesRepo(t),
+		grants:  mocks.NewPasteGrantsRepo(t),
+	}
+
+	return NewStars(m.repo, m.counter, NewPasteAccess(m.pastes, m.grants)), m
+}
+
+func TestStarsUseCase_Stars(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Star paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newStarsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{Hash: "test", Visibility: entity.VisibilityPublic, Stars: 2}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Create", ctx, paste.Hash, "user").
+			Once().
+			Return(true, nil)
+		m.counter.On("Incr", ctx, paste.Hash, 2, 1).
+			Once().
+			Return(3, nil)
+
+		stars, err := uc.Star(ctx, paste.Hash)
+		require.NoError(t, err)
+		require.Equal(t, 3, stars)
+	})
+
+	t.Run("Star starred paste again", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newStarsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{Hash: "test", Visibility: entity.VisibilityPublic, Stars: 2}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Create", ctx, paste.Hash, "user").
+			Once().
+			Return(false, nil)
+		m.counter.On("Get", ctx, paste.Hash).
+			Once().
+			Return(3, true, nil)
+
+		stars, err := uc.Star(ctx, paste.Hash)
+		require.NoError(t, err)
+		require.Equal(t, 3, stars)
+	})
+
+	t.Run("Star error for anonymous user", func(t *testing.T) {
+		t.Parallel()
+
+		uc, _ := newStarsUseCase(t)
+
+		_, err := uc.Star(context.Background(), "test")
+		require.ErrorIs(t, err, ErrUnauthorized)
+	})
+
+	t.Run("Star error on private paste without access", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newStarsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{
+				Hash:       "test",
+				Visibility: entity.VisibilityPrivate,
+				UserID:     sql.NullString{String: "author", Valid: true},
+			}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.grants.On("Exists", ctx, paste.Hash, "user").
+			Once().
+			Return(false, nil)
+
+		_, err := uc.Star(ctx, paste.Hash)
+		require.ErrorIs(t, err, ErrPasteNotFound)
+	})
+
+	t.Run("Unstar paste", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newStarsUseCase(t)
+			ctx   = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			paste = &entity.Paste{Hash: "test", Visibility: entity.VisibilityUnlisted, Stars: 1}
+		)
+
+		m.pastes.On("Get", ctx, paste.Hash).
+			Once().
+			Return(paste, nil)
+		m.repo.On("Delete", ctx, paste.Hash, "user").
+			Once().
+			Return(true, nil)
+		m.counter.On("Incr", ctx, paste.Hash, 1, -1).
+			Once().
+			Return(0, nil)
+
+		stars, err := uc.Unstar(ctx, paste.Hash)
+		require.NoError(t, err)
+		require.Equal(t, 0, stars)
+	})
+
+	t.Run("List starred pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m     = newStarsUseCase(t)
+			ctx       = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			starredAt = time.Date(2023, 10, 29, 20, 38, 41, 0, time.UTC)
+			starred   = []*entity.StarredPaste{
+				{Paste: &entity.Paste{Hash: "first", Stars: 1}, StarredAt: starredAt},
+				{Paste: &entity.Paste{Hash: "second", Stars: 4}, StarredAt: starredAt.Add(-time.Hour)},
+			}
+		)
+
+		m.repo.On("ListByUser", ctx, entity.StarListQuery{UserID: "user", Limit: 2}).
+			Once().
+			Return(starred, nil)
+		m.counter.On("Get", ctx, "first").
+			Once().
+			Return(2, true, nil)
+
+		page, err := uc.ListStarred(ctx, entity.StarListQuery{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page.Pastes, 1)
+		require.Equal(t, 2, page.Pastes[0].Paste.Stars)
+		require.Equal(t, &entity.PasteListCursor{
+			Sort: entity.SortStarred,
+			Key:  starredAt.Format(time.RFC3339Nano),
+			Hash: "first",
+		}, page.Next)
+	})
+
+	t.Run("Flush stars", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m = newStarsUseCase(t)
+			ctx   = context.Background()
+			stars = map[string]int{"first": 1}
+		)
+
+		m.counter.On("Pending", ctx, starsFlushBatch).
+			Once().
+			Return([]string{"first", "deleted"}, nil)
+		m.repo.On("Reconcile", ctx, []string{"first", "deleted"}).
+			Once().
+			Return(stars, nil)
+		m.counter.On("Set", ctx, stars).
+			Once().
+			Return(nil)
+		m.counter.On("Pending", ctx, starsFlushBatch).
+			Once().
+			Return([]string{}, nil)
+
+		err := uc.FlushStars(ctx)
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+)
+
+var _ Tags = (*TagsUseCase)(nil)
+
+type TagsUseCase struct {
+	repo   PasteTagsRepo
+	pastes PastesRepo
+}
+
+func NewTags(r PasteTagsRepo, p PastesRepo) *TagsUseCase {
+	return &TagsUseCase{
+		repo:   r,
+		pastes: p,
+	}
+}
+
+// ListTagged returns a page of not expired pastes with the tags visible to the user
+// from context, newest first. Anonymous users see only public pastes, authenticated
+// users also see their own pastes and private pastes they are granted access to.
+// Tags are normalized as in PastesUseCase.Create.
+func (uc *TagsUseCase) ListTagged(ctx context.Context, q entity.TagQuery) (*entity.PastesPage, error) {
+	if len(q.Tags) > 0 {
+		var err error
+		if q.Tags, err = normalizeTags(q.Tags); err != nil {
+			return nil, fmt.Errorf("TagsUseCase.ListTagged: %w", err)
+		}
+	}
+
+	q.UserID, _ = ctx.Value(entity.UserIDKey).(string)
+
+	pastes, next, err := listPage(q.Limit, func(limit int) ([]*entity.Paste, error) {
+		q.Limit = limit
+
+		return uc.pastes.ListByTags(ctx, q)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("TagsUseCase.ListTagged: %w", err)
+	}
+
+	page := &entity.PastesPage{Pastes: pastes}
+
+	if next {
+		page.Next = entity.SortCreated.Cursor(pastes[len(pastes)-1])
+	}
+
+	return page, nil
+}
+
+// Tags returns the most used tags with numbers of not expired pastes visible
+// to the user from context, as in ListTagged.
+func (uc *TagsUseCase) Tags(ctx context.Context) ([]*entity.TagCount, error) {
+	userID, _ := ctx.Value(entity.UserIDKey).(string)
+
+	counts, err := uc.repo.Counts(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("TagsUseCase.Tags: %w", err)
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/romankravchuk/pastebin/internal/entity"
+	"github.com/romankravchuk/pastebin/internal/usecase/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+type tagsMocks struct {
+	repo   *mocks.PasteTagsRepo
+	pastes *mocks.PastesRepo
+}
+
+func newTagsUseCase(t *testing.T) (*TagsUseCase, *tagsMocks) {
+	t.Helper()
+
+	m := &tagsMocks{
+		repo:   mocks.NewPasteTagsRepo(t),
+		pastes: mocks.NewPastesRepo(t),
+	}
+
+	return NewTags(m.repo, m.pastes), m
+}
+
+func TestTagsUseCase_Tags(t *testing.T) {
+	t.Parallel()
+
+	t.Run("List tagged pastes", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m   = newTagsUseCase(t)
+			ctx     = context.Background()
+			created = time.Date(2023, 10, 29, 20, 38, 41, 0, time.UTC)
+			pastes  = []*entity.Paste{
+				{Hash: "first", CreatedAt: created},
+				{Hash: "second", CreatedAt: created.Add(-time.Hour)},
+				{Hash: "third", CreatedAt: created.Add(-2 * time.Hour)},
+			}
+		)
+
+		m.pastes.On("ListByTags", ctx, entity.TagQuery{Tags: []string{"go", "k8s"}, Any: true, Limit: 3}).
+			Once().
+			Return(pastes, nil)
+
+		page, err := uc.ListTagged(ctx, entity.TagQuery{Tags: []string{"K8s", "Go"}, Any: true, Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, pastes[:2], page.Pastes)
+		require.Equal(t, &entity.PasteListCursor{Sort: entity.SortCreated, Key: "2023-10-29T19:38:41Z", Hash: "second"}, page.Next)
+	})
+
+	t.Run("List tagged pastes error on invalid tag", func(t *testing.T) {
+		t.Parallel()
+
+		uc, _ := newTagsUseCase(t)
+
+		_, err := uc.ListTagged(context.Background(), entity.TagQuery{Tags: []string{"a/b"}, Limit: 2})
+		require.ErrorIs(t, err, ErrInvalidTags)
+	})
+
+	t.Run("Get tags visible to user", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			uc, m  = newTagsUseCase(t)
+			ctx    = context.WithValue(context.Background(), entity.UserIDKey, "user")
+			counts = []*entity.TagCount{{Tag: "go", Count: 3}, {Tag: "k8s", Count: 1}}
+		)
+
+		m.repo.On("Counts", ctx, "user").
+			Once().
+			Return(counts, nil)
+
+		got, err := uc.Tags(ctx)
+		require.NoError(t, err)
+		require.Equal(t, counts, got)
+	})
+}
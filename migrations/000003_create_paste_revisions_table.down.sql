@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS paste_revisions;
+ALTER TABLE pastes DROP COLUMN IF EXISTS revision;
@@ -0,0 +1,10 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS revision integer NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS paste_revisions (
+    paste_hash varchar(8) NOT NULL REFERENCES pastes(hash) ON DELETE CASCADE,
+    revision integer NOT NULL,
+    title varchar(255) NOT NULL,
+    format varchar(255) NOT NULL,
+    created_at timestamp(0) with time zone NOT NULL,
+    PRIMARY KEY (paste_hash, revision)
+);
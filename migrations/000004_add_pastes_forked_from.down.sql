@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS pastes_forked_from_idx;
+ALTER TABLE pastes DROP COLUMN IF EXISTS forked_from;
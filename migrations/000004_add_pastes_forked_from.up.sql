@@ -0,0 +1,3 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS forked_from varchar(8) REFERENCES pastes(hash) ON DELETE SET NULL DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS pastes_forked_from_idx ON pastes (forked_from);
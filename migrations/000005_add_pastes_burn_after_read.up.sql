@@ -0,0 +1 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS burn_after_read bool NOT NULL DEFAULT false;
@@ -0,0 +1,2 @@
+ALTER TABLE pastes DROP COLUMN IF EXISTS max_views;
+ALTER TABLE pastes DROP COLUMN IF EXISTS views;
@@ -0,0 +1,2 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS views bigint NOT NULL DEFAULT 0;
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS max_views bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+UPDATE pastes SET expires_at = CURRENT_TIMESTAMP + INTERVAL '2' YEAR WHERE expires_at IS NULL;
+ALTER TABLE pastes ALTER COLUMN expires_at SET NOT NULL;
+ALTER TABLE pastes ALTER COLUMN expires_at SET DEFAULT CURRENT_TIMESTAMP + INTERVAL '2' YEAR;
@@ -0,0 +1,2 @@
+ALTER TABLE pastes ALTER COLUMN expires_at DROP DEFAULT;
+ALTER TABLE pastes ALTER COLUMN expires_at DROP NOT NULL;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS paste_files (
+    paste_hash varchar(8) NOT NULL REFERENCES pastes(hash) ON DELETE CASCADE,
+    position integer NOT NULL,
+    name varchar(255) NOT NULL,
+    format varchar(255) NOT NULL,
+    PRIMARY KEY (paste_hash, position),
+    UNIQUE (paste_hash, name)
+);
@@ -0,0 +1 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS format_confidence double precision NOT NULL DEFAULT 1;
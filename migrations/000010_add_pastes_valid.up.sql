@@ -0,0 +1 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS valid boolean NOT NULL DEFAULT true;
@@ -0,0 +1 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS converted_from varchar(8) REFERENCES pastes(hash) ON DELETE SET NULL DEFAULT NULL;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS pastes_title_trgm_idx;
+DROP TABLE IF EXISTS paste_search;
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS paste_search (
+    paste_hash varchar(8) NOT NULL PRIMARY KEY REFERENCES pastes(hash) ON DELETE CASCADE,
+    content text NOT NULL,
+    document tsvector NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS paste_search_document_idx ON paste_search USING GIN (document);
+CREATE INDEX IF NOT EXISTS pastes_title_trgm_idx ON pastes USING GIN (title gin_trgm_ops);
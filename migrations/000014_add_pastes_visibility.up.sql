@@ -0,0 +1,9 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS visibility varchar(8) NOT NULL DEFAULT 'unlisted'
+    CHECK (visibility IN ('public', 'unlisted', 'private'));
+
+CREATE TABLE IF NOT EXISTS paste_grants (
+    paste_hash varchar(8) NOT NULL REFERENCES pastes(hash) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (paste_hash, user_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS pastes_user_id_created_at_idx;
+DROP INDEX IF EXISTS pastes_public_created_at_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS pastes_public_created_at_idx ON pastes (created_at DESC, hash DESC) WHERE visibility = 'public';
+CREATE INDEX IF NOT EXISTS pastes_user_id_created_at_idx ON pastes (user_id, created_at DESC, hash DESC) WHERE visibility = 'public';
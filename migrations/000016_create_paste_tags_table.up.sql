@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS paste_tags (
+    paste_hash varchar(8) NOT NULL REFERENCES pastes(hash) ON DELETE CASCADE,
+    tag varchar(32) NOT NULL,
+    PRIMARY KEY (paste_hash, tag)
+);
+
+CREATE INDEX IF NOT EXISTS paste_tags_tag_idx ON paste_tags (tag);
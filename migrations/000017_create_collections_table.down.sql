@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS collection_grants;
+DROP TABLE IF EXISTS collection_pastes;
+DROP TABLE IF EXISTS collections;
@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS collections (
+    hash varchar(8) PRIMARY KEY,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title varchar(255) NOT NULL,
+    visibility varchar(8) NOT NULL DEFAULT 'unlisted'
+        CHECK (visibility IN ('public', 'unlisted', 'private')),
+    created_at timestamp(0) with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS collections_user_id_idx ON collections (user_id);
+
+CREATE TABLE IF NOT EXISTS collection_pastes (
+    collection_hash varchar(8) NOT NULL REFERENCES collections(hash) ON DELETE CASCADE,
+    paste_hash varchar(8) NOT NULL REFERENCES pastes(hash) ON DELETE CASCADE,
+    position integer NOT NULL,
+    PRIMARY KEY (collection_hash, paste_hash)
+);
+
+CREATE INDEX IF NOT EXISTS collection_pastes_paste_hash_idx ON collection_pastes (paste_hash);
+
+CREATE TABLE IF NOT EXISTS collection_grants (
+    collection_hash varchar(8) NOT NULL REFERENCES collections(hash) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (collection_hash, user_id)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS paste_stars;
+ALTER TABLE pastes DROP COLUMN IF EXISTS stars;
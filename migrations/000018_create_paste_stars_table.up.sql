@@ -0,0 +1,10 @@
+ALTER TABLE pastes ADD COLUMN IF NOT EXISTS stars integer NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS paste_stars (
+    paste_hash varchar(8) NOT NULL REFERENCES pastes(hash) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (paste_hash, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS paste_stars_user_id_created_at_idx ON paste_stars (user_id, created_at DESC, paste_hash DESC);
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS paste_comments (
+    id bigserial PRIMARY KEY,
+    paste_hash varchar(8) NOT NULL REFERENCES pastes(hash) ON DELETE CASCADE,
+    user_id uuid NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_id bigint REFERENCES paste_comments(id) ON DELETE CASCADE,
+    revision integer NOT NULL,
+    line_start integer NOT NULL DEFAULT 0,
+    line_end integer NOT NULL DEFAULT 0 CHECK (line_end >= line_start),
+    text text NOT NULL,
+    deleted boolean NOT NULL DEFAULT false,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS paste_comments_paste_hash_idx ON paste_comments (paste_hash, id);
+
+CREATE INDEX IF NOT EXISTS paste_comments_parent_id_idx ON paste_comments (parent_id);
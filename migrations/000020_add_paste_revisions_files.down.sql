@@ -0,0 +1 @@
+ALTER TABLE paste_revisions DROP COLUMN IF EXISTS files;
@@ -0,0 +1 @@
+ALTER TABLE paste_revisions ADD COLUMN IF NOT EXISTS files boolean NOT NULL DEFAULT false;
@@ -0,0 +1 @@
+-- Replaced snippet markers can not be restored, contents are reindexed on the next update.
@@ -0,0 +1,3 @@
+UPDATE paste_search
+SET content = translate(content, U&'\E000\E001', U&'\FFFD\FFFD')
+WHERE content ~ U&'[\E000\E001]';
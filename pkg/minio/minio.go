@@ -2,6 +2,7 @@ package minio
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -81,3 +82,18 @@ func (m *Minio) DeleteObject(ctx context.Context, bucket, object string) error {
 
 	return nil
 }
+
+// DeleteObjects deletes all objects with the prefix from the bucket.
+// Objects failed to delete do not stop deleting the rest, their errors are joined.
+func (m *Minio) DeleteObjects(ctx context.Context, bucket, prefix string) error {
+	var (
+		objects = m.c.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true})
+		errs    []error
+	)
+
+	for rerr := range m.c.RemoveObjects(ctx, bucket, objects, minio.RemoveObjectsOptions{}) {
+		errs = append(errs, fmt.Errorf("failed to delete object %q from minio bucket %q: %w", rerr.ObjectName, bucket, rerr.Err))
+	}
+
+	return errors.Join(errs...)
+}
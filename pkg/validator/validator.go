@@ -30,6 +30,13 @@ func New() (*Validator, error) {
 		return nil, err
 	}
 
+	for tag, text := range translations {
+		err = v.RegisterTranslation(tag, translator, registerTranslation(tag, text), translate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &Validator{
 		errors:     make(map[string]string),
 		validator:  v,
@@ -37,6 +44,27 @@ func New() (*Validator, error) {
 	}, nil
 }
 
+// translations are messages for tags missing in the default translations.
+var translations = map[string]string{
+	"required_without": "{0} is required if {1} is not present",
+	"excluded_with":    "{0} must not be present with {1}",
+}
+
+func registerTranslation(tag, text string) validator.RegisterTranslationsFunc {
+	return func(ut ut.Translator) error {
+		return ut.Add(tag, text, true)
+	}
+}
+
+func translate(ut ut.Translator, fe validator.FieldError) string {
+	t, err := ut.T(fe.Tag(), fe.Field(), fe.Param())
+	if err != nil {
+		return fe.Error()
+	}
+
+	return t
+}
+
 func (v *Validator) Valid(input interface{}) bool {
 	var (
 		errs validator.ValidationErrors